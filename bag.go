@@ -0,0 +1,202 @@
+package pin
+
+import "strconv"
+
+// Bag is a multiset of Identifiers, counting copies of each, such as the
+// pieces in hand of shogi or the pockets of crazyhouse.
+//
+// Bag is built on Map, so updates never allocate and iteration follows the
+// canonical order: by side, then abbreviation, then state, then terminal
+// status.
+//
+// The zero value is an empty bag ready to use.
+type Bag struct {
+	counts Map[int]
+	total  int
+}
+
+// Add inserts one copy of id into the bag.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (b *Bag) Add(id Identifier) {
+	b.add(id, 1)
+}
+
+// add inserts n copies of a valid id into the bag.
+func (b *Bag) add(id Identifier, n int) {
+	count, _ := b.counts.Get(id)
+	b.counts.Set(id, count+n)
+	b.total += n
+}
+
+// Remove deletes one copy of id from the bag and reports whether one was
+// present.
+func (b *Bag) Remove(id Identifier) bool {
+	count, ok := b.counts.Get(id)
+	if !ok {
+		return false
+	}
+
+	if count == 1 {
+		b.counts.Delete(id)
+	} else {
+		b.counts.Set(id, count-1)
+	}
+	b.total--
+	return true
+}
+
+// Count returns the number of copies of id in the bag.
+func (b *Bag) Count(id Identifier) int {
+	count, _ := b.counts.Get(id)
+	return count
+}
+
+// Total returns the number of copies in the bag, all identifiers included.
+func (b *Bag) Total() int {
+	return b.total
+}
+
+// Len returns the number of distinct identifiers in the bag.
+func (b *Bag) Len() int {
+	return b.counts.Len()
+}
+
+// Range calls fn for each distinct identifier and its count, in canonical
+// order. If fn returns false, Range stops the iteration.
+func (b *Bag) Range(fn func(id Identifier, count int) bool) {
+	b.counts.Range(fn)
+}
+
+// Subtract returns a copy of the bag with the copies of other removed,
+// leaving both operands unchanged. It is the check an engine needs when
+// validating drops from a hand.
+//
+// Returns an *UnderflowError wrapping ErrNotEnoughCopies for the first
+// identifier, in canonical order, of which other holds more copies than
+// the bag.
+func (b *Bag) Subtract(other *Bag) (Bag, error) {
+	out := *b
+
+	var err error
+	other.Range(func(id Identifier, want int) bool {
+		have := out.Count(id)
+		if have < want {
+			err = &UnderflowError{Identifier: id, Have: have, Want: want}
+			return false
+		}
+
+		if have == want {
+			out.counts.Delete(id)
+		} else {
+			out.counts.Set(id, have-want)
+		}
+		out.total -= want
+		return true
+	})
+	if err != nil {
+		return Bag{}, err
+	}
+
+	return out, nil
+}
+
+// Map returns a new bag holding the result of t for each copy in the bag,
+// leaving the bag unchanged. Copies that t maps to the same identifier add
+// up, so Map(CapturedT) turns captured pieces into a hand.
+//
+// Panics if t returns an identifier that is not valid.
+func (b *Bag) Map(t Transform) Bag {
+	var out Bag
+	b.Range(func(id Identifier, count int) bool {
+		out.add(t(id), count)
+		return true
+	})
+	return out
+}
+
+// ============================================================================
+// Serialization
+// ============================================================================
+
+// String returns the run-length form of the bag, as written by AppendTo.
+func (b *Bag) String() string {
+	return string(b.AppendTo(make([]byte, 0, b.Len()*(MaxStringLength+2))))
+}
+
+// AppendTo appends the run-length form of the bag to dst and returns the
+// result. Each distinct identifier is written once, prefixed by its count
+// in decimal when greater than one:
+//
+//	N3P2b
+//
+// Identifiers follow the canonical order, so first-player pieces come
+// before second-player pieces, and a bag always has a single form. This
+// ordering is part of the format and will not change. An empty bag
+// appends nothing.
+func (b *Bag) AppendTo(dst []byte) []byte {
+	b.Range(func(id Identifier, count int) bool {
+		if count > 1 {
+			dst = strconv.AppendInt(dst, int64(count), 10)
+		}
+		dst = id.AppendTo(dst)
+		return true
+	})
+	return dst
+}
+
+// ============================================================================
+// Parsing
+// ============================================================================
+
+// ParseBag parses the run-length form written by Bag.AppendTo, expanding
+// count prefixes:
+//
+//	ParseBag("3P2bN") // 3 P, 2 b, 1 N
+//
+// Segments may come in any order and repeat, their counts adding up. An
+// empty string is an empty bag.
+//
+// An invalid segment returns a *DecodeError with its offset, wrapping
+// ErrInvalidCount for a count that is zero, has a leading zero, or
+// overflows, and the parsing sentinels otherwise.
+func ParseBag(s string) (*Bag, error) {
+	b := new(Bag)
+
+	for i := 0; i < len(s); {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+
+		count := 1
+		if i > start {
+			n, err := strconv.ParseInt(s[start:i], 10, 32)
+			if err != nil || n == 0 || s[start] == '0' {
+				return nil, segmentError(s, start, i, ErrInvalidCount)
+			}
+			count = int(n)
+		}
+
+		id, n, err := parsePrefix(s[i:])
+		if err != nil {
+			if err == ErrEmptyInput {
+				err = ErrMustContainOneLetter
+			}
+			return nil, segmentError(s, start, min(i+n+1, len(s)), err)
+		}
+		i += n
+
+		b.add(id, count)
+	}
+
+	return b, nil
+}
+
+// segmentError returns a DecodeError for the segment s[start:end] of a
+// single-line notation, such as a bag or a FEEN string, so the column
+// follows from the offset.
+func segmentError(s string, start, end int, err error) *DecodeError {
+	tok := s[start:end]
+	return &DecodeError{Offset: int64(start), Line: 1, Column: start + 1, Token: tok[:min(len(tok), maxTokenEcho)], Err: err}
+}
@@ -0,0 +1,319 @@
+package pin
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestBagZeroValueIsEmpty(t *testing.T) {
+	var b Bag
+
+	if b.Count(MustParse("P")) != 0 || b.Total() != 0 || b.Len() != 0 {
+		t.Errorf("zero Bag = %d, %d, %d, want empty", b.Count(MustParse("P")), b.Total(), b.Len())
+	}
+	if b.Remove(MustParse("P")) {
+		t.Error("zero Bag Remove(P) = true, want false")
+	}
+}
+
+func TestBagAddRemove(t *testing.T) {
+	var b Bag
+	for _, s := range []string{"P", "P", "b", "P", "N"} {
+		b.Add(MustParse(s))
+	}
+
+	tests := []struct {
+		pin  string
+		want int
+	}{
+		{"P", 3},
+		{"b", 1},
+		{"N", 1},
+		{"p", 0},
+		{"+P", 0},
+	}
+
+	for _, tt := range tests {
+		if got := b.Count(MustParse(tt.pin)); got != tt.want {
+			t.Errorf("Count(%q) = %d, want %d", tt.pin, got, tt.want)
+		}
+	}
+	if b.Total() != 5 || b.Len() != 3 {
+		t.Errorf("Total(), Len() = %d, %d, want 5, 3", b.Total(), b.Len())
+	}
+
+	if !b.Remove(MustParse("P")) || b.Count(MustParse("P")) != 2 {
+		t.Errorf("after Remove(P), Count(P) = %d, want 2", b.Count(MustParse("P")))
+	}
+	if !b.Remove(MustParse("b")) || b.Count(MustParse("b")) != 0 || b.Len() != 2 {
+		t.Errorf("after Remove(b), Count(b) = %d, Len() = %d, want 0, 2", b.Count(MustParse("b")), b.Len())
+	}
+	if b.Remove(MustParse("b")) {
+		t.Error("Remove(b) on an absent identifier = true, want false")
+	}
+	if b.Total() != 3 {
+		t.Errorf("Total() = %d, want 3", b.Total())
+	}
+}
+
+func TestBagInvalidIdentifier(t *testing.T) {
+	var b Bag
+
+	if b.Count(Identifier{}) != 0 || b.Remove(Identifier{}) {
+		t.Error("zero Identifier reported as present")
+	}
+
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("Add(zero) panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+	b.Add(Identifier{})
+}
+
+func TestBagRangeCanonicalOrder(t *testing.T) {
+	var b Bag
+	for _, s := range []string{"p", "B", "P", "p", "+P"} {
+		b.Add(MustParse(s))
+	}
+
+	var got []string
+	b.Range(func(id Identifier, count int) bool {
+		got = append(got, id.String()+":"+strconv.Itoa(count))
+		return true
+	})
+
+	want := []string{"B:1", "P:1", "+P:1", "p:2"}
+	if len(got) != len(want) {
+		t.Fatalf("Range() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Range() = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestBagRangeStopsEarly(t *testing.T) {
+	var b Bag
+	for _, s := range []string{"A", "B", "C"} {
+		b.Add(MustParse(s))
+	}
+
+	n := 0
+	b.Range(func(Identifier, int) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("Range() visited %d identifiers, want 2", n)
+	}
+}
+
+func TestBagSubtract(t *testing.T) {
+	hand, _ := ParseBag("3P2bN")
+	drops, _ := ParseBag("2Pb")
+
+	got, err := hand.Subtract(drops)
+	if err != nil {
+		t.Fatalf("Subtract() error = %v", err)
+	}
+	if got.String() != "NPb" || got.Total() != 3 {
+		t.Errorf("Subtract() = %q (%d), want %q (3)", got.String(), got.Total(), "NPb")
+	}
+
+	// Operands are left untouched
+	if hand.String() != "N3P2b" || drops.String() != "2Pb" {
+		t.Errorf("operands modified: %q, %q", hand.String(), drops.String())
+	}
+
+	// Subtracting everything leaves an empty bag
+	if empty, err := hand.Subtract(hand); err != nil || empty.Total() != 0 || empty.Len() != 0 {
+		t.Errorf("Subtract(self) = %q, %v, want empty", empty.String(), err)
+	}
+}
+
+func TestBagSubtractUnderflow(t *testing.T) {
+	hand, _ := ParseBag("2PN")
+
+	tests := []struct {
+		drops string
+		id    string
+		have  int
+		want  int
+	}{
+		{"3P", "P", 2, 3},
+		{"r", "r", 0, 1},
+		{"2N3P", "N", 1, 2}, // first in canonical order
+	}
+
+	for _, tt := range tests {
+		drops, _ := ParseBag(tt.drops)
+		got, err := hand.Subtract(drops)
+
+		var ue *UnderflowError
+		if !errors.As(err, &ue) {
+			t.Errorf("Subtract(%q) error = %v, want *UnderflowError", tt.drops, err)
+			continue
+		}
+		if ue.Identifier != MustParse(tt.id) || ue.Have != tt.have || ue.Want != tt.want {
+			t.Errorf("Subtract(%q) error = %+v, want %s %d %d", tt.drops, ue, tt.id, tt.have, tt.want)
+		}
+		if !errors.Is(err, ErrNotEnoughCopies) || got.Total() != 0 {
+			t.Errorf("Subtract(%q) = %q, %v, want empty bag and ErrNotEnoughCopies", tt.drops, got.String(), err)
+		}
+	}
+
+	if hand.String() != "N2P" {
+		t.Errorf("failed Subtract modified the bag: %q", hand.String())
+	}
+}
+
+func TestBagMap(t *testing.T) {
+	captured, _ := ParseBag("+p2pr")
+
+	got := captured.Map(CapturedT)
+	if got.String() != "3PR" || got.Total() != 4 {
+		t.Errorf("Map(CapturedT) = %q (%d), want %q (4)", got.String(), got.Total(), "3PR")
+	}
+	if captured.String() != "2p+pr" {
+		t.Errorf("Map() modified the bag: %q", captured.String())
+	}
+
+	var empty Bag
+	if got := empty.Map(FlipT); got.Total() != 0 || got.Len() != 0 {
+		t.Errorf("empty Map(FlipT) = %q, want empty", got.String())
+	}
+
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("Map(zero) panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+	captured.Map(func(Identifier) Identifier { return Identifier{} })
+}
+
+// ============================================================================
+// Serialization Tests
+// ============================================================================
+
+func TestBagString(t *testing.T) {
+	tests := []struct {
+		pins []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"P"}, "P"},
+		{[]string{"P", "b", "P", "N", "P", "b"}, "N3P2b"},
+		{[]string{"p", "+P", "P^", "+P"}, "P^2+Pp"},
+		{[]string{"-s^", "-s^", "K"}, "K2-s^"},
+	}
+
+	for _, tt := range tests {
+		var b Bag
+		for _, s := range tt.pins {
+			b.Add(MustParse(s))
+		}
+
+		if got := b.String(); got != tt.want {
+			t.Errorf("String() for %v = %q, want %q", tt.pins, got, tt.want)
+		}
+		if got := string(b.AppendTo([]byte("x"))); got != "x"+tt.want {
+			t.Errorf("AppendTo() for %v = %q, want %q", tt.pins, got, "x"+tt.want)
+		}
+	}
+}
+
+func TestBagStringMultiDigitCount(t *testing.T) {
+	var b Bag
+	for i := 0; i < 18; i++ {
+		b.Add(MustParse("p"))
+	}
+	b.Add(MustParse("P"))
+
+	if got := b.String(); got != "P18p" {
+		t.Errorf("String() = %q, want %q", got, "P18p")
+	}
+}
+
+// ============================================================================
+// Parsing Tests
+// ============================================================================
+
+func TestParseBag(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string // canonical form
+		total int
+	}{
+		{"", "", 0},
+		{"P", "P", 1},
+		{"3P2bN", "N3P2b", 6},
+		{"N3P2b", "N3P2b", 6},
+		{"P2P", "3P", 3},
+		{"1P", "P", 1},
+		{"2+P^10-s", "2+P^10-s", 12},
+	}
+
+	for _, tt := range tests {
+		b, err := ParseBag(tt.input)
+		if err != nil {
+			t.Errorf("ParseBag(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got := b.String(); got != tt.want || b.Total() != tt.total {
+			t.Errorf("ParseBag(%q) = %q (%d), want %q (%d)", tt.input, got, b.Total(), tt.want, tt.total)
+		}
+	}
+}
+
+func TestParseBagRoundTrip(t *testing.T) {
+	var b Bag
+	for i, id := range All() {
+		for n := 0; n <= i%4; n++ {
+			b.Add(id)
+		}
+	}
+
+	got, err := ParseBag(b.String())
+	if err != nil {
+		t.Fatalf("ParseBag() error = %v", err)
+	}
+	if got.String() != b.String() || got.Total() != b.Total() {
+		t.Errorf("round trip = %q, want %q", got.String(), b.String())
+	}
+}
+
+func TestParseBagErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int64
+		token  string
+		err    error
+	}{
+		{"0P", 0, "0", ErrInvalidCount},
+		{"P03b", 1, "03", ErrInvalidCount},
+		{"99999999999P", 0, "99999999999", ErrInvalidCount},
+		{"3P2", 2, "2", ErrMustContainOneLetter},
+		{"3P2!", 2, "2!", ErrMustContainOneLetter},
+		{"N+", 1, "+", ErrMustContainOneLetter},
+		{"2P*b", 2, "*", ErrInvalidStateModifier},
+		{"P^^", 2, "^", ErrMustContainOneLetter},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseBag(tt.input)
+
+		var de *DecodeError
+		if !errors.As(err, &de) {
+			t.Errorf("ParseBag(%q) error = %v, want *DecodeError", tt.input, err)
+			continue
+		}
+		if de.Offset != tt.offset || de.Token != tt.token || !errors.Is(err, tt.err) || de.Line != 1 || de.Column != int(tt.offset)+1 {
+			t.Errorf("ParseBag(%q) error = %d %q %v, want %d %q %v",
+				tt.input, de.Offset, de.Token, de.Err, tt.offset, tt.token, tt.err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package pin
+
+import (
+	"strings"
+	"testing"
+)
+
+// Benchmark inputs cover every length and component of the grammar.
+var benchmarkInputs = []string{"K", "p", "+R", "k^", "-b^", "+P^"}
+
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse(benchmarkInputs[i%len(benchmarkInputs)])
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	inputs := make([][]byte, len(benchmarkInputs))
+	for i, s := range benchmarkInputs {
+		inputs[i] = []byte(s)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBytes(inputs[i%len(inputs)])
+	}
+}
+
+func BenchmarkParseInvalid(b *testing.B) {
+	inputs := []string{"", "1", "K+", "+K^X"}
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse(inputs[i%len(inputs)])
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = IsValid(benchmarkInputs[i%len(benchmarkInputs)])
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	id := MustParse("+K^")
+	for i := 0; i < b.N; i++ {
+		_ = id.String()
+	}
+}
+
+func BenchmarkAppendTo(b *testing.B) {
+	id := MustParse("+K^")
+	buf := make([]byte, 0, MaxStringLength)
+	for i := 0; i < b.N; i++ {
+		buf = id.AppendTo(buf[:0])
+	}
+}
+
+func BenchmarkCountValid(b *testing.B) {
+	data := []byte(strings.Repeat("K +p^ -r, q^ 1\n", 1000))
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		_, _ = CountValid(data)
+	}
+}
+
+func BenchmarkParseAllParallel(b *testing.B) {
+	data := []byte(strings.Repeat("K +p^ -r, q^ S\n", 100_000))
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseAllParallel(data)
+	}
+}
@@ -0,0 +1,69 @@
+package pin
+
+// BinarySize is the length in bytes of the binary form of an Identifier.
+//
+// 312 identifiers do not fit in a single byte, so the binary form uses two.
+const BinarySize = 2
+
+// Binary layout fields; see MarshalBinary.
+const (
+	binaryAbbrMask   = 0x1f
+	binarySideShift  = 5
+	binaryStateShift = 6
+	binaryTerminal   = 0x01
+)
+
+// MarshalBinary encodes the Identifier in BinarySize bytes, implementing
+// encoding.BinaryMarshaler.
+//
+// The layout is part of the package's compatibility guarantee: data written
+// by any version decodes identically in every later version.
+//
+//	byte 0: bits 0-4 abbreviation (0 = 'A' ... 25 = 'Z'),
+//	        bit 5 side (0 = First, 1 = Second),
+//	        bits 6-7 state (0 = Normal, 1 = Enhanced, 2 = Diminished)
+//	byte 1: bit 0 terminal status, bits 1-7 reserved (0)
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero value).
+func (id Identifier) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, BinarySize))
+}
+
+// AppendBinary appends the binary form of the Identifier to b, implementing
+// encoding.BinaryAppender. See MarshalBinary for the layout.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero value).
+func (id Identifier) AppendBinary(b []byte) ([]byte, error) {
+	if !id.isValid() {
+		return b, ErrInvalidIdentifier
+	}
+
+	b0 := byte(id.Abbr()-'A') | byte(id.Side())<<binarySideShift | byte(id.State())<<binaryStateShift
+	var b1 byte
+	if id.IsTerminal() {
+		b1 = binaryTerminal
+	}
+
+	return append(b, b0, b1), nil
+}
+
+// UnmarshalBinary decodes the binary form produced by MarshalBinary,
+// implementing encoding.BinaryUnmarshaler.
+//
+// Returns ErrInvalidBinary if data is not exactly BinarySize bytes, or
+// encodes an out-of-range attribute or a reserved bit.
+func (id *Identifier) UnmarshalBinary(data []byte) error {
+	if len(data) != BinarySize {
+		return ErrInvalidBinary
+	}
+
+	abbr := data[0] & binaryAbbrMask
+	state := State(data[0] >> binaryStateShift)
+	if abbr >= 26 || !isValidState(state) || data[1]&^binaryTerminal != 0 {
+		return ErrInvalidBinary
+	}
+
+	side := Side(data[0] >> binarySideShift & 1)
+	*id = makeIdentifier(rune('A'+abbr), side, state, data[1]&binaryTerminal != 0)
+	return nil
+}
@@ -0,0 +1,91 @@
+package pin
+
+import (
+	"encoding"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Identifier{}
+	_ encoding.BinaryUnmarshaler = (*Identifier)(nil)
+)
+
+func TestMarshalBinaryLayout(t *testing.T) {
+	tests := []struct {
+		pin  string
+		want [BinarySize]byte
+	}{
+		{"A", [2]byte{0x00, 0x00}},
+		{"K", [2]byte{0x0a, 0x00}},
+		{"k", [2]byte{0x2a, 0x00}},
+		{"+K", [2]byte{0x4a, 0x00}},
+		{"-K", [2]byte{0x8a, 0x00}},
+		{"K^", [2]byte{0x0a, 0x01}},
+		{"-z^", [2]byte{0xb9, 0x01}},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.pin).MarshalBinary()
+		if err != nil {
+			t.Errorf("MarshalBinary(%s) error = %v", tt.pin, err)
+			continue
+		}
+		if string(got) != string(tt.want[:]) {
+			t.Errorf("MarshalBinary(%s) = %#v, want %#v", tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+
+		data, err := id.MarshalBinary()
+		if err != nil {
+			t.Errorf("MarshalBinary(%s) error = %v", id, err)
+			continue
+		}
+
+		var back Identifier
+		if err := back.UnmarshalBinary(data); err != nil || back != id {
+			t.Errorf("UnmarshalBinary(%#v) = %s, %v, want %s", data, back, err, id)
+		}
+	}
+}
+
+func TestMarshalBinaryInvalid(t *testing.T) {
+	if _, err := (Identifier{}).MarshalBinary(); err != ErrInvalidIdentifier {
+		t.Errorf("MarshalBinary(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	got, err := MustParse("k^").AppendBinary([]byte{0xff})
+	if err != nil {
+		t.Fatalf("AppendBinary() error = %v", err)
+	}
+	if string(got) != "\xff\x2a\x01" {
+		t.Errorf("AppendBinary() = %#v", got)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	tests := [][]byte{
+		nil,
+		{0x0a},
+		{0x0a, 0x00, 0x00},
+		{0x1a, 0x00}, // abbreviation 26
+		{0xca, 0x00}, // state 3
+		{0x0a, 0x02}, // reserved bit
+	}
+
+	for _, data := range tests {
+		id := MustParse("K")
+		if err := id.UnmarshalBinary(data); err != ErrInvalidBinary {
+			t.Errorf("UnmarshalBinary(%#v) error = %v, want ErrInvalidBinary", data, err)
+		}
+		if id.String() != "K" {
+			t.Errorf("UnmarshalBinary(%#v) modified the receiver: %s", data, id)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package pin
+
+// Board is a rectangular board mapping squares to Identifiers, the shared
+// in-memory representation of a position's piece placement.
+//
+// Squares are addressed by row and column, both counted from 0: row 0 is
+// the first rank written in a FEEN placement (the top of a diagram), and
+// column 0 its leftmost square. Empty squares hold no identifier.
+//
+// Set and Remove update the board in place; WithPiece and WithoutPiece
+// return an updated copy and leave the board unchanged, for callers
+// treating positions as values. Create boards with NewBoard; the zero
+// value has no squares.
+type Board struct {
+	rows, cols int
+	squares    []Identifier // row-major; zero Identifiers are empty
+	pieces     int
+}
+
+// NewBoard returns an empty board of the given dimensions.
+//
+// Panics with ErrInvalidSquare if rows or cols is not positive, or if the
+// board has more than 4096 squares, the bound of FEEN placements.
+func NewBoard(rows, cols int) *Board {
+	if rows <= 0 || cols <= 0 || rows > maxPlacementSquares/cols {
+		panic(ErrInvalidSquare)
+	}
+	return &Board{rows: rows, cols: cols, squares: make([]Identifier, rows*cols)}
+}
+
+// Rows returns the number of rows of the board.
+func (b *Board) Rows() int {
+	return b.rows
+}
+
+// Cols returns the number of columns of the board.
+func (b *Board) Cols() int {
+	return b.cols
+}
+
+// Contains reports whether the square at row and col is on the board.
+func (b *Board) Contains(row, col int) bool {
+	return row >= 0 && row < b.rows && col >= 0 && col < b.cols
+}
+
+// Square returns the index of the square at row and col in row-major
+// order, as used by ZobristTable, or -1 if it is off the board.
+func (b *Board) Square(row, col int) int {
+	if !b.Contains(row, col) {
+		return -1
+	}
+	return row*b.cols + col
+}
+
+// Get returns the identifier on the square at row and col, and whether
+// one stands there. Squares off the board are empty.
+func (b *Board) Get(row, col int) (Identifier, bool) {
+	i := b.Square(row, col)
+	if i < 0 || b.squares[i].IsZero() {
+		return Identifier{}, false
+	}
+	return b.squares[i], true
+}
+
+// Set places id on the square at row and col, replacing any identifier
+// standing there.
+//
+// Panics with ErrInvalidIdentifier if id is not valid, or with
+// ErrInvalidSquare if the square is off the board.
+func (b *Board) Set(row, col int, id Identifier) {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+	i := b.mustSquare(row, col)
+	if b.squares[i].IsZero() {
+		b.pieces++
+	}
+	b.squares[i] = id
+}
+
+// Remove empties the square at row and col, returning the identifier that
+// stood there and whether there was one.
+//
+// Panics with ErrInvalidSquare if the square is off the board.
+func (b *Board) Remove(row, col int) (Identifier, bool) {
+	i := b.mustSquare(row, col)
+	id := b.squares[i]
+	if id.IsZero() {
+		return Identifier{}, false
+	}
+	b.squares[i] = Identifier{}
+	b.pieces--
+	return id, true
+}
+
+// mustSquare returns the index of a square on the board.
+func (b *Board) mustSquare(row, col int) int {
+	i := b.Square(row, col)
+	if i < 0 {
+		panic(ErrInvalidSquare)
+	}
+	return i
+}
+
+// WithPiece returns a copy of the board with id placed on the square at
+// row and col, leaving the board unchanged.
+//
+// Panics as Set does.
+func (b *Board) WithPiece(row, col int, id Identifier) *Board {
+	out := b.Clone()
+	out.Set(row, col, id)
+	return out
+}
+
+// WithoutPiece returns a copy of the board with the square at row and col
+// emptied, leaving the board unchanged.
+//
+// Panics as Remove does.
+func (b *Board) WithoutPiece(row, col int) *Board {
+	out := b.Clone()
+	out.Remove(row, col)
+	return out
+}
+
+// Clone returns a copy of the board.
+func (b *Board) Clone() *Board {
+	out := *b
+	out.squares = append([]Identifier(nil), b.squares...)
+	return &out
+}
+
+// Map returns a copy of the board with each identifier replaced by the
+// result of t, leaving the board unchanged. Empty squares stay empty, so
+// Map(FlipT) hands every piece to the other side.
+//
+// Panics as MapInPlace does.
+func (b *Board) Map(t Transform) *Board {
+	out := b.Clone()
+	out.MapInPlace(t)
+	return out
+}
+
+// MapInPlace replaces each identifier on the board with the result of t,
+// in a single pass and without allocating.
+//
+// Panics with ErrInvalidIdentifier if t returns an identifier that is not
+// valid, leaving the squares before it updated.
+func (b *Board) MapInPlace(t Transform) {
+	for i, id := range b.squares {
+		if id.IsZero() {
+			continue
+		}
+		if id = t(id); !id.isValid() {
+			panic(ErrInvalidIdentifier)
+		}
+		b.squares[i] = id
+	}
+}
+
+// Len returns the number of occupied squares.
+func (b *Board) Len() int {
+	return b.pieces
+}
+
+// Count returns the number of squares holding id.
+func (b *Board) Count(id Identifier) int {
+	if !id.isValid() {
+		return 0
+	}
+
+	n := 0
+	for _, sq := range b.squares {
+		if sq == id {
+			n++
+		}
+	}
+	return n
+}
+
+// Range calls fn for each occupied square and its identifier, in row-major
+// order. If fn returns false, Range stops the iteration.
+func (b *Board) Range(fn func(row, col int, id Identifier) bool) {
+	for i, id := range b.squares {
+		if !id.IsZero() && !fn(i/b.cols, i%b.cols, id) {
+			return
+		}
+	}
+}
+
+// Equal reports whether b and other have the same dimensions and the same
+// identifiers on the same squares.
+func (b *Board) Equal(other *Board) bool {
+	if b.rows != other.rows || b.cols != other.cols || b.pieces != other.pieces {
+		return false
+	}
+	for i, id := range b.squares {
+		if other.squares[i] != id {
+			return false
+		}
+	}
+	return true
+}
+
+// Placement returns the rows of the board as a FEEN piece placement, for
+// a GameState.
+func (b *Board) Placement() [][]Identifier {
+	squares := append([]Identifier(nil), b.squares...)
+
+	ranks := make([][]Identifier, b.rows)
+	for r := range ranks {
+		ranks[r] = squares[r*b.cols : (r+1)*b.cols : (r+1)*b.cols]
+	}
+	return ranks
+}
+
+// Board returns the piece placement of the state as a Board.
+//
+// Returns ErrInvalidPlacement if the placement is empty or its ranks do not
+// all have the same number of squares.
+func (g *GameState) Board() (*Board, error) {
+	if len(g.Placement) == 0 || len(g.Placement[0]) == 0 {
+		return nil, ErrInvalidPlacement
+	}
+
+	cols := len(g.Placement[0])
+	for _, rank := range g.Placement {
+		if len(rank) != cols {
+			return nil, ErrInvalidPlacement
+		}
+	}
+	if len(g.Placement) > maxPlacementSquares/cols {
+		return nil, ErrInvalidPlacement
+	}
+
+	b := NewBoard(len(g.Placement), cols)
+	for r, rank := range g.Placement {
+		for c, id := range rank {
+			if id.isValid() {
+				b.Set(r, c, id)
+			}
+		}
+	}
+	return b, nil
+}
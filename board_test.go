@@ -0,0 +1,220 @@
+package pin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewBoard(t *testing.T) {
+	b := NewBoard(8, 9)
+	if b.Rows() != 8 || b.Cols() != 9 || b.Len() != 0 {
+		t.Errorf("NewBoard(8, 9) = %dx%d with %d pieces, want empty 8x9", b.Rows(), b.Cols(), b.Len())
+	}
+
+	for _, dims := range [][2]int{{0, 8}, {8, 0}, {-1, 8}, {65, 64}} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrInvalidSquare {
+					t.Errorf("NewBoard(%d, %d) panic = %v, want ErrInvalidSquare", dims[0], dims[1], r)
+				}
+			}()
+			NewBoard(dims[0], dims[1])
+		}()
+	}
+}
+
+func TestBoardSetGetRemove(t *testing.T) {
+	b := NewBoard(8, 8)
+	king := MustParse("K^")
+
+	b.Set(7, 4, king)
+	if got, ok := b.Get(7, 4); !ok || got != king {
+		t.Errorf("Get(7, 4) = %v, %v, want K^, true", got, ok)
+	}
+	if _, ok := b.Get(0, 0); ok {
+		t.Error("Get(0, 0) on an empty square reported a piece")
+	}
+	if _, ok := b.Get(8, 0); ok {
+		t.Error("Get(8, 0) off the board reported a piece")
+	}
+
+	// Replacing keeps the count
+	b.Set(7, 4, MustParse("Q"))
+	if b.Len() != 1 {
+		t.Errorf("Len() after replacing = %d, want 1", b.Len())
+	}
+
+	if got, ok := b.Remove(7, 4); !ok || got != MustParse("Q") {
+		t.Errorf("Remove(7, 4) = %v, %v, want Q, true", got, ok)
+	}
+	if _, ok := b.Remove(7, 4); ok || b.Len() != 0 {
+		t.Errorf("Remove() of an empty square reported a piece, Len() = %d", b.Len())
+	}
+}
+
+func TestBoardPanics(t *testing.T) {
+	b := NewBoard(2, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+		want error
+	}{
+		{"Set off board", func() { b.Set(2, 0, MustParse("K")) }, ErrInvalidSquare},
+		{"Set negative", func() { b.Set(0, -1, MustParse("K")) }, ErrInvalidSquare},
+		{"Set zero id", func() { b.Set(0, 0, Identifier{}) }, ErrInvalidIdentifier},
+		{"Remove off board", func() { b.Remove(0, 2) }, ErrInvalidSquare},
+		{"WithPiece off board", func() { b.WithPiece(5, 5, MustParse("K")) }, ErrInvalidSquare},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s: panic = %v, want %v", tt.name, r, tt.want)
+				}
+			}()
+			tt.fn()
+		}()
+	}
+}
+
+func TestBoardImmutableUpdates(t *testing.T) {
+	b := NewBoard(3, 3)
+	b.Set(1, 1, MustParse("p"))
+
+	with := b.WithPiece(0, 0, MustParse("K"))
+	if _, ok := b.Get(0, 0); ok {
+		t.Error("WithPiece() modified the original board")
+	}
+	if got, _ := with.Get(0, 0); got != MustParse("K") || with.Len() != 2 {
+		t.Errorf("WithPiece() = %v with %d pieces, want K with 2", got, with.Len())
+	}
+
+	without := with.WithoutPiece(1, 1)
+	if with.Len() != 2 || without.Len() != 1 {
+		t.Errorf("WithoutPiece() lengths = %d, %d, want 2, 1", with.Len(), without.Len())
+	}
+	if _, ok := without.Get(1, 1); ok {
+		t.Error("WithoutPiece() left the piece")
+	}
+}
+
+func TestBoardMap(t *testing.T) {
+	b := NewBoard(2, 2)
+	b.Set(0, 0, MustParse("+p"))
+	b.Set(1, 1, MustParse("K^"))
+
+	got := b.Map(CapturedT)
+	if id, _ := got.Get(0, 0); id != MustParse("P") {
+		t.Errorf("Map(CapturedT) at 0,0 = %v, want P", id)
+	}
+	if id, _ := got.Get(1, 1); id != MustParse("k^") || got.Len() != 2 {
+		t.Errorf("Map(CapturedT) at 1,1 = %v with %d pieces, want k^ with 2", id, got.Len())
+	}
+	if id, _ := b.Get(0, 0); id != MustParse("+p") {
+		t.Errorf("Map() modified the original board: %v", id)
+	}
+
+	want := b.Map(FlipT)
+	b.MapInPlace(FlipT)
+	if !b.Equal(want) {
+		t.Error("MapInPlace(FlipT) differs from Map(FlipT)")
+	}
+	if id, _ := b.Get(0, 0); id != MustParse("+P") {
+		t.Errorf("MapInPlace(FlipT) at 0,0 = %v, want +P", id)
+	}
+
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("Map(zero) panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+	b.Map(func(Identifier) Identifier { return Identifier{} })
+}
+
+func TestBoardCountRangeEqual(t *testing.T) {
+	b := NewBoard(2, 3)
+	b.Set(0, 2, MustParse("P"))
+	b.Set(1, 0, MustParse("P"))
+	b.Set(1, 1, MustParse("k"))
+
+	if got := b.Count(MustParse("P")); got != 2 {
+		t.Errorf("Count(P) = %d, want 2", got)
+	}
+	if got := b.Count(Identifier{}); got != 0 {
+		t.Errorf("Count(zero) = %d, want 0", got)
+	}
+
+	var got []string
+	b.Range(func(row, col int, id Identifier) bool {
+		got = append(got, id.String()+"@"+string(rune('0'+row))+string(rune('0'+col)))
+		return len(got) < 2
+	})
+	if strings.Join(got, " ") != "P@02 P@10" {
+		t.Errorf("Range() = %v, want [P@02 P@10]", got)
+	}
+
+	if !b.Equal(b.Clone()) {
+		t.Error("Equal(Clone()) = false, want true")
+	}
+	if b.Equal(b.WithoutPiece(1, 1)) || b.Equal(NewBoard(3, 2)) {
+		t.Error("Equal() = true for different boards")
+	}
+	if b.Square(1, 2) != 5 || b.Square(2, 0) != -1 {
+		t.Errorf("Square() = %d, %d, want 5, -1", b.Square(1, 2), b.Square(2, 0))
+	}
+}
+
+func TestBoardFEEN(t *testing.T) {
+	g := MustParseFEEN(chessFEEN)
+
+	b, err := g.Board()
+	if err != nil {
+		t.Fatalf("Board() error = %v", err)
+	}
+	if b.Rows() != 8 || b.Cols() != 8 || b.Len() != 32 {
+		t.Errorf("Board() = %dx%d with %d pieces, want 8x8 with 32", b.Rows(), b.Cols(), b.Len())
+	}
+	if got, _ := b.Get(7, 4); got != MustParse("K^") {
+		t.Errorf("Get(7, 4) = %v, want K^", got)
+	}
+
+	// Moving a pawn on a copy, then back to FEEN
+	moved := b.WithoutPiece(6, 4).WithPiece(4, 4, MustParse("P"))
+	g.Placement = moved.Placement()
+	g.Active, g.Inactive = g.Inactive, g.Active
+	want := "rnbqk^bnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQK^BNR / chess/CHESS"
+	if got := g.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBoardFEENIrregular(t *testing.T) {
+	for _, s := range []string{"8/7 / C/c", "3/4 / C/c"} {
+		if _, err := MustParseFEEN(s).Board(); err != ErrInvalidPlacement {
+			t.Errorf("ParseFEEN(%q).Board() error = %v, want ErrInvalidPlacement", s, err)
+		}
+	}
+
+	var g GameState
+	if _, err := g.Board(); err != ErrInvalidPlacement {
+		t.Errorf("empty GameState Board() error = %v, want ErrInvalidPlacement", err)
+	}
+}
+
+func TestBoardPlacementIsCopy(t *testing.T) {
+	b := NewBoard(2, 2)
+	ranks := b.Placement()
+	ranks[0][0] = MustParse("K")
+
+	if b.Len() != 0 {
+		t.Error("modifying Placement() modified the board")
+	}
+
+	// Ranks are capped, so growing one does not overwrite the next
+	_ = append(ranks[0], MustParse("Q"))
+	if !ranks[1][0].IsZero() {
+		t.Errorf("appending to rank 0 overwrote rank 1 with %v", ranks[1][0])
+	}
+}
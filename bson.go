@@ -0,0 +1,47 @@
+package pin
+
+import "encoding/binary"
+
+// bsonTypeString is the BSON element type of UTF-8 strings.
+const bsonTypeString = 0x02
+
+// MarshalBSONValue implements bson.ValueMarshaler (MongoDB Go driver v2).
+// The Identifier is stored as a BSON string holding its PIN.
+//
+// The method only relies on the BSON wire format, so the pin package does not
+// depend on the driver; see the bsonpin module for the interface checks.
+//
+// Returns ErrInvalidIdentifier for the zero value.
+func (id Identifier) MarshalBSONValue() (byte, []byte, error) {
+	if !id.isValid() {
+		return 0, nil, ErrInvalidIdentifier
+	}
+
+	// int32 length (including the trailing NUL), bytes, NUL
+	buf := make([]byte, 4, 4+MaxStringLength+1)
+	buf = id.AppendTo(buf)
+	buf = append(buf, 0)
+	binary.LittleEndian.PutUint32(buf, uint32(len(buf)-4))
+
+	return bsonTypeString, buf, nil
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler (MongoDB Go driver v2).
+// It accepts a BSON string holding a PIN.
+func (id *Identifier) UnmarshalBSONValue(typ byte, data []byte) error {
+	if typ != bsonTypeString {
+		return ErrBSONNotString
+	}
+
+	if len(data) < 5 || data[len(data)-1] != 0 ||
+		int(binary.LittleEndian.Uint32(data)) != len(data)-4 {
+		return ErrInvalidBSONString
+	}
+
+	parsed, err := ParseBytes(data[4 : len(data)-1])
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
@@ -0,0 +1,102 @@
+package pin
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// ============================================================================
+// MarshalBSONValue Tests
+// ============================================================================
+
+func TestIdentifierMarshalBSONValue(t *testing.T) {
+	tests := []struct {
+		id   Identifier
+		want []byte
+	}{
+		{MustParse("K"), []byte{2, 0, 0, 0, 'K', 0}},
+		{MustParse("+r"), []byte{3, 0, 0, 0, '+', 'r', 0}},
+		{MustParse("-p^"), []byte{4, 0, 0, 0, '-', 'p', '^', 0}},
+	}
+
+	for _, tt := range tests {
+		typ, data, err := tt.id.MarshalBSONValue()
+		if err != nil {
+			t.Errorf("MarshalBSONValue(%s) error = %v", tt.id, err)
+			continue
+		}
+		if typ != 0x02 {
+			t.Errorf("MarshalBSONValue(%s) type = %#x, want 0x02", tt.id, typ)
+		}
+		if !bytes.Equal(data, tt.want) {
+			t.Errorf("MarshalBSONValue(%s) data = %v, want %v", tt.id, data, tt.want)
+		}
+	}
+}
+
+func TestIdentifierMarshalBSONValueZero(t *testing.T) {
+	if _, _, err := (Identifier{}).MarshalBSONValue(); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("MarshalBSONValue() error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+// ============================================================================
+// UnmarshalBSONValue Tests
+// ============================================================================
+
+func TestIdentifierUnmarshalBSONValue(t *testing.T) {
+	var id Identifier
+	if err := id.UnmarshalBSONValue(0x02, []byte{4, 0, 0, 0, '+', 'K', '^', 0}); err != nil {
+		t.Fatalf("UnmarshalBSONValue() error = %v", err)
+	}
+	if id.String() != "+K^" {
+		t.Errorf("UnmarshalBSONValue() = %q, want \"+K^\"", id.String())
+	}
+}
+
+func TestIdentifierUnmarshalBSONValueErrors(t *testing.T) {
+	tests := []struct {
+		typ  byte
+		data []byte
+		want error
+	}{
+		{0x0A, nil, ErrBSONNotString},                // null
+		{0x10, []byte{1, 0, 0, 0}, ErrBSONNotString}, // int32
+		{0x02, []byte{2, 0, 0}, ErrInvalidBSONString},
+		{0x02, []byte{2, 0, 0, 0, 'K', 'K'}, ErrInvalidBSONString}, // missing NUL
+		{0x02, []byte{3, 0, 0, 0, 'K', 0}, ErrInvalidBSONString},   // wrong length
+		{0x02, []byte{1, 0, 0, 0, 0}, ErrEmptyInput},               // empty string
+		{0x02, []byte{3, 0, 0, 0, '*', 'K', 0}, ErrInvalidStateModifier},
+	}
+
+	for _, tt := range tests {
+		id := MustParse("K")
+		err := id.UnmarshalBSONValue(tt.typ, tt.data)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("UnmarshalBSONValue(%#x, %v) error = %v, want %v", tt.typ, tt.data, err, tt.want)
+		}
+		if id.String() != "K" {
+			t.Errorf("UnmarshalBSONValue() modified receiver on error: %q", id.String())
+		}
+	}
+}
+
+func TestIdentifierBSONValueRoundTrip(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		in := fromIndex(i)
+
+		typ, data, err := in.MarshalBSONValue()
+		if err != nil {
+			t.Fatalf("MarshalBSONValue(%s) error = %v", in, err)
+		}
+
+		var out Identifier
+		if err := out.UnmarshalBSONValue(typ, data); err != nil {
+			t.Fatalf("UnmarshalBSONValue(%s) error = %v", in, err)
+		}
+		if out != in {
+			t.Errorf("round trip = %s, want %s", out, in)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Package bsonpin ties pin.Identifier to the MongoDB Go driver (v2).
+//
+// pin.Identifier implements bson.ValueMarshaler and bson.ValueUnmarshaler
+// directly, storing its canonical PIN string, so documents round-trip
+// without registering custom codecs:
+//
+//	type Position struct {
+//		Pieces []pin.Identifier `bson:"pieces"`
+//	}
+//
+// The pin package relies only on the BSON wire format and does not import
+// the driver. This package lives in its own module and pins the interface
+// contract at compile time against the actual driver.
+package bsonpin
+
+import (
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// Compile-time interface checks.
+var (
+	_ bson.ValueMarshaler   = pin.Identifier{}
+	_ bson.ValueUnmarshaler = (*pin.Identifier)(nil)
+)
@@ -0,0 +1,102 @@
+package bsonpin
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+type position struct {
+	Piece  pin.Identifier   `bson:"piece"`
+	Pieces []pin.Identifier `bson:"pieces"`
+}
+
+// ============================================================================
+// Document Round-Trip Tests
+// ============================================================================
+
+func TestDocumentRoundTrip(t *testing.T) {
+	in := position{
+		Piece:  pin.MustParse("+K^"),
+		Pieces: []pin.Identifier{pin.MustParse("p"), pin.MustParse("-R")},
+	}
+
+	data, err := bson.Marshal(in)
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+
+	var out position
+	if err := bson.Unmarshal(data, &out); err != nil {
+		t.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+
+	if out.Piece != in.Piece {
+		t.Errorf("Piece = %s, want %s", out.Piece, in.Piece)
+	}
+	if len(out.Pieces) != len(in.Pieces) {
+		t.Fatalf("len(Pieces) = %d, want %d", len(out.Pieces), len(in.Pieces))
+	}
+	for i := range in.Pieces {
+		if out.Pieces[i] != in.Pieces[i] {
+			t.Errorf("Pieces[%d] = %s, want %s", i, out.Pieces[i], in.Pieces[i])
+		}
+	}
+}
+
+func TestStoredAsCanonicalString(t *testing.T) {
+	data, err := bson.Marshal(position{Piece: pin.MustParse("+K^")})
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+
+	var raw struct {
+		Piece string `bson:"piece"`
+	}
+	if err := bson.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+	if raw.Piece != "+K^" {
+		t.Errorf("stored piece = %q, want \"+K^\"", raw.Piece)
+	}
+}
+
+// ============================================================================
+// Validation Tests
+// ============================================================================
+
+func TestUnmarshalRejectsInvalidPIN(t *testing.T) {
+	data, err := bson.Marshal(bson.D{{Key: "piece", Value: "*K"}})
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+
+	var out position
+	err = bson.Unmarshal(data, &out)
+	if !errors.Is(err, pin.ErrInvalidStateModifier) {
+		t.Errorf("bson.Unmarshal() error = %v, want ErrInvalidStateModifier", err)
+	}
+}
+
+func TestUnmarshalRejectsNonString(t *testing.T) {
+	data, err := bson.Marshal(bson.D{{Key: "piece", Value: int32(42)}})
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+
+	var out position
+	err = bson.Unmarshal(data, &out)
+	if !errors.Is(err, pin.ErrBSONNotString) {
+		t.Errorf("bson.Unmarshal() error = %v, want ErrBSONNotString", err)
+	}
+}
+
+func TestMarshalRejectsZeroIdentifier(t *testing.T) {
+	_, err := bson.Marshal(position{})
+	if !errors.Is(err, pin.ErrInvalidIdentifier) {
+		t.Errorf("bson.Marshal() error = %v, want ErrInvalidIdentifier", err)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/sashite/pin.go/v3/bsonpin
+
+go 1.25.0
+
+require (
+	github.com/sashite/pin.go/v3 v3.0.0
+	go.mongodb.org/mongo-driver/v2 v2.9.1
+)
+
+replace github.com/sashite/pin.go/v3 => ../
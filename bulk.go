@@ -0,0 +1,205 @@
+package pin
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+)
+
+// CountValid counts the valid and invalid tokens of data, separated by any
+// run of ASCII whitespace or commas as read by a Decoder.
+//
+// It scans data in place and never allocates, for data-cleaning jobs over
+// large corpora.
+func CountValid(data []byte) (valid, invalid int) {
+	for i := 0; ; {
+		start, end := scanToken(data, i)
+		if start == end {
+			return valid, invalid
+		}
+		if isValidToken(data[start:end]) {
+			valid++
+		} else {
+			invalid++
+		}
+		i = end
+	}
+}
+
+// ValidateBytes checks the tokens of data, separated as for CountValid,
+// and stops at the first invalid one.
+//
+// It returns the number of valid tokens and nil if all are valid, or the
+// number of valid tokens preceding the first invalid one and a *DecodeError
+// locating it. It does not allocate unless a token is invalid.
+func ValidateBytes(data []byte) (int, error) {
+	n := 0
+	for i := 0; ; {
+		start, end := scanToken(data, i)
+		if start == end {
+			return n, nil
+		}
+
+		tok := data[start:end]
+		if err := tokenError(tok); err != nil {
+			return n, tokenDecodeError(data, start, end, err)
+		}
+		n++
+		i = end
+	}
+}
+
+// parallelMinShard is the smallest input, in bytes, worth a worker of
+// ParseAllParallel.
+const parallelMinShard = 64 << 10
+
+// ParseAllParallel parses the tokens of data, separated as for CountValid,
+// sharding the input across GOMAXPROCS workers so that ingestion of large
+// corpora scales with cores. The identifiers are returned in input order.
+//
+// Inputs too small to shard are parsed on the calling goroutine. Each shard
+// is scanned twice, to count and then to parse its tokens, so the result is
+// allocated once and at its exact size.
+//
+// If a token is invalid, it returns a *DecodeError locating the first
+// invalid token of data.
+func ParseAllParallel(data []byte) ([]Identifier, error) {
+	return parseAllParallel(data, runtime.GOMAXPROCS(0))
+}
+
+// parseAllParallel implements ParseAllParallel with at most the given
+// number of workers.
+func parseAllParallel(data []byte, workers int) ([]Identifier, error) {
+	workers = max(1, min(workers, len(data)/parallelMinShard))
+
+	// Cut the shards at separators, so no token straddles two of them
+	bounds := make([]int, workers+1)
+	bounds[workers] = len(data)
+	for w := 1; w < workers; w++ {
+		i := max(len(data)*w/workers, bounds[w-1])
+		for i < len(data) && !isSeparator(data[i]) {
+			i++
+		}
+		bounds[w] = i
+	}
+
+	// Count the tokens of each shard, to place its output
+	offsets := make([]int, workers+1)
+	forEachShard(workers, func(w int) {
+		offsets[w+1] = countTokens(data[:bounds[w+1]], bounds[w])
+	})
+	for w := 0; w < workers; w++ {
+		offsets[w+1] += offsets[w]
+	}
+
+	ids := make([]Identifier, offsets[workers])
+	errs := make([]error, workers)
+	forEachShard(workers, func(w int) {
+		errs[w] = parseTokens(ids[offsets[w]:offsets[w+1]], data[:bounds[w+1]], bounds[w])
+	})
+
+	// Shards are in input order, so the first error is the earliest
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// forEachShard calls fn for each shard index below n, concurrently, and
+// waits for all calls to return.
+func forEachShard(n int, fn func(w int)) {
+	if n == 1 {
+		fn(0)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for w := 0; w < n; w++ {
+		go func(w int) {
+			defer wg.Done()
+			fn(w)
+		}(w)
+	}
+	wg.Wait()
+}
+
+// countTokens returns the number of tokens of data at or after i.
+func countTokens(data []byte, i int) int {
+	n := 0
+	for {
+		start, end := scanToken(data, i)
+		if start == end {
+			return n
+		}
+		n++
+		i = end
+	}
+}
+
+// parseTokens parses the tokens of data at or after i into dst, which
+// holds exactly as many elements, and returns a *DecodeError for the
+// first invalid one.
+func parseTokens(dst []Identifier, data []byte, i int) error {
+	for k := range dst {
+		start, end := scanToken(data, i)
+
+		tok := data[start:end]
+		id, err := parseToken(tok)
+		if err != nil {
+			return tokenDecodeError(data, start, end, err)
+		}
+		dst[k] = id
+		i = end
+	}
+	return nil
+}
+
+// tokenDecodeError returns a DecodeError for the token data[start:end],
+// locating its line and column. Lines are counted only on this error path,
+// so that scanning valid input stays free of the extra pass.
+func tokenDecodeError(data []byte, start, end int, err error) *DecodeError {
+	tok := data[start:end]
+	return &DecodeError{
+		Offset: int64(start),
+		Line:   bytes.Count(data[:start], []byte{'\n'}) + 1,
+		Column: start - bytes.LastIndexByte(data[:start], '\n'),
+		Token:  string(tok[:min(len(tok), maxTokenEcho)]),
+		Err:    err,
+	}
+}
+
+// scanToken returns the bounds of the first token of data at or after i;
+// start equals end if there is none.
+func scanToken(data []byte, i int) (start, end int) {
+	for i < len(data) && isSeparator(data[i]) {
+		i++
+	}
+	start = i
+	for i < len(data) && !isSeparator(data[i]) {
+		i++
+	}
+	return start, i
+}
+
+// isValidToken reports whether tok is a valid PIN string.
+func isValidToken(tok []byte) bool {
+	return tokenError(tok) == nil
+}
+
+// tokenError returns the parsing sentinel for an invalid token, or nil.
+func tokenError(tok []byte) error {
+	_, err := parseToken(tok)
+	return err
+}
+
+// parseToken parses a token, returning the parsing sentinel if invalid.
+func parseToken(tok []byte) (Identifier, error) {
+	if len(tok) > MaxStringLength {
+		return Identifier{}, ErrInputTooLong
+	}
+	id, _, err := parseBytes(tok)
+	return id, err
+}
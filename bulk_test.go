@@ -0,0 +1,175 @@
+package pin
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCountValid(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantValid   int
+		wantInvalid int
+	}{
+		{"", 0, 0},
+		{" ,\n", 0, 0},
+		{"K", 1, 0},
+		{"K +p^,-r\n\tQ", 4, 0},
+		{"K K+ 1 +K^X q", 2, 3},
+		{",,K,,", 1, 0},
+	}
+
+	for _, tt := range tests {
+		valid, invalid := CountValid([]byte(tt.input))
+		if valid != tt.wantValid || invalid != tt.wantInvalid {
+			t.Errorf("CountValid(%q) = %d, %d, want %d, %d", tt.input, valid, invalid, tt.wantValid, tt.wantInvalid)
+		}
+	}
+}
+
+func TestValidateBytes(t *testing.T) {
+	n, err := ValidateBytes([]byte("K +p^,-r\n"))
+	if n != 3 || err != nil {
+		t.Errorf("ValidateBytes() = %d, %v, want 3, nil", n, err)
+	}
+
+	n, err = ValidateBytes([]byte("K  +p^ K+ 1"))
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("ValidateBytes() error = %v, want *DecodeError", err)
+	}
+	if n != 2 || de.Offset != 7 || de.Token != "K+" || !errors.Is(err, ErrInvalidTerminalMarker) {
+		t.Errorf("ValidateBytes() = %d, %+v, want 2 and K+ at offset 7", n, de)
+	}
+}
+
+func TestValidateBytesLineColumn(t *testing.T) {
+	_, err := ValidateBytes([]byte("K q\n+p^\n  -r, K+"))
+
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Line != 3 || de.Column != 7 {
+		t.Errorf("ValidateBytes() error = %+v, want line 3, column 7", de)
+	}
+}
+
+func TestValidateBytesLongToken(t *testing.T) {
+	long := bytes.Repeat([]byte("K"), 100)
+
+	_, err := ValidateBytes(append([]byte("K "), long...))
+	var de *DecodeError
+	if !errors.As(err, &de) || !errors.Is(err, ErrInputTooLong) || de.Offset != 2 || len(de.Token) != 32 {
+		t.Errorf("ValidateBytes() error = %+v, want a truncated ErrInputTooLong at offset 2", de)
+	}
+}
+
+func TestValidateBytesMatchesDecoder(t *testing.T) {
+	input := "K +p^ , Q -k^ r"
+
+	var want int
+	d := NewDecoder(bytes.NewReader([]byte(input)))
+	for {
+		if _, err := d.Decode(); err != nil {
+			break
+		}
+		want++
+	}
+
+	if n, err := ValidateBytes([]byte(input)); n != want || err != nil {
+		t.Errorf("ValidateBytes() = %d, %v, want %d, nil", n, err, want)
+	}
+}
+
+func TestBulkValidationNoAllocs(t *testing.T) {
+	data := bytes.Repeat([]byte("K +p^ -r, q^\n"), 100)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _ = CountValid(data)
+		_, _ = ValidateBytes(data)
+	})
+	if allocs != 0 {
+		t.Errorf("CountValid/ValidateBytes allocate %v times, want 0", allocs)
+	}
+}
+
+// corpus returns a large input cycling through all identifiers, with
+// varied separators.
+func corpus(n int) []byte {
+	seps := []string{" ", "\n", ", ", "\t\t"}
+	var b bytes.Buffer
+	for i := 0; i < n; i++ {
+		b.WriteString(fromIndex(i % identifierCount).String())
+		b.WriteString(seps[i%len(seps)])
+	}
+	return b.Bytes()
+}
+
+func TestParseAllParallel(t *testing.T) {
+	data := corpus(200_000)
+
+	for _, workers := range []int{1, 2, 3, 8} {
+		ids, err := parseAllParallel(data, workers)
+		if err != nil {
+			t.Fatalf("parseAllParallel(%d workers) error = %v", workers, err)
+		}
+		if len(ids) != 200_000 {
+			t.Fatalf("parseAllParallel(%d workers) = %d identifiers, want 200000", workers, len(ids))
+		}
+		for i, id := range ids {
+			if id != fromIndex(i%identifierCount) {
+				t.Fatalf("parseAllParallel(%d workers)[%d] = %v, want %v", workers, i, id, fromIndex(i%identifierCount))
+			}
+		}
+	}
+}
+
+func TestParseAllParallelSmallInput(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{" , ", 0},
+		{"K +p^,-r\n\tQ", 4},
+	}
+
+	for _, tt := range tests {
+		ids, err := ParseAllParallel([]byte(tt.input))
+		if err != nil || len(ids) != tt.want {
+			t.Errorf("ParseAllParallel(%q) = %v, %v, want %d identifiers", tt.input, ids, err, tt.want)
+		}
+	}
+}
+
+func TestParseAllParallelReportsFirstError(t *testing.T) {
+	data := corpus(100_000)
+
+	// Invalid tokens near the end and in the middle of the input
+	late := len(data) - 40
+	for data[late-1] != ' ' {
+		late++
+	}
+	copy(data[late:], "++")
+	early := len(data) / 3
+	for data[early-1] != ' ' {
+		early++
+	}
+	copy(data[early:], "K#")
+
+	for _, workers := range []int{1, 4} {
+		_, err := parseAllParallel(data, workers)
+
+		var de *DecodeError
+		if !errors.As(err, &de) {
+			t.Fatalf("parseAllParallel(%d workers) error = %v, want *DecodeError", workers, err)
+		}
+		if de.Offset != int64(early) {
+			t.Errorf("parseAllParallel(%d workers) error at offset %d, want %d", workers, de.Offset, early)
+		}
+
+		// Same diagnosis as the sequential scan
+		if _, want := ValidateBytes(data); err.Error() != want.Error() {
+			t.Errorf("parseAllParallel(%d workers) error = %v, want %v", workers, err, want)
+		}
+	}
+}
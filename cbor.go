@@ -0,0 +1,74 @@
+package pin
+
+import "encoding/binary"
+
+// CBOR major type 3 (text string) header bytes.
+const (
+	cborTextString = 0x60
+	cborNull       = 0xf6
+	cborUndefined  = 0xf7
+)
+
+// MarshalCBOR implements cbor.Marshaler (github.com/fxamacker/cbor/v2).
+// The Identifier is encoded as a CBOR text string holding its PIN, which
+// takes 2 to 4 bytes.
+//
+// The method only relies on the CBOR wire format (RFC 8949), so the pin
+// package does not depend on the library; see the cborpin module for the
+// interface checks.
+//
+// Returns ErrInvalidIdentifier for the zero value.
+func (id Identifier) MarshalCBOR() ([]byte, error) {
+	if !id.isValid() {
+		return nil, ErrInvalidIdentifier
+	}
+
+	buf := make([]byte, 1, 1+MaxStringLength)
+	buf = id.AppendTo(buf)
+	buf[0] = cborTextString | byte(len(buf)-1)
+
+	return buf, nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler (github.com/fxamacker/cbor/v2).
+// It accepts a definite-length CBOR text string holding a PIN.
+//
+// CBOR null and undefined leave the Identifier unchanged.
+func (id *Identifier) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidCBORString
+	}
+	if len(data) == 1 && (data[0] == cborNull || data[0] == cborUndefined) {
+		return nil
+	}
+	if data[0]&0xe0 != cborTextString {
+		return ErrCBORNotString
+	}
+
+	// Argument: length inline below 24, or in the following 1, 2, 4, or 8 bytes
+	n, rest := uint64(data[0]&0x1f), data[1:]
+	switch {
+	case n < 24:
+	case n <= 27:
+		size := 1 << (n - 24)
+		if len(rest) < size {
+			return ErrInvalidCBORString
+		}
+		var arg [8]byte
+		copy(arg[8-size:], rest[:size])
+		n, rest = binary.BigEndian.Uint64(arg[:]), rest[size:]
+	default:
+		// Reserved values and indefinite-length strings
+		return ErrInvalidCBORString
+	}
+	if uint64(len(rest)) != n {
+		return ErrInvalidCBORString
+	}
+
+	parsed, err := ParseBytes(rest)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
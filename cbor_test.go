@@ -0,0 +1,122 @@
+package pin
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// ============================================================================
+// MarshalCBOR Tests
+// ============================================================================
+
+func TestIdentifierMarshalCBOR(t *testing.T) {
+	tests := []struct {
+		id   Identifier
+		want []byte
+	}{
+		{MustParse("K"), []byte{0x61, 'K'}},
+		{MustParse("+r"), []byte{0x62, '+', 'r'}},
+		{MustParse("-p^"), []byte{0x63, '-', 'p', '^'}},
+	}
+
+	for _, tt := range tests {
+		data, err := tt.id.MarshalCBOR()
+		if err != nil {
+			t.Errorf("MarshalCBOR(%s) error = %v", tt.id, err)
+			continue
+		}
+		if !bytes.Equal(data, tt.want) {
+			t.Errorf("MarshalCBOR(%s) = %x, want %x", tt.id, data, tt.want)
+		}
+	}
+}
+
+func TestIdentifierMarshalCBORZero(t *testing.T) {
+	if _, err := (Identifier{}).MarshalCBOR(); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("MarshalCBOR() error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+// ============================================================================
+// UnmarshalCBOR Tests
+// ============================================================================
+
+func TestIdentifierUnmarshalCBOR(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{[]byte{0x63, '+', 'K', '^'}, "+K^"},
+		{[]byte{0x78, 0x02, '-', 'p'}, "-p"},             // 1-byte length
+		{[]byte{0x79, 0x00, 0x01, 'r'}, "r"},             // 2-byte length
+		{[]byte{0x7a, 0x00, 0x00, 0x00, 0x01, 'B'}, "B"}, // 4-byte length
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		if err := id.UnmarshalCBOR(tt.data); err != nil {
+			t.Errorf("UnmarshalCBOR(%x) error = %v", tt.data, err)
+			continue
+		}
+		if id.String() != tt.want {
+			t.Errorf("UnmarshalCBOR(%x) = %q, want %q", tt.data, id.String(), tt.want)
+		}
+	}
+}
+
+func TestIdentifierUnmarshalCBORNull(t *testing.T) {
+	for _, data := range [][]byte{{0xf6}, {0xf7}} {
+		id := MustParse("K")
+		if err := id.UnmarshalCBOR(data); err != nil {
+			t.Errorf("UnmarshalCBOR(%x) error = %v", data, err)
+		}
+		if id.String() != "K" {
+			t.Errorf("UnmarshalCBOR(%x) changed the Identifier to %q", data, id.String())
+		}
+	}
+}
+
+func TestIdentifierUnmarshalCBORErrors(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want error
+	}{
+		{nil, ErrInvalidCBORString},
+		{[]byte{0x18, 0x2a}, ErrCBORNotString},         // unsigned integer
+		{[]byte{0x42, 'K', '^'}, ErrCBORNotString},     // byte string
+		{[]byte{0x62, 'K'}, ErrInvalidCBORString},      // truncated
+		{[]byte{0x61, 'K', 'K'}, ErrInvalidCBORString}, // trailing data
+		{[]byte{0x79, 0x00}, ErrInvalidCBORString},     // truncated length
+		{[]byte{0x7f, 0x61, 'K', 0xff}, ErrInvalidCBORString},
+		{[]byte{0x60}, ErrEmptyInput},
+		{[]byte{0x64, '+', 'K', '^', '^'}, ErrInputTooLong},
+		{[]byte{0x62, '*', 'K'}, ErrInvalidStateModifier},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		if err := id.UnmarshalCBOR(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("UnmarshalCBOR(%x) error = %v, want %v", tt.data, err, tt.want)
+		}
+	}
+}
+
+func TestIdentifierCBORRoundTrip(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		want := fromIndex(i)
+
+		data, err := want.MarshalCBOR()
+		if err != nil {
+			t.Fatalf("MarshalCBOR(%s) error = %v", want, err)
+		}
+
+		var got Identifier
+		if err := got.UnmarshalCBOR(data); err != nil {
+			t.Fatalf("UnmarshalCBOR(%x) error = %v", data, err)
+		}
+		if got != want {
+			t.Errorf("round trip of %s = %s", want, got)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Package cborpin ties pin.Identifier to github.com/fxamacker/cbor/v2.
+//
+// pin.Identifier implements cbor.Marshaler and cbor.Unmarshaler directly,
+// encoding its PIN as a CBOR text string of 2 to 4 bytes, so binary game
+// state snapshots embed identifiers without registering anything:
+//
+//	type Snapshot struct {
+//		Pieces []pin.Identifier `cbor:"1,keyasint"`
+//	}
+//
+// The pin package relies only on the CBOR wire format and does not import
+// the library. This package lives in its own module and pins the interface
+// contract at compile time against the actual library.
+package cborpin
+
+import (
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// Compile-time interface checks.
+var (
+	_ cbor.Marshaler   = pin.Identifier{}
+	_ cbor.Unmarshaler = (*pin.Identifier)(nil)
+)
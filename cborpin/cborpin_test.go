@@ -0,0 +1,104 @@
+package cborpin
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+type snapshot struct {
+	Piece  pin.Identifier   `cbor:"1,keyasint"`
+	Pieces []pin.Identifier `cbor:"2,keyasint"`
+}
+
+// ============================================================================
+// Round-Trip Tests
+// ============================================================================
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	in := snapshot{
+		Piece:  pin.MustParse("+K^"),
+		Pieces: []pin.Identifier{pin.MustParse("p"), pin.MustParse("-R")},
+	}
+
+	data, err := cbor.Marshal(in)
+	if err != nil {
+		t.Fatalf("cbor.Marshal() error = %v", err)
+	}
+
+	var out snapshot
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatalf("cbor.Unmarshal() error = %v", err)
+	}
+
+	if out.Piece != in.Piece {
+		t.Errorf("Piece = %s, want %s", out.Piece, in.Piece)
+	}
+	if len(out.Pieces) != len(in.Pieces) {
+		t.Fatalf("len(Pieces) = %d, want %d", len(out.Pieces), len(in.Pieces))
+	}
+	for i := range in.Pieces {
+		if out.Pieces[i] != in.Pieces[i] {
+			t.Errorf("Pieces[%d] = %s, want %s", i, out.Pieces[i], in.Pieces[i])
+		}
+	}
+}
+
+func TestEncodedAsTextString(t *testing.T) {
+	data, err := cbor.Marshal(pin.MustParse("+K^"))
+	if err != nil {
+		t.Fatalf("cbor.Marshal() error = %v", err)
+	}
+	if want := []byte{0x63, '+', 'K', '^'}; !bytes.Equal(data, want) {
+		t.Errorf("cbor.Marshal() = %x, want %x", data, want)
+	}
+
+	var s string
+	if err := cbor.Unmarshal(data, &s); err != nil {
+		t.Fatalf("cbor.Unmarshal() error = %v", err)
+	}
+	if s != "+K^" {
+		t.Errorf("decoded string = %q, want \"+K^\"", s)
+	}
+}
+
+// ============================================================================
+// Validation Tests
+// ============================================================================
+
+func TestUnmarshalRejectsInvalidPIN(t *testing.T) {
+	data, err := cbor.Marshal("*K")
+	if err != nil {
+		t.Fatalf("cbor.Marshal() error = %v", err)
+	}
+
+	var id pin.Identifier
+	err = cbor.Unmarshal(data, &id)
+	if !errors.Is(err, pin.ErrInvalidStateModifier) {
+		t.Errorf("cbor.Unmarshal() error = %v, want ErrInvalidStateModifier", err)
+	}
+}
+
+func TestUnmarshalRejectsNonString(t *testing.T) {
+	data, err := cbor.Marshal(42)
+	if err != nil {
+		t.Fatalf("cbor.Marshal() error = %v", err)
+	}
+
+	var id pin.Identifier
+	err = cbor.Unmarshal(data, &id)
+	if !errors.Is(err, pin.ErrCBORNotString) {
+		t.Errorf("cbor.Unmarshal() error = %v, want ErrCBORNotString", err)
+	}
+}
+
+func TestMarshalRejectsZeroIdentifier(t *testing.T) {
+	_, err := cbor.Marshal(snapshot{})
+	if !errors.Is(err, pin.ErrInvalidIdentifier) {
+		t.Errorf("cbor.Marshal() error = %v, want ErrInvalidIdentifier", err)
+	}
+}
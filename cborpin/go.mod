@@ -0,0 +1,12 @@
+module github.com/sashite/pin.go/v3/cborpin
+
+go 1.21
+
+require (
+	github.com/fxamacker/cbor/v2 v2.7.0
+	github.com/sashite/pin.go/v3 v3.0.0
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
+
+replace github.com/sashite/pin.go/v3 => ../
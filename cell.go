@@ -0,0 +1,232 @@
+package pin
+
+import "strconv"
+
+// maxCellIndex bounds the file and rank indices of a Cell, as FEEN
+// placements bound the squares of a board.
+const maxCellIndex = maxPlacementSquares
+
+// Cell is the coordinate of a square in the CELL notation, such as "e4":
+// a file, written as lowercase letters, followed by a rank, written as a
+// positive decimal number.
+//
+// File and Rank are counted from 0: file "a" is 0, "z" is 25, "aa" is 26,
+// and so on, while rank "1" is 0. As in chess diagrams, files run from the
+// left of the board and ranks from its bottom, so that the first player
+// sees cell "a1" in the bottom-left corner. Board converts cells to its
+// rows and columns with RowCol and CellAt.
+//
+// See https://sashite.dev/specs/cell/1.0.0/ for the specification.
+//
+// Cell is an immutable value type, comparable with ==.
+type Cell struct {
+	File, Rank int
+}
+
+// NewCell creates the Cell of the given file and rank indices.
+//
+// Panics with ErrInvalidCell if an index is negative or not below 4096.
+func NewCell(file, rank int) Cell {
+	c := Cell{File: file, Rank: rank}
+	if !c.isValid() {
+		panic(ErrInvalidCell)
+	}
+	return c
+}
+
+// isValid reports whether the indices of c are in range.
+func (c Cell) isValid() bool {
+	return c.File >= 0 && c.File < maxCellIndex && c.Rank >= 0 && c.Rank < maxCellIndex
+}
+
+// ParseCell converts a CELL string, such as "e4" or "aa10", into a Cell.
+//
+// Returns a *SyntaxError wrapping ErrInvalidCell if s is not lowercase
+// letters followed by a positive number without leading zeros, or if an
+// index is not below 4096.
+func ParseCell(s string) (Cell, error) {
+	file, n := parseCellLetters(s)
+	if n == 0 {
+		return Cell{}, newSyntaxError(s, 0, ErrInvalidCell)
+	}
+	if file < 0 {
+		return Cell{}, newSyntaxError(s, n-1, ErrInvalidCell)
+	}
+
+	rank, m := parseCellNumber(s[n:])
+	if rank < 0 || n+m != len(s) {
+		return Cell{}, newSyntaxError(s, n+m, ErrInvalidCell)
+	}
+
+	return Cell{File: file, Rank: rank}, nil
+}
+
+// MustParseCell is like ParseCell but panics on error.
+// Use for constants or trusted input.
+func MustParseCell(s string) Cell {
+	c, err := ParseCell(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// parseCellLetters parses the lowercase letters at the start of s as a
+// zero-based index, "a" being 0 and "aa" 26. It returns the index, or -1
+// if it is too large, and the number of letters.
+func parseCellLetters(s string) (index, n int) {
+	v := 0
+	for n < len(s) && s[n] >= 'a' && s[n] <= 'z' {
+		v = v*26 + int(s[n]-'a') + 1
+		n++
+		if v > maxCellIndex {
+			return -1, n
+		}
+	}
+	return v - 1, n
+}
+
+// parseCellNumber parses the positive decimal number at the start of s as a
+// zero-based index, "1" being 0. It returns the index, or -1 if the number
+// is missing, zero, has a leading zero, or is too large, and the number of
+// digits.
+func parseCellNumber(s string) (index, n int) {
+	v := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		v = v*10 + int(s[n]-'0')
+		n++
+		if v > maxCellIndex {
+			return -1, n - 1
+		}
+	}
+	if n == 0 || v == 0 || s[0] == '0' {
+		return -1, 0
+	}
+	return v - 1, n
+}
+
+// appendCellLetters appends the letters of the zero-based index i, or '?'
+// if i is negative.
+func appendCellLetters(dst []byte, i int) []byte {
+	if i < 0 {
+		return append(dst, '?')
+	}
+
+	// 14 letters hold any int: 26^14 exceeds 2^64
+	var buf [14]byte
+	n := len(buf)
+	for v := uint64(i) + 1; v > 0; v = (v - 1) / 26 {
+		n--
+		buf[n] = byte('a' + (v-1)%26)
+	}
+	return append(dst, buf[n:]...)
+}
+
+// String returns the CELL string of the cell, such as "e4".
+func (c Cell) String() string {
+	return string(c.AppendTo(make([]byte, 0, 8)))
+}
+
+// AppendTo appends the CELL string of the cell to dst and returns the
+// result.
+//
+// File and Rank may be set to any value, so AppendTo never panics: indices
+// of 4096 or more are written in full, and a negative index as '?'. Such
+// strings are not valid CELL and ParseCell rejects them.
+func (c Cell) AppendTo(dst []byte) []byte {
+	dst = appendCellLetters(dst, c.File)
+	if c.Rank < 0 {
+		return append(dst, '?')
+	}
+	return strconv.AppendUint(dst, uint64(c.Rank)+1, 10)
+}
+
+// MarshalText returns the CELL string, implementing encoding.TextMarshaler.
+//
+// Returns ErrInvalidCell if an index is negative or not below 4096.
+func (c Cell) MarshalText() ([]byte, error) {
+	if !c.isValid() {
+		return nil, ErrInvalidCell
+	}
+	return c.AppendTo(nil), nil
+}
+
+// UnmarshalText parses a CELL string, implementing encoding.TextUnmarshaler.
+func (c *Cell) UnmarshalText(text []byte) error {
+	parsed, err := ParseCell(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+	return nil
+}
+
+// ============================================================================
+// Board coordinates
+// ============================================================================
+
+// RowCol returns the row and column of the board at cell c, counting rows
+// from the top as Board does. The square may be off the board; check with
+// Contains.
+func (b *Board) RowCol(c Cell) (row, col int) {
+	return b.rows - 1 - c.Rank, c.File
+}
+
+// CellAt returns the cell of the square at row and col.
+func (b *Board) CellAt(row, col int) Cell {
+	return Cell{File: col, Rank: b.rows - 1 - row}
+}
+
+// ContainsCell reports whether cell c is on the board.
+func (b *Board) ContainsCell(c Cell) bool {
+	return b.Contains(b.RowCol(c))
+}
+
+// ParseCell converts a cell of the board into a Cell, accepting both the
+// CELL form, such as "e4", and the form of shogi, such as "5c", where files
+// are numbered from 1 at the right of the board and ranks lettered from
+// "a" at its top.
+//
+// Returns a *SyntaxError wrapping ErrInvalidCell if s is in neither form,
+// or ErrInvalidSquare if the cell is off the board.
+func (b *Board) ParseCell(s string) (Cell, error) {
+	var c Cell
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		file, n := parseCellNumber(s)
+		if file < 0 {
+			return Cell{}, newSyntaxError(s, n, ErrInvalidCell)
+		}
+		row, m := parseCellLetters(s[n:])
+		if row < 0 || m == 0 || n+m != len(s) {
+			return Cell{}, newSyntaxError(s, n+m, ErrInvalidCell)
+		}
+		c = b.CellAt(row, b.cols-1-file)
+	} else {
+		var err error
+		if c, err = ParseCell(s); err != nil {
+			return Cell{}, err
+		}
+	}
+
+	if !b.ContainsCell(c) {
+		return Cell{}, newSyntaxError(s, 0, ErrInvalidSquare)
+	}
+	return c, nil
+}
+
+// GetCell is like Get, for the square at cell c.
+func (b *Board) GetCell(c Cell) (Identifier, bool) {
+	return b.Get(b.RowCol(c))
+}
+
+// SetCell is like Set, for the square at cell c.
+func (b *Board) SetCell(c Cell, id Identifier) {
+	row, col := b.RowCol(c)
+	b.Set(row, col, id)
+}
+
+// RemoveCell is like Remove, for the square at cell c.
+func (b *Board) RemoveCell(c Cell) (Identifier, bool) {
+	return b.Remove(b.RowCol(c))
+}
@@ -0,0 +1,224 @@
+package pin
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseCell(t *testing.T) {
+	tests := []struct {
+		input      string
+		file, rank int
+	}{
+		{"a1", 0, 0},
+		{"e4", 4, 3},
+		{"i10", 8, 9},
+		{"z26", 25, 25},
+		{"aa1", 26, 0},
+		{"az1", 51, 0},
+		{"ba1", 52, 0},
+		{"fan4096", 4095, 4095},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseCell(tt.input)
+		if err != nil {
+			t.Errorf("ParseCell(%q) error = %v", tt.input, err)
+			continue
+		}
+		if c.File != tt.file || c.Rank != tt.rank {
+			t.Errorf("ParseCell(%q) = %d, %d, want %d, %d", tt.input, c.File, c.Rank, tt.file, tt.rank)
+		}
+		if got := c.String(); got != tt.input {
+			t.Errorf("ParseCell(%q).String() = %q", tt.input, got)
+		}
+	}
+}
+
+func TestParseCellErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{"", 0},
+		{"4e", 0},
+		{"E4", 0},
+		{"e", 1},
+		{"e0", 1},
+		{"e04", 1},
+		{"e4x", 2},
+		{"e4A", 2},
+		{"fao1", 2},
+		{"a4097", 4},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseCell(tt.input)
+
+		var se *SyntaxError
+		if !errors.As(err, &se) || !errors.Is(err, ErrInvalidCell) {
+			t.Errorf("ParseCell(%q) error = %v, want *SyntaxError wrapping ErrInvalidCell", tt.input, err)
+			continue
+		}
+		if se.Offset != tt.offset {
+			t.Errorf("ParseCell(%q) offset = %d, want %d", tt.input, se.Offset, tt.offset)
+		}
+	}
+}
+
+func TestNewCell(t *testing.T) {
+	if got := NewCell(4, 3); got != MustParseCell("e4") {
+		t.Errorf("NewCell(4, 3) = %v, want e4", got)
+	}
+
+	for _, idx := range [][2]int{{-1, 0}, {0, -1}, {4096, 0}, {0, 4096}} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrInvalidCell {
+					t.Errorf("NewCell(%d, %d) panic = %v, want ErrInvalidCell", idx[0], idx[1], r)
+				}
+			}()
+			NewCell(idx[0], idx[1])
+		}()
+	}
+}
+
+func TestCellText(t *testing.T) {
+	text, err := MustParseCell("h8").MarshalText()
+	if err != nil || string(text) != "h8" {
+		t.Errorf("MarshalText() = %q, %v, want h8", text, err)
+	}
+
+	var c Cell
+	if err := c.UnmarshalText([]byte("b12")); err != nil || c != NewCell(1, 11) {
+		t.Errorf("UnmarshalText(b12) = %v, %v, want b12", c, err)
+	}
+	if err := c.UnmarshalText([]byte("12b")); !errors.Is(err, ErrInvalidCell) {
+		t.Errorf("UnmarshalText(12b) error = %v, want ErrInvalidCell", err)
+	}
+}
+
+func TestCellOutOfRange(t *testing.T) {
+	tests := []struct {
+		cell Cell
+		want string
+	}{
+		{Cell{File: 500000, Rank: 1}, "abkpu2"},
+		{Cell{File: math.MaxInt64, Rank: math.MaxInt64}, "crpxnlskvljfhh9223372036854775808"},
+		{Cell{File: -1, Rank: 3}, "?4"},
+		{Cell{File: 2, Rank: -7}, "c?"},
+		{Cell{File: math.MinInt64, Rank: math.MinInt64}, "??"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cell.String(); got != tt.want {
+			t.Errorf("Cell%+v.String() = %q, want %q", tt.cell, got, tt.want)
+		}
+		if _, err := ParseCell(tt.cell.String()); err == nil {
+			t.Errorf("ParseCell(%q) accepted an out-of-range cell", tt.cell.String())
+		}
+		if text, err := tt.cell.MarshalText(); err != ErrInvalidCell {
+			t.Errorf("Cell%+v.MarshalText() = %q, %v, want ErrInvalidCell", tt.cell, text, err)
+		}
+	}
+
+	var v struct{ At Cell }
+	v.At = Cell{File: -1}
+	if _, err := json.Marshal(v); !errors.Is(err, ErrInvalidCell) {
+		t.Errorf("json.Marshal(invalid cell) error = %v, want ErrInvalidCell", err)
+	}
+}
+
+func TestBoardRowCol(t *testing.T) {
+	b := NewBoard(8, 8)
+
+	if row, col := b.RowCol(MustParseCell("e1")); row != 7 || col != 4 {
+		t.Errorf("RowCol(e1) = %d, %d, want 7, 4", row, col)
+	}
+	if got := b.CellAt(0, 0); got != MustParseCell("a8") {
+		t.Errorf("CellAt(0, 0) = %v, want a8", got)
+	}
+	if !b.ContainsCell(MustParseCell("h8")) || b.ContainsCell(MustParseCell("i1")) || b.ContainsCell(MustParseCell("a9")) {
+		t.Error("ContainsCell() disagrees with the 8x8 bounds")
+	}
+
+	// Every square maps back to itself
+	for row := 0; row < b.Rows(); row++ {
+		for col := 0; col < b.Cols(); col++ {
+			if r, c := b.RowCol(b.CellAt(row, col)); r != row || c != col {
+				t.Errorf("RowCol(CellAt(%d, %d)) = %d, %d", row, col, r, c)
+			}
+		}
+	}
+}
+
+func TestBoardParseCell(t *testing.T) {
+	b, _ := MustParseFEEN(shogiFEEN).Board()
+
+	tests := []struct {
+		input string
+		piece string
+	}{
+		{"e1", "K^"},
+		{"e9", "k^"},
+		{"5i", "K^"},
+		{"5a", "k^"},
+		{"8b", "r"},
+		{"2h", "R"},
+		{"9i", "L"},
+	}
+
+	for _, tt := range tests {
+		c, err := b.ParseCell(tt.input)
+		if err != nil {
+			t.Errorf("ParseCell(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got, _ := b.GetCell(c); got != MustParse(tt.piece) {
+			t.Errorf("GetCell(%q) = %v, want %s", tt.input, got, tt.piece)
+		}
+	}
+
+	errs := []struct {
+		input string
+		err   error
+	}{
+		{"", ErrInvalidCell},
+		{"5", ErrInvalidCell},
+		{"05c", ErrInvalidCell},
+		{"5c3", ErrInvalidCell},
+		{"5C", ErrInvalidCell},
+		{"j1", ErrInvalidSquare},
+		{"a10", ErrInvalidSquare},
+		{"10a", ErrInvalidSquare},
+		{"1j", ErrInvalidSquare},
+	}
+
+	for _, tt := range errs {
+		if _, err := b.ParseCell(tt.input); !errors.Is(err, tt.err) {
+			t.Errorf("ParseCell(%q) error = %v, want %v", tt.input, err, tt.err)
+		}
+	}
+}
+
+func TestBoardCellUpdates(t *testing.T) {
+	b := NewBoard(8, 8)
+	e4 := MustParseCell("e4")
+
+	b.SetCell(e4, MustParse("P"))
+	if got, ok := b.Get(4, 4); !ok || got != MustParse("P") {
+		t.Errorf("Get(4, 4) after SetCell(e4) = %v, %v, want P, true", got, ok)
+	}
+	if got, ok := b.RemoveCell(e4); !ok || got != MustParse("P") || b.Len() != 0 {
+		t.Errorf("RemoveCell(e4) = %v, %v, want P, true", got, ok)
+	}
+
+	defer func() {
+		if r := recover(); r != ErrInvalidSquare {
+			t.Errorf("SetCell(i9) panic = %v, want ErrInvalidSquare", r)
+		}
+	}()
+	b.SetCell(MustParseCell("i9"), MustParse("K"))
+}
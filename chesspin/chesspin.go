@@ -0,0 +1,129 @@
+// Package chesspin converts between the pieces and boards of
+// github.com/notnil/chess and those of pin, so programs built on that
+// library interoperate with PIN and FEEN without boilerplate:
+//
+//	id, _ := chesspin.FromPiece(chess.WhiteKnight) // N
+//	b := chesspin.FromBoard(game.Position().Board())
+//
+// White is the first side and black the second. Kings are terminal pieces,
+// as in FEEN, and squares map to pin cells of the same name, "e4" to "e4".
+//
+// This package lives in its own module so that the pin package itself keeps
+// no third-party dependencies.
+package chesspin
+
+import (
+	"github.com/notnil/chess"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// boardSize is the number of ranks and files of a chess board.
+const boardSize = 8
+
+// abbrs lists the abbreviations of pin.ChessProfile, indexed by chess piece
+// type.
+var abbrs = [...]rune{
+	chess.King:   'K',
+	chess.Queen:  'Q',
+	chess.Rook:   'R',
+	chess.Bishop: 'B',
+	chess.Knight: 'N',
+	chess.Pawn:   'P',
+}
+
+// FromColor returns the side of a chess color, and false for chess.NoColor.
+func FromColor(c chess.Color) (pin.Side, bool) {
+	switch c {
+	case chess.White:
+		return pin.First, true
+	case chess.Black:
+		return pin.Second, true
+	default:
+		return 0, false
+	}
+}
+
+// ToColor returns the chess color of a side, or chess.NoColor if the side
+// is invalid.
+func ToColor(side pin.Side) chess.Color {
+	switch side {
+	case pin.First:
+		return chess.White
+	case pin.Second:
+		return chess.Black
+	default:
+		return chess.NoColor
+	}
+}
+
+// FromPiece returns the identifier of a chess piece, and false for
+// chess.NoPiece. Pieces of a given type and color are built with
+// chess.NewPiece.
+func FromPiece(p chess.Piece) (pin.Identifier, bool) {
+	side, ok := FromColor(p.Color())
+	if !ok {
+		return pin.Identifier{}, false
+	}
+
+	abbr := abbrs[p.Type()]
+	return pin.NewIdentifierWithOptions(abbr, side, pin.Normal, abbr == 'K'), true
+}
+
+// ToPiece returns the chess piece of an identifier. States and terminal
+// markers are dropped, as chess.Piece cannot record them.
+//
+// Returns pin.ErrInvalidIdentifier if id is not valid, or
+// pin.ErrAbbrNotInProfile if it is outside pin.ChessProfile.
+func ToPiece(id pin.Identifier) (chess.Piece, error) {
+	if id.IsZero() {
+		return chess.NoPiece, pin.ErrInvalidIdentifier
+	}
+
+	for t, abbr := range abbrs {
+		if abbr != 0 && abbr == id.Abbr() {
+			return chess.NewPiece(chess.PieceType(t), ToColor(id.Side())), nil
+		}
+	}
+	return chess.NoPiece, pin.ErrAbbrNotInProfile
+}
+
+// FromBoard returns the pin board of a chess board, 8 rows by 8 columns,
+// rank 8 on row 0 as in FEN.
+func FromBoard(b *chess.Board) *pin.Board {
+	out := pin.NewBoard(boardSize, boardSize)
+	for sq, p := range b.SquareMap() {
+		if id, ok := FromPiece(p); ok {
+			out.SetCell(pin.NewCell(int(sq.File()), int(sq.Rank())), id)
+		}
+	}
+	return out
+}
+
+// ToBoard returns the chess board of a pin board.
+//
+// Returns pin.ErrInvalidSquare if the board is not 8 by 8, or the errors of
+// ToPiece for a piece chess cannot hold.
+func ToBoard(b *pin.Board) (*chess.Board, error) {
+	if b.Rows() != boardSize || b.Cols() != boardSize {
+		return nil, pin.ErrInvalidSquare
+	}
+
+	m := make(map[chess.Square]chess.Piece, b.Len())
+
+	var err error
+	b.Range(func(row, col int, id pin.Identifier) bool {
+		var p chess.Piece
+		if p, err = ToPiece(id); err != nil {
+			return false
+		}
+		c := b.CellAt(row, col)
+		m[chess.NewSquare(chess.File(c.File), chess.Rank(c.Rank))] = p
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return chess.NewBoard(m), nil
+}
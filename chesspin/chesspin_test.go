@@ -0,0 +1,116 @@
+package chesspin
+
+import (
+	"testing"
+
+	"github.com/notnil/chess"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+const startFEEN = "rnbqk^bnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQK^BNR / CHESS/chess"
+
+func TestColors(t *testing.T) {
+	if side, ok := FromColor(chess.White); !ok || side != pin.First {
+		t.Errorf("FromColor(White) = %v, %v, want First, true", side, ok)
+	}
+	if side, ok := FromColor(chess.Black); !ok || side != pin.Second {
+		t.Errorf("FromColor(Black) = %v, %v, want Second, true", side, ok)
+	}
+	if _, ok := FromColor(chess.NoColor); ok {
+		t.Error("FromColor(NoColor) = true, want false")
+	}
+
+	if ToColor(pin.First) != chess.White || ToColor(pin.Second) != chess.Black || ToColor(pin.Side(2)) != chess.NoColor {
+		t.Error("ToColor() does not map First to White and Second to Black")
+	}
+}
+
+func TestPieces(t *testing.T) {
+	tests := []struct {
+		piece chess.Piece
+		pin   string
+	}{
+		{chess.WhiteKing, "K^"},
+		{chess.BlackKing, "k^"},
+		{chess.WhiteQueen, "Q"},
+		{chess.BlackRook, "r"},
+		{chess.WhiteBishop, "B"},
+		{chess.BlackKnight, "n"},
+		{chess.WhitePawn, "P"},
+		{chess.BlackPawn, "p"},
+	}
+
+	for _, tt := range tests {
+		id, ok := FromPiece(tt.piece)
+		if !ok || id != pin.MustParse(tt.pin) {
+			t.Errorf("FromPiece(%v) = %v, %v, want %s", tt.piece, id, ok, tt.pin)
+		}
+		if p, err := ToPiece(id); err != nil || p != tt.piece {
+			t.Errorf("ToPiece(%s) = %v, %v, want %v", tt.pin, p, err, tt.piece)
+		}
+	}
+
+	if _, ok := FromPiece(chess.NoPiece); ok {
+		t.Error("FromPiece(NoPiece) = true, want false")
+	}
+}
+
+func TestToPieceErrors(t *testing.T) {
+	if p, err := ToPiece(pin.MustParse("+R")); err != nil || p != chess.WhiteRook {
+		t.Errorf("ToPiece(+R) = %v, %v, want WhiteRook", p, err)
+	}
+	if _, err := ToPiece(pin.MustParse("S")); err != pin.ErrAbbrNotInProfile {
+		t.Errorf("ToPiece(S) error = %v, want ErrAbbrNotInProfile", err)
+	}
+	if _, err := ToPiece(pin.Identifier{}); err != pin.ErrInvalidIdentifier {
+		t.Errorf("ToPiece(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestFromBoard(t *testing.T) {
+	game := chess.NewGame()
+	if err := game.MoveStr("e4"); err != nil {
+		t.Fatal(err)
+	}
+
+	b := FromBoard(game.Position().Board())
+
+	want, _ := pin.MustParseFEEN(startFEEN).Board()
+	want.RemoveCell(pin.MustParseCell("e2"))
+	want.SetCell(pin.MustParseCell("e4"), pin.MustParse("P"))
+	if !b.Equal(want) {
+		t.Errorf("FromBoard() =\n%s\nwant\n%s", b.RenderASCII(), want.RenderASCII())
+	}
+}
+
+func TestToBoard(t *testing.T) {
+	b, _ := pin.MustParseFEEN(startFEEN).Board()
+
+	cb, err := ToBoard(b)
+	if err != nil {
+		t.Fatalf("ToBoard() error = %v", err)
+	}
+	if got, want := cb.String(), chess.NewGame().Position().Board().String(); got != want {
+		t.Errorf("ToBoard() = %s, want %s", got, want)
+	}
+	if cb.Piece(chess.E1) != chess.WhiteKing || cb.Piece(chess.D8) != chess.BlackQueen {
+		t.Errorf("ToBoard() e1, d8 = %v, %v, want WhiteKing, BlackQueen", cb.Piece(chess.E1), cb.Piece(chess.D8))
+	}
+
+	if !FromBoard(cb).Equal(b) {
+		t.Error("FromBoard(ToBoard(b)) != b")
+	}
+}
+
+func TestToBoardErrors(t *testing.T) {
+	if _, err := ToBoard(pin.NewBoard(9, 9)); err != pin.ErrInvalidSquare {
+		t.Errorf("ToBoard(9x9) error = %v, want ErrInvalidSquare", err)
+	}
+
+	b := pin.NewBoard(8, 8)
+	b.SetCell(pin.MustParseCell("a1"), pin.MustParse("S"))
+	if _, err := ToBoard(b); err != pin.ErrAbbrNotInProfile {
+		t.Errorf("ToBoard(shogi piece) error = %v, want ErrAbbrNotInProfile", err)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/sashite/pin.go/v3/chesspin
+
+go 1.21
+
+require (
+	github.com/notnil/chess v1.10.0
+	github.com/sashite/pin.go/v3 v3.0.0
+)
+
+replace github.com/sashite/pin.go/v3 => ../
@@ -0,0 +1,192 @@
+// Command pin is a command-line companion to the pin package.
+//
+// Usage:
+//
+//	pin table [-profile name] [-format markdown|html]
+//
+// The table command prints every PIN identifier, or the subset allowed by a
+// built-in profile, with its description and, for profiles, its piece name
+// and glyph. The table is generated from the package's own data, so it can
+// be embedded in documentation without drifting.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+
+	pin "github.com/sashite/pin.go/v3"
+)
+
+// profiles lists the built-in profiles selectable with -profile.
+var profiles = []*pin.Profile{
+	pin.ChessProfile,
+	pin.ShogiProfile,
+	pin.XiangqiProfile,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	switch args[0] {
+	case "table":
+		return runTable(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "pin: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+}
+
+// usage prints the command summary.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  pin table [-profile name] [-format markdown|html]")
+}
+
+// ============================================================================
+// Table Command
+// ============================================================================
+
+// row is a line of the identifier table.
+type row struct {
+	pin         string
+	glyph       string
+	name        string
+	description string
+}
+
+// runTable executes the table command.
+func runTable(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("table", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	profileName := fs.String("profile", "", "restrict the table to a built-in profile ("+profileNames()+")")
+	format := fs.String("format", "markdown", "output format: markdown or html")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var profile *pin.Profile
+	if *profileName != "" {
+		profile = lookupProfile(*profileName)
+		if profile == nil {
+			fmt.Fprintf(stderr, "pin: unknown profile %q (want %s)\n", *profileName, profileNames())
+			return 2
+		}
+	}
+
+	rows := tableRows(profile)
+
+	switch *format {
+	case "markdown", "md":
+		writeMarkdown(stdout, rows, profile != nil)
+	case "html":
+		writeHTML(stdout, rows, profile != nil)
+	default:
+		fmt.Fprintf(stderr, "pin: unknown format %q (want markdown or html)\n", *format)
+		return 2
+	}
+
+	return 0
+}
+
+// lookupProfile returns the built-in profile with the given name, or nil.
+func lookupProfile(name string) *pin.Profile {
+	for _, p := range profiles {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// profileNames returns the names of the built-in profiles, comma-separated.
+func profileNames() string {
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// tableRows returns the rows for every identifier allowed by profile, in
+// canonical order (side, abbreviation, state, terminal status).
+func tableRows(profile *pin.Profile) []row {
+	var rows []row
+	for _, side := range []pin.Side{pin.First, pin.Second} {
+		for abbr := 'A'; abbr <= 'Z'; abbr++ {
+			if !profile.AllowsAbbr(abbr) {
+				continue
+			}
+			for _, state := range []pin.State{pin.Normal, pin.Enhanced, pin.Diminished} {
+				for _, terminal := range []bool{false, true} {
+					id := pin.NewIdentifierWithOptions(abbr, side, state, terminal)
+					rows = append(rows, row{
+						pin:         id.String(),
+						glyph:       profile.Glyph(id),
+						name:        profile.PieceName(id),
+						description: id.Describe(),
+					})
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// writeMarkdown writes rows as a Markdown table.
+func writeMarkdown(w io.Writer, rows []row, named bool) {
+	if named {
+		fmt.Fprintln(w, "| PIN | Glyph | Name | Description |")
+		fmt.Fprintln(w, "|-----|-------|------|-------------|")
+	} else {
+		fmt.Fprintln(w, "| PIN | Description |")
+		fmt.Fprintln(w, "|-----|-------------|")
+	}
+
+	for _, r := range rows {
+		if named {
+			fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n", r.pin, r.glyph, r.name, r.description)
+		} else {
+			fmt.Fprintf(w, "| `%s` | %s |\n", r.pin, r.description)
+		}
+	}
+}
+
+// writeHTML writes rows as an HTML table.
+func writeHTML(w io.Writer, rows []row, named bool) {
+	fmt.Fprintln(w, "<table>")
+	fmt.Fprintln(w, "  <thead>")
+	if named {
+		fmt.Fprintln(w, "    <tr><th>PIN</th><th>Glyph</th><th>Name</th><th>Description</th></tr>")
+	} else {
+		fmt.Fprintln(w, "    <tr><th>PIN</th><th>Description</th></tr>")
+	}
+	fmt.Fprintln(w, "  </thead>")
+	fmt.Fprintln(w, "  <tbody>")
+
+	for _, r := range rows {
+		fmt.Fprintf(w, "    <tr><td><code>%s</code></td>", html.EscapeString(r.pin))
+		if named {
+			fmt.Fprintf(w, "<td>%s</td><td>%s</td>", html.EscapeString(r.glyph), html.EscapeString(r.name))
+		}
+		fmt.Fprintf(w, "<td>%s</td></tr>\n", html.EscapeString(r.description))
+	}
+
+	fmt.Fprintln(w, "  </tbody>")
+	fmt.Fprintln(w, "</table>")
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunTableMarkdown(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"table"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run(table) = %d, stderr = %q", code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+
+	// Header, separator, and one row per identifier
+	if len(lines) != 2+312 {
+		t.Fatalf("run(table) printed %d lines, want %d", len(lines), 2+312)
+	}
+	if lines[0] != "| PIN | Description |" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if lines[2] != "| `A` | first normal A |" {
+		t.Errorf("first row = %q", lines[2])
+	}
+	if lines[len(lines)-1] != "| `-z^` | second diminished Z terminal |" {
+		t.Errorf("last row = %q", lines[len(lines)-1])
+	}
+}
+
+func TestRunTableProfile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"table", "-profile", "chess"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run(table -profile chess) = %d, stderr = %q", code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+
+	// 6 abbreviations, 2 sides, 3 states, 2 terminal statuses
+	if len(lines) != 2+72 {
+		t.Fatalf("run(table -profile chess) printed %d lines, want %d", len(lines), 2+72)
+	}
+	if !strings.Contains(stdout.String(), "| `k^` | ♚ | King | second normal K terminal |") {
+		t.Errorf("output is missing the black king row:\n%s", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "`S`") {
+		t.Error("output contains an identifier outside the chess profile")
+	}
+}
+
+func TestRunTableHTML(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"table", "-profile", "shogi", "-format", "html"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run(table -format html) = %d, stderr = %q", code, stderr.String())
+	}
+
+	out := stdout.String()
+	if !strings.HasPrefix(out, "<table>\n") || !strings.HasSuffix(out, "</table>\n") {
+		t.Errorf("output is not an HTML table:\n%s", out)
+	}
+	if !strings.Contains(out, "<tr><td><code>+P</code></td><td>と</td><td>Tokin</td><td>first enhanced P</td></tr>") {
+		t.Errorf("output is missing the tokin row:\n%s", out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"frobnicate"},
+		{"table", "-profile", "go"},
+		{"table", "-format", "pdf"},
+		{"table", "-bogus"},
+	}
+
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, &stdout, &stderr); code != 2 {
+			t.Errorf("run(%q) = %d, want 2", args, code)
+		}
+		if stderr.Len() == 0 {
+			t.Errorf("run(%q) wrote nothing to stderr", args)
+		}
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"help"}, &stdout, &stderr); code != 0 {
+		t.Errorf("run(help) = %d, want 0", code)
+	}
+	if !strings.Contains(stdout.String(), "pin table") {
+		t.Errorf("run(help) output = %q", stdout.String())
+	}
+}
@@ -0,0 +1,74 @@
+package pin
+
+// CodeCount is the number of valid identifiers, and so of compact codes:
+// codes range from 0 to CodeCount-1.
+const CodeCount = identifierCount
+
+// Code returns the compact code of the Identifier, a small integer in the
+// range [0, CodeCount) suited to indexing arrays, bitboards, and
+// transposition tables, or to database storage.
+//
+// Codes follow canonical order: by side, then abbreviation, then state, then
+// terminal status, so that
+//
+//	code = ((side*26 + abbr-'A')*3 + state)*2 + terminal
+//
+// with First = 0, Normal = 0, Enhanced = 1, Diminished = 2. The mapping is
+// part of the package's compatibility guarantee: it never changes between
+// versions.
+//
+// Panics with ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value).
+func (id Identifier) Code() uint16 {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+	return uint16(id.index())
+}
+
+// FromCode returns the Identifier with the given compact code.
+// Returns ErrInvalidCode if code is not below CodeCount.
+func FromCode(code uint16) (Identifier, error) {
+	if code >= CodeCount {
+		return Identifier{}, ErrInvalidCode
+	}
+	return fromIndex(int(code)), nil
+}
+
+// All returns every valid identifier in canonical order, so that All()[c]
+// has code c. The slice is newly allocated and may be modified by the
+// caller.
+func All() []Identifier {
+	// Built afresh rather than copied from the interned table, which
+	// callers of Intern could have modified
+	ids := make([]Identifier, identifierCount)
+	for i := range ids {
+		ids[i] = fromIndex(i)
+	}
+	return ids
+}
+
+// interned holds the canonical instance of every valid identifier, by code.
+var interned = func() (table [identifierCount]Identifier) {
+	for i := range table {
+		table[i] = fromIndex(i)
+	}
+	return table
+}()
+
+// Intern returns the canonical pointer to the Identifier: equal identifiers
+// yield the same pointer, so APIs that need pointer identity (caches,
+// sync.Map keys, C interop) can compare identifiers by address.
+//
+// The pointer is read-only: the value it points to is shared by the whole
+// process, and writing through it would change what Intern returns for
+// every caller. Copy the value before modifying it.
+//
+// Panics with ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value).
+func (id Identifier) Intern() *Identifier {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+	return &interned[id.index()]
+}
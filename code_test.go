@@ -0,0 +1,140 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCodeRoundTrip(t *testing.T) {
+	for code := uint16(0); code < CodeCount; code++ {
+		id, err := FromCode(code)
+		if err != nil {
+			t.Fatalf("FromCode(%d) error = %v", code, err)
+		}
+		if got := id.Code(); got != code {
+			t.Errorf("FromCode(%d).Code() = %d", code, got)
+		}
+	}
+}
+
+// The mapping is stable across versions; these values must never change.
+func TestCodeStableValues(t *testing.T) {
+	tests := []struct {
+		pin  string
+		want uint16
+	}{
+		{"A", 0},
+		{"A^", 1},
+		{"+A", 2},
+		{"-A^", 5},
+		{"K", 60},
+		{"+K^", 63},
+		{"Z", 150},
+		{"-Z^", 155},
+		{"a", 156},
+		{"k", 216},
+		{"-z^", 311},
+	}
+
+	for _, tt := range tests {
+		if got := MustParse(tt.pin).Code(); got != tt.want {
+			t.Errorf("MustParse(%q).Code() = %d, want %d", tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestFromCodeOutOfRange(t *testing.T) {
+	for _, code := range []uint16{CodeCount, CodeCount + 1, 0xffff} {
+		if _, err := FromCode(code); !errors.Is(err, ErrInvalidCode) {
+			t.Errorf("FromCode(%d) error = %v, want ErrInvalidCode", code, err)
+		}
+	}
+}
+
+func TestCodePanicsOnZeroValue(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+	Identifier{}.Code()
+	t.Error("Code() did not panic")
+}
+
+func TestAll(t *testing.T) {
+	ids := All()
+	if len(ids) != CodeCount {
+		t.Fatalf("len(All()) = %d, want %d", len(ids), CodeCount)
+	}
+
+	seen := make(map[string]bool)
+	for i, id := range ids {
+		if got := id.Code(); got != uint16(i) {
+			t.Errorf("All()[%d].Code() = %d", i, got)
+		}
+		seen[id.String()] = true
+	}
+	if len(seen) != CodeCount {
+		t.Errorf("All() has %d distinct identifiers, want %d", len(seen), CodeCount)
+	}
+}
+
+func TestAllReturnsCopy(t *testing.T) {
+	All()[0] = Identifier{}
+	if All()[0].IsZero() {
+		t.Error("All() shares its slice between calls")
+	}
+}
+
+func TestIntern(t *testing.T) {
+	for _, id := range All() {
+		p := id.Intern()
+		if *p != id {
+			t.Errorf("%v.Intern() points to %v", id, *p)
+		}
+		if q := MustParse(id.String()).Intern(); q != p {
+			t.Errorf("%v.Intern() returned distinct pointers", id)
+		}
+	}
+
+	if MustParse("K").Intern() == MustParse("k").Intern() {
+		t.Error("K and k share an interned pointer")
+	}
+}
+
+func TestInternWriteDoesNotReachAll(t *testing.T) {
+	id := MustParse("K")
+	p := id.Intern()
+
+	// Simulate a caller breaking the read-only contract, then restore
+	*p = MustParse("q")
+	defer func() { *p = id }()
+
+	if got := All()[id.Code()]; got != id {
+		t.Errorf("All()[%d] = %v after a write through Intern, want %v", id.Code(), got, id)
+	}
+	if got, err := FromCode(id.Code()); err != nil || got != id {
+		t.Errorf("FromCode(%d) = %v, %v after a write through Intern, want %v", id.Code(), got, err, id)
+	}
+}
+
+func TestInternNoAllocs(t *testing.T) {
+	id := MustParse("+K^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = id.Intern()
+	})
+	if allocs != 0 {
+		t.Errorf("Intern allocates %v times, want 0", allocs)
+	}
+}
+
+func TestInternPanicsOnZeroValue(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+	Identifier{}.Intern()
+	t.Error("Intern() did not panic")
+}
@@ -0,0 +1,77 @@
+package pin
+
+import "sync/atomic"
+
+// ConcurrentSet is a set of Identifiers safe for concurrent use without locks.
+//
+// It has the same layout as Set, one bit per valid identifier, but every
+// word is updated with atomic operations, so goroutines sharing piece
+// availability state (e.g., in a parallel search) never block each other.
+//
+// The zero value is an empty set ready to use. A ConcurrentSet must not be
+// copied after first use.
+type ConcurrentSet struct {
+	bits [setWords]atomic.Uint64
+}
+
+// Add inserts id into the set and reports whether it was absent.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (s *ConcurrentSet) Add(id Identifier) bool {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+
+	i := id.index()
+	word, mask := &s.bits[i/64], uint64(1)<<(i%64)
+	for {
+		old := word.Load()
+		if old&mask != 0 {
+			return false
+		}
+		if word.CompareAndSwap(old, old|mask) {
+			return true
+		}
+	}
+}
+
+// Remove deletes id from the set and reports whether it was present.
+func (s *ConcurrentSet) Remove(id Identifier) bool {
+	if !id.isValid() {
+		return false
+	}
+
+	i := id.index()
+	word, mask := &s.bits[i/64], uint64(1)<<(i%64)
+	for {
+		old := word.Load()
+		if old&mask == 0 {
+			return false
+		}
+		if word.CompareAndSwap(old, old&^mask) {
+			return true
+		}
+	}
+}
+
+// Contains reports whether id is in the set.
+func (s *ConcurrentSet) Contains(id Identifier) bool {
+	if !id.isValid() {
+		return false
+	}
+
+	i := id.index()
+	return s.bits[i/64].Load()&(1<<(i%64)) != 0
+}
+
+// Snapshot returns the current contents of the set as a Set.
+//
+// Each word of the set is read atomically, but updates made concurrently
+// with Snapshot may or may not be reflected in the result.
+func (s *ConcurrentSet) Snapshot() Set {
+	var out Set
+	for w := range s.bits {
+		out.bits[w] = s.bits[w].Load()
+	}
+	return out
+}
@@ -0,0 +1,123 @@
+package pin
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSetAddRemoveContains(t *testing.T) {
+	var s ConcurrentSet
+	k := MustParse("K^")
+
+	if s.Contains(k) {
+		t.Error("zero ConcurrentSet Contains(K^) = true, want false")
+	}
+	if !s.Add(k) {
+		t.Error("Add(K^) = false on first insertion, want true")
+	}
+	if s.Add(k) {
+		t.Error("Add(K^) = true on second insertion, want false")
+	}
+	if !s.Contains(k) {
+		t.Error("Contains(K^) = false after Add, want true")
+	}
+	if !s.Remove(k) {
+		t.Error("Remove(K^) = false on present element, want true")
+	}
+	if s.Remove(k) {
+		t.Error("Remove(K^) = true on absent element, want false")
+	}
+	if s.Contains(k) {
+		t.Error("Contains(K^) = true after Remove, want false")
+	}
+}
+
+func TestConcurrentSetInvalidIdentifier(t *testing.T) {
+	var s ConcurrentSet
+
+	if s.Contains(Identifier{}) {
+		t.Error("Contains(zero) = true, want false")
+	}
+	if s.Remove(Identifier{}) {
+		t.Error("Remove(zero) = true, want false")
+	}
+
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("Add(zero) panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+	s.Add(Identifier{})
+}
+
+func TestConcurrentSetSnapshot(t *testing.T) {
+	var s ConcurrentSet
+	for _, pin := range []string{"k", "+K", "K", "-z^"} {
+		s.Add(MustParse(pin))
+	}
+
+	snap := s.Snapshot()
+	s.Remove(MustParse("K"))
+
+	got := snap.SortedSlice()
+	want := []string{"K", "+K", "k", "-z^"}
+	if len(got) != len(want) {
+		t.Fatalf("Snapshot() = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i].String() != want[i] {
+			t.Errorf("Snapshot()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestConcurrentSetParallel(t *testing.T) {
+	var (
+		s     ConcurrentSet
+		wg    sync.WaitGroup
+		added [8]int
+	)
+
+	// Every goroutine races to add every identifier; each must be won once
+	for g := 0; g < len(added); g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < identifierCount; i++ {
+				if s.Add(fromIndex(i)) {
+					added[g]++
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range added {
+		total += n
+	}
+	if total != identifierCount {
+		t.Errorf("successful Add() calls = %d, want %d", total, identifierCount)
+	}
+	if n := len(s.Snapshot().SortedSlice()); n != identifierCount {
+		t.Errorf("Snapshot() has %d elements, want %d", n, identifierCount)
+	}
+
+	// Concurrent removals of distinct halves leave the set empty
+	for half := 0; half < 2; half++ {
+		wg.Add(1)
+		go func(half int) {
+			defer wg.Done()
+			for i := half; i < identifierCount; i += 2 {
+				if !s.Remove(fromIndex(i)) {
+					t.Errorf("Remove(%s) = false, want true", fromIndex(i))
+				}
+			}
+		}(half)
+	}
+	wg.Wait()
+
+	if n := len(s.Snapshot().SortedSlice()); n != 0 {
+		t.Errorf("Snapshot() has %d elements after removals, want 0", n)
+	}
+}
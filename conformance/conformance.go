@@ -0,0 +1,124 @@
+// Package conformance runs PIN test vectors against the pin package and
+// reports every divergence, so the implementation can be checked against
+// the vectors of the specification.
+//
+// A vector file is a JSON object listing valid and invalid inputs:
+//
+//	{
+//	  "valid": [
+//	    {"input": "+K^", "expected": {"abbr": "K", "side": "first", "state": "enhanced", "terminal": true}}
+//	  ],
+//	  "invalid": [
+//	    {"input": "K+", "error": "invalid_terminal_marker"}
+//	  ]
+//	}
+//
+// The expected identifier uses the object form of pin.IdentifierObject; the
+// optional error is a code as returned by pin.ErrorCode. Bundled returns the
+// vectors shipped with this package.
+package conformance
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+//go:embed vectors.json
+var bundled []byte
+
+// Vectors is a set of PIN test vectors.
+type Vectors struct {
+	Valid   []ValidCase   `json:"valid"`
+	Invalid []InvalidCase `json:"invalid"`
+}
+
+// ValidCase is an input that must parse to Expected and format back to
+// itself.
+type ValidCase struct {
+	Input    string               `json:"input"`
+	Expected pin.IdentifierObject `json:"expected"`
+}
+
+// InvalidCase is an input that must be rejected, with the error code Error
+// if it is not empty.
+type InvalidCase struct {
+	Input string `json:"input"`
+	Error string `json:"error,omitempty"`
+}
+
+// Divergence reports a vector the implementation does not satisfy.
+type Divergence struct {
+	// Input is the input of the vector.
+	Input string
+	// Want and Got describe the expected and actual outcomes.
+	Want, Got string
+}
+
+// String returns a one-line description of the divergence.
+func (d Divergence) String() string {
+	return fmt.Sprintf("%q: want %s, got %s", d.Input, d.Want, d.Got)
+}
+
+// Load decodes vectors from r. Unknown fields of the file and its cases are
+// rejected, so that a misspelled key cannot silently disable a check.
+func Load(r io.Reader) (*Vectors, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var v Vectors
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("conformance: %w", err)
+	}
+	return &v, nil
+}
+
+// Bundled returns the vectors shipped with this package.
+func Bundled() *Vectors {
+	v, err := Load(bytes.NewReader(bundled))
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Run checks every vector against pin.Parse and Identifier.String, and
+// returns the divergences in vector order, or nil if there are none.
+func (v *Vectors) Run() []Divergence {
+	var out []Divergence
+
+	for _, c := range v.Valid {
+		want := pin.Identifier(c.Expected)
+
+		id, err := pin.Parse(c.Input)
+		switch {
+		case err != nil:
+			out = append(out, Divergence{c.Input, want.Describe(), "error " + pin.ErrorCode(err)})
+		case id != want:
+			out = append(out, Divergence{c.Input, want.Describe(), id.Describe()})
+		case id.String() != c.Input:
+			out = append(out, Divergence{c.Input, "String() " + c.Input, "String() " + id.String()})
+		}
+	}
+
+	for _, c := range v.Invalid {
+		want := "an error"
+		if c.Error != "" {
+			want = "error " + c.Error
+		}
+
+		id, err := pin.Parse(c.Input)
+		switch {
+		case err == nil:
+			out = append(out, Divergence{c.Input, want, id.Describe()})
+		case c.Error != "" && pin.ErrorCode(err) != c.Error:
+			out = append(out, Divergence{c.Input, want, "error " + pin.ErrorCode(err)})
+		}
+	}
+
+	return out
+}
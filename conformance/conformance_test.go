@@ -0,0 +1,61 @@
+package conformance
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBundledVectorsPass(t *testing.T) {
+	v := Bundled()
+	if len(v.Valid) != 312 {
+		t.Errorf("valid vectors = %d, want 312", len(v.Valid))
+	}
+	if len(v.Invalid) == 0 {
+		t.Error("no invalid vectors")
+	}
+
+	for _, d := range v.Run() {
+		t.Errorf("divergence: %s", d)
+	}
+}
+
+func TestRunReportsDivergences(t *testing.T) {
+	v, err := Load(strings.NewReader(`{
+		"valid": [
+			{"input": "K", "expected": {"abbr": "K", "side": "first"}},
+			{"input": "+K", "expected": {"abbr": "K", "side": "second", "state": "enhanced"}},
+			{"input": "K+", "expected": {"abbr": "K", "side": "first"}}
+		],
+		"invalid": [
+			{"input": "K^"},
+			{"input": "*K", "error": "invalid_terminal_marker"},
+			{"input": "", "error": "empty_input"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	got := v.Run()
+	want := []string{
+		`"+K": want second enhanced K, got first enhanced K`,
+		`"K+": want first normal K, got error invalid_terminal_marker`,
+		`"K^": want an error, got first normal K terminal`,
+		`"*K": want error invalid_terminal_marker, got error invalid_state_modifier`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Run() = %v, want %d divergences", got, len(want))
+	}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Errorf("divergence %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoadRejectsUnknownFields(t *testing.T) {
+	_, err := Load(strings.NewReader(`{"valid": [{"inptu": "K"}]}`))
+	if err == nil || !strings.HasPrefix(err.Error(), "conformance: ") {
+		t.Errorf("Load() error = %v, want a conformance error", err)
+	}
+}
@@ -6,6 +6,8 @@
 // See https://sashite.dev/specs/pin/1.0.0/ for the specification.
 package pin
 
+import "strings"
+
 // Side represents the piece side (First or Second).
 type Side uint8
 
@@ -32,8 +34,22 @@ const (
 const (
 	// MaxStringLength is the maximum length of a valid PIN string.
 	MaxStringLength = 3
+
+	// MaxQuotedLength is the maximum length of a quoted PIN string, as
+	// written by AppendQuoted.
+	MaxQuotedLength = MaxStringLength + 2
 )
 
+// MaxEncodedLen returns the maximum length of n PIN strings joined by
+// single-byte separators, as written by AppendAllTo, so fixed buffers can
+// be sized up front. It returns 0 for n <= 0.
+func MaxEncodedLen(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return n*(MaxStringLength+1) - 1
+}
+
 // Formatting constants (internal use)
 const (
 	enhancedPrefix   = '+'
@@ -67,6 +83,38 @@ func (s State) String() string {
 	}
 }
 
+// ParseSide parses a side name, case-insensitively: "first" or "second",
+// or the chess-style "w"/"white" and "b"/"black".
+//
+// Returns ErrInvalidSide for any other string.
+func ParseSide(s string) (Side, error) {
+	switch strings.ToLower(s) {
+	case "first", "w", "white":
+		return First, nil
+	case "second", "b", "black":
+		return Second, nil
+	default:
+		return 0, ErrInvalidSide
+	}
+}
+
+// ParseState parses a state name, case-insensitively: "normal", "enhanced",
+// or "diminished", or the PIN modifiers "+" and "-".
+//
+// Returns ErrInvalidState for any other string.
+func ParseState(s string) (State, error) {
+	switch strings.ToLower(s) {
+	case "normal":
+		return Normal, nil
+	case "enhanced", "+":
+		return Enhanced, nil
+	case "diminished", "-":
+		return Diminished, nil
+	default:
+		return 0, ErrInvalidState
+	}
+}
+
 // isValidSide reports whether s is a valid Side value.
 func isValidSide(s Side) bool {
 	return s == First || s == Second
@@ -1,6 +1,9 @@
 package pin
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 // ============================================================================
 // Side Tests
@@ -80,6 +83,101 @@ func TestMaxStringLength(t *testing.T) {
 	}
 }
 
+func TestMaxEncodedLen(t *testing.T) {
+	tests := []struct {
+		n, want int
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 3},
+		{2, 7},
+		{10, 39},
+	}
+
+	for _, tt := range tests {
+		if got := MaxEncodedLen(tt.n); got != tt.want {
+			t.Errorf("MaxEncodedLen(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+
+	// The bound is reached by terminal pieces with a state modifier
+	ids := []Identifier{MustParse("+K^"), MustParse("-p^")}
+	if got := len(AppendAllTo(nil, ids, ' ')); got != MaxEncodedLen(len(ids)) {
+		t.Errorf("len(AppendAllTo()) = %d, want %d", got, MaxEncodedLen(len(ids)))
+	}
+}
+
+// ============================================================================
+// Name Parsing Tests
+// ============================================================================
+
+func TestParseSide(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Side
+	}{
+		{"first", First},
+		{"First", First},
+		{"w", First},
+		{"WHITE", First},
+		{"second", Second},
+		{"b", Second},
+		{"Black", Second},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSide(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSide(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "1", "sente", " first"} {
+		if _, err := ParseSide(input); !errors.Is(err, ErrInvalidSide) {
+			t.Errorf("ParseSide(%q) error = %v, want ErrInvalidSide", input, err)
+		}
+	}
+}
+
+func TestParseState(t *testing.T) {
+	tests := []struct {
+		input string
+		want  State
+	}{
+		{"normal", Normal},
+		{"Enhanced", Enhanced},
+		{"+", Enhanced},
+		{"DIMINISHED", Diminished},
+		{"-", Diminished},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseState(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseState(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "*", "promoted", "++"} {
+		if _, err := ParseState(input); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("ParseState(%q) error = %v, want ErrInvalidState", input, err)
+		}
+	}
+}
+
+func TestParseSideStateRoundTrip(t *testing.T) {
+	for _, side := range []Side{First, Second} {
+		if got, err := ParseSide(side.String()); err != nil || got != side {
+			t.Errorf("ParseSide(%q) = %v, %v", side.String(), got, err)
+		}
+	}
+	for _, state := range []State{Normal, Enhanced, Diminished} {
+		if got, err := ParseState(state.String()); err != nil || got != state {
+			t.Errorf("ParseState(%q) = %v, %v", state.String(), got, err)
+		}
+	}
+}
+
 // ============================================================================
 // Validation Helper Tests
 // ============================================================================
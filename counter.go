@@ -0,0 +1,119 @@
+package pin
+
+// Counter tallies identifiers by abbreviation and side, and by state and
+// side, for material tracking in engines and analysis tools.
+//
+// Unlike Bag, Counter forgets the combination of attributes of each piece:
+// it answers "how many first-player rooks" and "how many enhanced
+// second-player pieces", not "how many enhanced second-player rooks".
+// Counts are signed, so a Delta between two positions reads as a material
+// balance.
+//
+// Counter is a fixed-size value type; copies are independent. The zero
+// value is an empty counter ready to use.
+type Counter struct {
+	pieces [2][26]int // by side and abbreviation
+	states [2][3]int  // by side and state
+}
+
+// NewCounter returns a Counter tallying the given Identifiers.
+//
+// Panics if any Identifier is not valid (e.g., the zero value).
+func NewCounter(ids ...Identifier) Counter {
+	var c Counter
+	for _, id := range ids {
+		c.Add(id)
+	}
+	return c
+}
+
+// Counter returns the tally of the bag by abbreviation and side, and by
+// state and side.
+func (b *Bag) Counter() Counter {
+	var c Counter
+	b.Range(func(id Identifier, count int) bool {
+		c.tally(id, count)
+		return true
+	})
+	return c
+}
+
+// Add tallies one copy of id.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (c *Counter) Add(id Identifier) {
+	c.tally(id, 1)
+}
+
+// Remove untallies one copy of id, such as a captured piece. Removing an
+// identifier that was never added makes the counts negative.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (c *Counter) Remove(id Identifier) {
+	c.tally(id, -1)
+}
+
+// tally adds n to the counts of id.
+func (c *Counter) tally(id Identifier, n int) {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+
+	side := id.Side()
+	c.pieces[side][id.abbr-'A'] += n
+	c.states[side][id.State()] += n
+}
+
+// Count returns the number of pieces with the given abbreviation and side,
+// whatever their state and terminal status. It returns 0 for an invalid
+// abbreviation or side.
+func (c Counter) Count(abbr rune, side Side) int {
+	if !isValidAbbr(abbr) || !isValidSide(side) {
+		return 0
+	}
+	return c.pieces[side][abbr-'A']
+}
+
+// CountByState returns the number of pieces of side in the given state.
+// It returns 0 for an invalid side or state.
+func (c Counter) CountByState(side Side, state State) int {
+	if !isValidSide(side) || !isValidState(state) {
+		return 0
+	}
+	return c.states[side][state]
+}
+
+// CountBySide returns the number of pieces of side. It returns 0 for an
+// invalid side.
+func (c Counter) CountBySide(side Side) int {
+	if !isValidSide(side) {
+		return 0
+	}
+
+	n := 0
+	for _, count := range c.states[side] {
+		n += count
+	}
+	return n
+}
+
+// Total returns the number of pieces of both sides.
+func (c Counter) Total() int {
+	return c.CountBySide(First) + c.CountBySide(Second)
+}
+
+// Delta returns the difference c minus other, count by count. Positive
+// counts are pieces c has in excess, negative counts pieces it lacks:
+//
+//	after.Delta(before).Count('Q', First) // 1 after a promotion to queen
+func (c Counter) Delta(other Counter) Counter {
+	for side := range c.pieces {
+		for i := range c.pieces[side] {
+			c.pieces[side][i] -= other.pieces[side][i]
+		}
+		for i := range c.states[side] {
+			c.states[side][i] -= other.states[side][i]
+		}
+	}
+	return c
+}
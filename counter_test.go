@@ -0,0 +1,140 @@
+package pin
+
+import "testing"
+
+func TestCounterZeroValueIsEmpty(t *testing.T) {
+	var c Counter
+
+	if c.Total() != 0 || c.Count('K', First) != 0 || c.CountBySide(Second) != 0 {
+		t.Errorf("zero Counter = %d, %d, %d, want empty", c.Total(), c.Count('K', First), c.CountBySide(Second))
+	}
+}
+
+func TestCounterCounts(t *testing.T) {
+	c := NewCounter(
+		MustParse("K^"), MustParse("R"), MustParse("+R"), MustParse("P"), MustParse("P"),
+		MustParse("k^"), MustParse("-p"), MustParse("+b"),
+	)
+
+	counts := []struct {
+		abbr rune
+		side Side
+		want int
+	}{
+		{'K', First, 1},
+		{'R', First, 2},
+		{'P', First, 2},
+		{'P', Second, 1},
+		{'B', First, 0},
+		{'B', Second, 1},
+		{'k', First, 0},
+		{'?', First, 0},
+		{'K', Side(2), 0},
+	}
+
+	for _, tt := range counts {
+		if got := c.Count(tt.abbr, tt.side); got != tt.want {
+			t.Errorf("Count(%q, %v) = %d, want %d", tt.abbr, tt.side, got, tt.want)
+		}
+	}
+
+	states := []struct {
+		side  Side
+		state State
+		want  int
+	}{
+		{First, Normal, 4},
+		{First, Enhanced, 1},
+		{First, Diminished, 0},
+		{Second, Normal, 1},
+		{Second, Enhanced, 1},
+		{Second, Diminished, 1},
+		{Second, State(3), 0},
+	}
+
+	for _, tt := range states {
+		if got := c.CountByState(tt.side, tt.state); got != tt.want {
+			t.Errorf("CountByState(%v, %v) = %d, want %d", tt.side, tt.state, got, tt.want)
+		}
+	}
+
+	if c.CountBySide(First) != 5 || c.CountBySide(Second) != 3 || c.Total() != 8 {
+		t.Errorf("CountBySide() = %d, %d, Total() = %d, want 5, 3, 8",
+			c.CountBySide(First), c.CountBySide(Second), c.Total())
+	}
+	if c.CountBySide(Side(2)) != 0 {
+		t.Errorf("CountBySide(invalid) = %d, want 0", c.CountBySide(Side(2)))
+	}
+}
+
+func TestCounterRemove(t *testing.T) {
+	c := NewCounter(MustParse("P"), MustParse("+P"))
+	c.Remove(MustParse("+P"))
+
+	if c.Count('P', First) != 1 || c.CountByState(First, Enhanced) != 0 || c.Total() != 1 {
+		t.Errorf("after Remove(+P) = %d, %d, %d, want 1, 0, 1",
+			c.Count('P', First), c.CountByState(First, Enhanced), c.Total())
+	}
+
+	c.Remove(MustParse("q"))
+	if c.Count('Q', Second) != -1 {
+		t.Errorf("Count(Q, Second) after removing an absent piece = %d, want -1", c.Count('Q', Second))
+	}
+}
+
+func TestCounterDelta(t *testing.T) {
+	// A pawn promotes to a queen and captures a knight
+	before := NewCounter(MustParse("K"), MustParse("P"), MustParse("k"), MustParse("n"))
+	after := NewCounter(MustParse("K"), MustParse("Q"), MustParse("k"))
+
+	d := after.Delta(before)
+
+	tests := []struct {
+		abbr rune
+		side Side
+		want int
+	}{
+		{'K', First, 0},
+		{'Q', First, 1},
+		{'P', First, -1},
+		{'N', Second, -1},
+	}
+
+	for _, tt := range tests {
+		if got := d.Count(tt.abbr, tt.side); got != tt.want {
+			t.Errorf("Delta().Count(%q, %v) = %d, want %d", tt.abbr, tt.side, got, tt.want)
+		}
+	}
+	if d.CountBySide(First) != 0 || d.CountBySide(Second) != -1 {
+		t.Errorf("Delta().CountBySide() = %d, %d, want 0, -1", d.CountBySide(First), d.CountBySide(Second))
+	}
+
+	// Operands are left untouched, and a counter has no delta with itself
+	if before.Total() != 4 || after.Total() != 3 {
+		t.Errorf("operands modified: %d, %d", before.Total(), after.Total())
+	}
+	if after.Delta(after) != (Counter{}) {
+		t.Error("Delta() with itself is not zero")
+	}
+}
+
+func TestBagCounter(t *testing.T) {
+	hand, _ := ParseBag("2+PN3b^")
+	c := hand.Counter()
+
+	if c.Count('P', First) != 2 || c.Count('B', Second) != 3 || c.CountByState(First, Enhanced) != 2 || c.Total() != 6 {
+		t.Errorf("Counter() = %d, %d, %d, %d, want 2, 3, 2, 6",
+			c.Count('P', First), c.Count('B', Second), c.CountByState(First, Enhanced), c.Total())
+	}
+}
+
+func TestCounterAddPanicsOnZeroIdentifier(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("Add(zero) panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+
+	var c Counter
+	c.Add(Identifier{})
+}
@@ -0,0 +1,333 @@
+package pin
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"math"
+	"sync"
+)
+
+// maxTokenEcho is the number of bytes of an invalid token kept in a DecodeError.
+const maxTokenEcho = 32
+
+// Decoder reads PIN identifiers from an input stream.
+//
+// Tokens are separated by ASCII whitespace or commas; runs of separators
+// are allowed, so newline-delimited, space-delimited, and comma-separated
+// lists all decode the same way.
+//
+// A Decoder tracks its byte offset in the input, so that ingestion of a
+// large file can be interrupted and later resumed with NewDecoderAt.
+//
+// Untrusted input can be bounded with SetMaxTokens and SetMaxBytes, and
+// long-running ingestion cancelled with DecodeContext. Dirty datasets can
+// be ingested in one pass with SetSkipInvalid.
+type Decoder struct {
+	r         *bufio.Reader
+	src       *limitReader
+	offset    int64
+	tokens    int64
+	maxTokens int64
+
+	// line is the current line, counted from 1, or 0 if unknown;
+	// lineStart is the offset at which it starts.
+	line      int
+	lineStart int64
+
+	skipInvalid bool
+	skipped     DecodeErrors
+}
+
+// readerPool holds the buffers of released Decoders.
+var readerPool = sync.Pool{
+	New: func() any { return bufio.NewReader(nil) },
+}
+
+// NewDecoder returns a Decoder reading from r.
+//
+// The Decoder buffers its input and may read data from r beyond the
+// identifiers requested. Its buffer comes from a shared pool; Release
+// returns it.
+func NewDecoder(r io.Reader) *Decoder {
+	d := &Decoder{src: &limitReader{}}
+	d.Reset(r)
+	return d
+}
+
+// Reset discards the state and buffered data of the Decoder and makes it
+// read from r, as if newly created by NewDecoder, so servers can reuse
+// Decoders across requests. Limits set by SetMaxTokens and SetMaxBytes,
+// and the mode set by SetSkipInvalid, are kept; the Skipped report is
+// cleared.
+func (d *Decoder) Reset(r io.Reader) {
+	*d.src = limitReader{r: r, max: d.src.max}
+	if d.r == nil {
+		d.r = readerPool.Get().(*bufio.Reader)
+	}
+	d.r.Reset(d.src)
+	d.offset = 0
+	d.tokens = 0
+	d.line = 1
+	d.lineStart = 0
+	d.skipped = nil
+}
+
+// Release returns the buffer of the Decoder to a shared pool, for reuse by
+// other Decoders. The Decoder must not be used again until Reset.
+func (d *Decoder) Release() {
+	if d.r == nil {
+		return
+	}
+	d.r.Reset(nil)
+	readerPool.Put(d.r)
+	d.r = nil
+	d.src.r = nil
+}
+
+// NewDecoderAt returns a Decoder reading from r starting at offset, as
+// previously reported by Offset.
+//
+// If offset falls within a token, the Decoder resynchronizes by skipping
+// the rest of that token, so decoding always starts on a token boundary.
+// Offsets reported by the returned Decoder are relative to the start of r.
+// Lines preceding offset are not read, so the errors of a Decoder resumed
+// past the start of r leave Line and Column unset.
+func NewDecoderAt(r io.ReaderAt, offset int64) *Decoder {
+	if offset <= 0 {
+		return NewDecoder(io.NewSectionReader(r, 0, math.MaxInt64))
+	}
+
+	// Start one byte early: it tells whether offset falls within a token
+	start := offset - 1
+	d := NewDecoder(io.NewSectionReader(r, start, math.MaxInt64-start))
+	d.offset = start
+	d.line = 0
+
+	if b, err := d.readByte(); err == nil && !isSeparator(b) {
+		// Read errors are reported by the next call to Decode
+		_ = d.skipToken()
+	}
+
+	return d
+}
+
+// Offset returns the byte offset of the Decoder in the input: the position
+// just after the last token returned by Decode.
+func (d *Decoder) Offset() int64 {
+	return d.offset
+}
+
+// SetMaxTokens limits the number of tokens, valid or not, that the Decoder
+// reads; once reached, Decode returns ErrTooManyTokens if another token
+// follows, and io.EOF otherwise. Zero or a negative n removes the limit.
+func (d *Decoder) SetMaxTokens(n int64) {
+	d.maxTokens = n
+}
+
+// SetMaxBytes limits the number of bytes the Decoder reads from its input,
+// counted from its creation or last Reset; reading beyond the limit returns
+// ErrInputTooLarge, while input ending exactly at the limit decodes
+// normally. Zero or a negative n removes the limit.
+func (d *Decoder) SetMaxBytes(n int64) {
+	d.src.max = n
+}
+
+// SetSkipInvalid sets the recovery mode of the Decoder. When skip is
+// true, Decode skips invalid tokens instead of returning them as errors,
+// and records them in the Skipped report, so dirty datasets can be
+// ingested in one pass. Skipped tokens count towards SetMaxTokens.
+func (d *Decoder) SetSkipInvalid(skip bool) {
+	d.skipInvalid = skip
+}
+
+// Skipped returns the invalid tokens skipped in recovery mode since the
+// creation or last Reset of the Decoder, in input order, or nil if there
+// are none. The report keeps every skipped token; bound its size on
+// untrusted input with SetMaxTokens.
+func (d *Decoder) Skipped() DecodeErrors {
+	return d.skipped
+}
+
+// DecodeContext is like Decode but first returns the error of ctx, if any,
+// so that a decoding loop stops once ctx is cancelled. It does not
+// interrupt a read blocked in the underlying reader.
+func (d *Decoder) DecodeContext(ctx context.Context) (Identifier, error) {
+	if err := ctx.Err(); err != nil {
+		return Identifier{}, err
+	}
+	return d.Decode()
+}
+
+// Decode reads the next identifier from the input.
+//
+// It returns io.EOF when no tokens remain. An invalid token is reported as
+// a *DecodeError wrapping the parsing error; the Decoder skips the token, so
+// decoding may continue with the next one. In recovery mode, set by
+// SetSkipInvalid, invalid tokens are recorded in the Skipped report instead
+// and Decode returns the next valid identifier.
+func (d *Decoder) Decode() (Identifier, error) {
+	for {
+		id, err := d.decode()
+		if de, ok := err.(*DecodeError); ok && d.skipInvalid {
+			d.skipped = append(d.skipped, de)
+			continue
+		}
+		return id, err
+	}
+}
+
+// decode reads the next token from the input and parses it.
+func (d *Decoder) decode() (Identifier, error) {
+	// Skip leading separators
+	var b byte
+	for {
+		c, err := d.readByte()
+		if err != nil {
+			return Identifier{}, err
+		}
+		if !isSeparator(c) {
+			b = c
+			break
+		}
+	}
+
+	if d.maxTokens > 0 && d.tokens >= d.maxTokens {
+		// Leave the extra token unread, so input ending at the limit
+		// reaches io.EOF instead
+		_ = d.r.UnreadByte()
+		d.offset--
+		return Identifier{}, ErrTooManyTokens
+	}
+
+	d.tokens++
+	start := d.offset - 1
+	var buf [maxTokenEcho]byte
+	buf[0] = b
+	n := 1
+
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Identifier{}, err
+		}
+		if isSeparator(c) {
+			// Leave the separator unread, so Offset is the end of the token
+			_ = d.r.UnreadByte()
+			break
+		}
+
+		d.offset++
+		if n < len(buf) {
+			buf[n] = c
+		}
+		n++
+	}
+
+	var (
+		id  Identifier
+		err error
+	)
+	if n > MaxStringLength {
+		err = ErrInputTooLong
+	} else {
+		id, _, err = parseBytes(buf[:n])
+	}
+	if err != nil {
+		de := &DecodeError{
+			Offset: start,
+			Token:  string(buf[:min(n, len(buf))]),
+			Err:    err,
+		}
+		if d.line > 0 {
+			de.Line = d.line
+			de.Column = int(start-d.lineStart) + 1
+		}
+		return Identifier{}, de
+	}
+
+	return id, nil
+}
+
+// readByte reads a byte and advances the offset and, past a newline, the
+// line. Tokens never contain newlines, so only separators read here move
+// to the next line.
+func (d *Decoder) readByte() (byte, error) {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	d.offset++
+	if b == '\n' && d.line > 0 {
+		d.line++
+		d.lineStart = d.offset
+	}
+	return b, nil
+}
+
+// skipToken consumes bytes up to the next separator, which is left unread.
+func (d *Decoder) skipToken() error {
+	for {
+		b, err := d.r.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if isSeparator(b) {
+			return d.r.UnreadByte()
+		}
+		d.offset++
+	}
+}
+
+// limitReader reads from r until max bytes have been read, if max is
+// positive, and then fails with ErrInputTooLarge unless r is exhausted.
+type limitReader struct {
+	r        io.Reader
+	n        int64
+	max      int64
+	exceeded bool
+}
+
+// Read implements io.Reader.
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.max <= 0 {
+		n, err := l.r.Read(p)
+		l.n += int64(n)
+		return n, err
+	}
+
+	if l.n >= l.max {
+		// At the limit, the input may only end
+		if !l.exceeded {
+			var probe [1]byte
+			if n, err := io.ReadFull(l.r, probe[:]); n == 0 {
+				return 0, err
+			}
+			l.exceeded = true
+		}
+		return 0, ErrInputTooLarge
+	}
+
+	if rest := l.max - l.n; int64(len(p)) > rest {
+		p = p[:rest]
+	}
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	return n, err
+}
+
+// isSeparator reports whether b separates tokens in a Decoder input.
+func isSeparator(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r', '\v', '\f', ',':
+		return true
+	default:
+		return false
+	}
+}
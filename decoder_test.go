@@ -0,0 +1,469 @@
+package pin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// decodeAll decodes identifiers until io.EOF, stopping at the first error.
+func decodeAll(d *Decoder) ([]string, error) {
+	var out []string
+	for {
+		id, err := d.Decode()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, id.String())
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"  \n\t ", nil},
+		{"K", []string{"K"}},
+		{"K +p^ -r", []string{"K", "+p^", "-r"}},
+		{"K\n+p^\r\n-r\n", []string{"K", "+p^", "-r"}},
+		{"K, +p^,-r", []string{"K", "+p^", "-r"}},
+		{",,K,,", []string{"K"}},
+	}
+
+	for _, tt := range tests {
+		got, err := decodeAll(NewDecoder(strings.NewReader(tt.input)))
+		if err != nil {
+			t.Errorf("Decode(%q) error = %v", tt.input, err)
+			continue
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("Decode(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantErr   error
+		wantOff   int64
+		wantToken string
+	}{
+		{"K X+ q", ErrInvalidTerminalMarker, 2, "X+"},
+		{"K ++K^^ q", ErrInputTooLong, 2, "++K^^"},
+		{"1", ErrMustContainOneLetter, 0, "1"},
+		{"K\n" + strings.Repeat("x", 40), ErrInputTooLong, 2, strings.Repeat("x", 32)},
+	}
+
+	for _, tt := range tests {
+		d := NewDecoder(strings.NewReader(tt.input))
+		_, err := decodeAll(d)
+
+		var de *DecodeError
+		if !errors.As(err, &de) {
+			t.Errorf("Decode(%q) error = %v, want *DecodeError", tt.input, err)
+			continue
+		}
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Decode(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+		}
+		if de.Offset != tt.wantOff {
+			t.Errorf("Decode(%q) error offset = %d, want %d", tt.input, de.Offset, tt.wantOff)
+		}
+		if de.Token != tt.wantToken {
+			t.Errorf("Decode(%q) error token = %q, want %q", tt.input, de.Token, tt.wantToken)
+		}
+	}
+}
+
+func TestDecoderContinuesAfterError(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K X+ q"))
+
+	if _, err := d.Decode(); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if _, err := d.Decode(); err == nil {
+		t.Fatal("Decode() error = nil on invalid token")
+	}
+	id, err := d.Decode()
+	if err != nil || id.String() != "q" {
+		t.Errorf("Decode() after error = %v, %v, want q", id, err)
+	}
+}
+
+func TestDecoderSkipInvalid(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K X+ q\n++,1 -r^ Qx"))
+	d.SetSkipInvalid(true)
+
+	got, err := decodeAll(d)
+	if err != nil || strings.Join(got, " ") != "K q -r^" {
+		t.Errorf("decodeAll() = %v, %v, want [K q -r^]", got, err)
+	}
+
+	skipped := d.Skipped()
+	want := []struct {
+		offset int64
+		token  string
+		err    error
+	}{
+		{2, "X+", ErrInvalidTerminalMarker},
+		{7, "++", ErrMustContainOneLetter},
+		{10, "1", ErrMustContainOneLetter},
+		{16, "Qx", ErrInvalidTerminalMarker},
+	}
+	if len(skipped) != len(want) {
+		t.Fatalf("Skipped() = %v, want %d errors", skipped, len(want))
+	}
+	for i, w := range want {
+		de := skipped[i]
+		if de.Offset != w.offset || de.Token != w.token || !errors.Is(de, w.err) {
+			t.Errorf("Skipped()[%d] = %d %q %v, want %d %q %v", i, de.Offset, de.Token, de.Err, w.offset, w.token, w.err)
+		}
+	}
+	if !errors.Is(skipped, ErrInvalidTerminalMarker) {
+		t.Error("errors.Is(Skipped(), ErrInvalidTerminalMarker) = false, want true")
+	}
+}
+
+func TestDecoderSkipInvalidCountsTokens(t *testing.T) {
+	d := NewDecoder(strings.NewReader("++ ++ ++ K"))
+	d.SetSkipInvalid(true)
+	d.SetMaxTokens(2)
+
+	if _, err := d.Decode(); !errors.Is(err, ErrTooManyTokens) {
+		t.Errorf("Decode() error = %v, want ErrTooManyTokens", err)
+	}
+	if len(d.Skipped()) != 2 {
+		t.Errorf("len(Skipped()) = %d, want 2", len(d.Skipped()))
+	}
+}
+
+func TestDecoderSkipInvalidDisabled(t *testing.T) {
+	d := NewDecoder(strings.NewReader("++ K"))
+
+	if _, err := d.Decode(); err == nil {
+		t.Error("Decode() error = nil on invalid token without recovery mode")
+	}
+	if d.Skipped() != nil {
+		t.Errorf("Skipped() = %v, want nil", d.Skipped())
+	}
+}
+
+func TestDecoderResetClearsSkipped(t *testing.T) {
+	d := NewDecoder(strings.NewReader("++ K"))
+	d.SetSkipInvalid(true)
+	_, _ = decodeAll(d)
+
+	d.Reset(strings.NewReader("-- q"))
+	if d.Skipped() != nil {
+		t.Errorf("Skipped() after Reset = %v, want nil", d.Skipped())
+	}
+
+	// The mode is kept
+	got, err := decodeAll(d)
+	if err != nil || strings.Join(got, " ") != "q" || len(d.Skipped()) != 1 {
+		t.Errorf("decodeAll() after Reset = %v, %v, %d skipped, want [q], 1 skipped", got, err, len(d.Skipped()))
+	}
+}
+
+func TestDecodeErrorMessage(t *testing.T) {
+	err := &DecodeError{Offset: 12, Token: "X+", Err: ErrInvalidTerminalMarker}
+	want := `pin: token "X+" at offset 12: invalid terminal marker`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestDecodeErrorMessageWithLine(t *testing.T) {
+	err := &DecodeError{Offset: 12, Line: 2, Column: 3, Token: "X+", Err: ErrInvalidTerminalMarker}
+	want := `pin: token "X+" at offset 12 (line 2, column 3): invalid terminal marker`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestDecoderErrorLineColumn(t *testing.T) {
+	d := NewDecoder(strings.NewReader("++ K\r\n  q\n\n\tK+, X^^\n"))
+	d.SetSkipInvalid(true)
+	_, _ = decodeAll(d)
+
+	want := []struct {
+		token        string
+		line, column int
+	}{
+		{"++", 1, 1},
+		{"K+", 4, 2},
+		{"X^^", 4, 6},
+	}
+
+	skipped := d.Skipped()
+	if len(skipped) != len(want) {
+		t.Fatalf("Skipped() = %v, want %d tokens", skipped, len(want))
+	}
+	for i, w := range want {
+		if de := skipped[i]; de.Token != w.token || de.Line != w.line || de.Column != w.column {
+			t.Errorf("Skipped()[%d] = %q at %d:%d, want %q at %d:%d", i, de.Token, de.Line, de.Column, w.token, w.line, w.column)
+		}
+	}
+
+	// Reset starts over at line 1
+	d.Reset(strings.NewReader("\n *K"))
+	_, _ = decodeAll(d)
+	if de := d.Skipped()[0]; de.Line != 2 || de.Column != 2 {
+		t.Errorf("error after Reset at %d:%d, want 2:2", de.Line, de.Column)
+	}
+}
+
+func TestNewDecoderAtErrorLineUnknown(t *testing.T) {
+	input := "K\n+p^ ++"
+	d := NewDecoderAt(strings.NewReader(input), 2)
+
+	_, err := d.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	_, err = d.Decode()
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Offset != 6 || de.Line != 0 || de.Column != 0 {
+		t.Errorf("Decode() error = %+v, want offset 6 with unknown line and column", de)
+	}
+}
+
+func TestDecoderOffset(t *testing.T) {
+	input := "K  +p^\n-r"
+	d := NewDecoder(strings.NewReader(input))
+
+	wantOffsets := []int64{1, 6, 9}
+	for i, want := range wantOffsets {
+		if _, err := d.Decode(); err != nil {
+			t.Fatalf("Decode() #%d error = %v", i, err)
+		}
+		if got := d.Offset(); got != want {
+			t.Errorf("Offset() after token #%d = %d, want %d", i, got, want)
+		}
+	}
+
+	if _, err := d.Decode(); err != io.EOF {
+		t.Errorf("Decode() at end error = %v, want io.EOF", err)
+	}
+	if got := d.Offset(); got != int64(len(input)) {
+		t.Errorf("Offset() at end = %d, want %d", got, len(input))
+	}
+}
+
+func TestNewDecoderAt(t *testing.T) {
+	input := "K  +p^\n-r, q"
+	r := strings.NewReader(input)
+
+	tests := []struct {
+		offset int64
+		want   []string
+	}{
+		{0, []string{"K", "+p^", "-r", "q"}},
+		{1, []string{"+p^", "-r", "q"}}, // just after a token
+		{2, []string{"+p^", "-r", "q"}}, // between separators
+		{3, []string{"+p^", "-r", "q"}}, // at the start of a token
+		{4, []string{"-r", "q"}},        // within a token
+		{5, []string{"-r", "q"}},        // within a token
+		{6, []string{"-r", "q"}},        // just after a token
+		{11, []string{"q"}},             // at the start of the last token
+		{12, nil},                       // at the end
+		{40, nil},                       // past the end
+	}
+
+	for _, tt := range tests {
+		got, err := decodeAll(NewDecoderAt(r, tt.offset))
+		if err != nil {
+			t.Errorf("NewDecoderAt(%d) Decode error = %v", tt.offset, err)
+			continue
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("NewDecoderAt(%d) decoded %v, want %v", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestNewDecoderAtResume(t *testing.T) {
+	input := "K +p^ -r q k^ +B"
+	r := strings.NewReader(input)
+
+	// Interrupt after two tokens, then resume from the saved offset
+	d := NewDecoder(strings.NewReader(input))
+	for i := 0; i < 2; i++ {
+		if _, err := d.Decode(); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+	}
+	saved := d.Offset()
+
+	resumed := NewDecoderAt(r, saved)
+	got, err := decodeAll(resumed)
+	if err != nil {
+		t.Fatalf("resumed Decode() error = %v", err)
+	}
+	if want := "-r q k^ +B"; strings.Join(got, " ") != want {
+		t.Errorf("resumed Decode() = %v, want %s", got, want)
+	}
+	if resumed.Offset() != int64(len(input)) {
+		t.Errorf("resumed Offset() = %d, want %d", resumed.Offset(), len(input))
+	}
+}
+
+func TestDecoderNoAllocs(t *testing.T) {
+	r := strings.NewReader("+K^ ")
+	d := NewDecoder(r)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Reset("+K^ ")
+		d.r.Reset(r)
+		if _, err := d.Decode(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Decode() allocates %v times, want 0", allocs)
+	}
+}
+
+// ============================================================================
+// Limits and Cancellation
+// ============================================================================
+
+func TestDecoderMaxTokens(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K X+ q R"))
+	d.SetMaxTokens(3)
+
+	got, err := decodeAll(d)
+	if !errors.Is(err, ErrInvalidTerminalMarker) {
+		t.Fatalf("Decode() error = %v, want the invalid token first", err)
+	}
+	more, err := decodeAll(d)
+	got = append(got, more...)
+
+	if strings.Join(got, " ") != "K q" || !errors.Is(err, ErrTooManyTokens) {
+		t.Errorf("Decode() = %v, %v; want [K q], ErrTooManyTokens", got, err)
+	}
+}
+
+func TestDecoderMaxTokensExact(t *testing.T) {
+	for _, input := range []string{"K Q", "K Q\n", "K Q ,\n"} {
+		d := NewDecoder(strings.NewReader(input))
+		d.SetMaxTokens(2)
+
+		got, err := decodeAll(d)
+		if err != nil || strings.Join(got, " ") != "K Q" {
+			t.Errorf("Decode(%q) = %v, %v; want [K Q], io.EOF", input, got, err)
+		}
+	}
+}
+
+func TestDecoderMaxBytes(t *testing.T) {
+	tests := []struct {
+		input   string
+		max     int64
+		want    string
+		wantErr error
+	}{
+		{"K q R", 5, "K q R", nil},
+		{"K q R ", 5, "K q", ErrInputTooLarge}, // R might continue past the limit
+		{"K q +R", 5, "K q", ErrInputTooLarge},
+		{"K q +R", 0, "K q +R", nil},
+	}
+
+	for _, tt := range tests {
+		d := NewDecoder(strings.NewReader(tt.input))
+		d.SetMaxBytes(tt.max)
+
+		got, err := decodeAll(d)
+		if strings.Join(got, " ") != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Decode(%q) with %d bytes = %v, %v; want [%s], %v", tt.input, tt.max, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDecoderMaxBytesLargeInput(t *testing.T) {
+	d := NewDecoder(strings.NewReader(strings.Repeat("K ", 10000)))
+	d.SetMaxBytes(100)
+
+	got, err := decodeAll(d)
+	if len(got) != 50 || !errors.Is(err, ErrInputTooLarge) {
+		t.Errorf("Decode() = %d identifiers, %v; want 50, ErrInputTooLarge", len(got), err)
+	}
+}
+
+func TestDecoderDecodeContext(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K q R"))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if id, err := d.DecodeContext(ctx); err != nil || id.String() != "K" {
+		t.Fatalf("DecodeContext() = %v, %v; want K", id, err)
+	}
+
+	cancel()
+	if _, err := d.DecodeContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("DecodeContext() after cancel error = %v, want context.Canceled", err)
+	}
+}
+
+func TestDecoderReset(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K q R"))
+	d.SetMaxTokens(2)
+	if _, err := d.Decode(); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	d.Reset(strings.NewReader("+p^ -r x"))
+	if d.Offset() != 0 {
+		t.Errorf("Offset() after Reset = %d, want 0", d.Offset())
+	}
+	got, err := decodeAll(d)
+	if !errors.Is(err, ErrTooManyTokens) || strings.Join(got, " ") != "+p^ -r" {
+		t.Errorf("decodeAll() after Reset = %v, %v, want [+p^ -r], ErrTooManyTokens", got, err)
+	}
+}
+
+func TestDecoderResetKeepsMaxBytes(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K"))
+	d.SetMaxBytes(4)
+	_, _ = decodeAll(d)
+
+	d.Reset(strings.NewReader("K q R"))
+	got, err := decodeAll(d)
+	if !errors.Is(err, ErrInputTooLarge) || strings.Join(got, " ") != "K q" {
+		t.Errorf("decodeAll() = %v, %v, want [K q], ErrInputTooLarge", got, err)
+	}
+}
+
+func TestDecoderReleaseAndReset(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K"))
+	d.Release()
+	d.Release()
+
+	d.Reset(strings.NewReader("q"))
+	if got, err := decodeAll(d); err != nil || strings.Join(got, " ") != "q" {
+		t.Errorf("decodeAll() after Release and Reset = %v, %v, want [q]", got, err)
+	}
+}
+
+func TestDecoderResetNoAllocs(t *testing.T) {
+	d := NewDecoder(strings.NewReader(""))
+	r := strings.NewReader("K +p^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Reset("K +p^")
+		d.Reset(r)
+		_, _ = d.Decode()
+		_, _ = d.Decode()
+	})
+	if allocs != 0 {
+		t.Errorf("Reset and Decode allocate %v times, want 0", allocs)
+	}
+}
@@ -0,0 +1,113 @@
+package pin
+
+import "strings"
+
+// Describe returns a long-form description of the Identifier, made of
+// lowercase keywords: the side, the state, the uppercase abbreviation, and
+// "terminal" for terminal pieces.
+//
+// Examples:
+//
+//	MustParse("K").Describe()    // "first normal K"
+//	MustParse("+r^").Describe()  // "second enhanced R terminal"
+//
+// ParseVerbose is its inverse.
+func (id Identifier) Describe() string {
+	var b strings.Builder
+	b.Grow(len("second diminished K terminal"))
+
+	b.WriteString(strings.ToLower(id.Side().String()))
+	b.WriteByte(' ')
+	b.WriteString(strings.ToLower(id.State().String()))
+	b.WriteByte(' ')
+	b.WriteRune(id.Abbr())
+	if id.IsTerminal() {
+		b.WriteString(" terminal")
+	}
+
+	return b.String()
+}
+
+// ParseVerbose parses a long-form description, the inverse of Describe.
+//
+// The description is a whitespace-separated list of keywords, in any order
+// and case-insensitive:
+//
+//   - a side: "first" or "second" (default "first")
+//   - a state: "normal", "enhanced", or "diminished" (default "normal")
+//   - exactly one letter A-Z, the abbreviation
+//   - optionally "terminal"
+//
+// Example:
+//
+//	ParseVerbose("second enhanced R terminal") // +r^
+//	ParseVerbose("terminal k")                 // K^
+//
+// Returns ErrEmptyInput, ErrUnknownKeyword, ErrDuplicateKeyword, or
+// ErrMustContainOneLetter if the description is invalid.
+func ParseVerbose(s string) (Identifier, error) {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return Identifier{}, ErrEmptyInput
+	}
+
+	var (
+		abbr                       rune
+		side                       Side
+		state                      State
+		terminal                   bool
+		hasAbbr, hasSide, hasState bool
+	)
+
+	for _, w := range words {
+		switch kw := strings.ToLower(w); kw {
+		case "first", "second":
+			if hasSide {
+				return Identifier{}, ErrDuplicateKeyword
+			}
+			hasSide = true
+			if kw == "second" {
+				side = Second
+			}
+
+		case "normal", "enhanced", "diminished":
+			if hasState {
+				return Identifier{}, ErrDuplicateKeyword
+			}
+			hasState = true
+			switch kw {
+			case "enhanced":
+				state = Enhanced
+			case "diminished":
+				state = Diminished
+			}
+
+		case "terminal":
+			if terminal {
+				return Identifier{}, ErrDuplicateKeyword
+			}
+			terminal = true
+
+		default:
+			// The letter case is not significant: the side is given by keyword
+			if len(w) != 1 {
+				return Identifier{}, ErrUnknownKeyword
+			}
+			letter, _, ok := classifyLetter(w[0])
+			if !ok {
+				return Identifier{}, ErrUnknownKeyword
+			}
+			if hasAbbr {
+				return Identifier{}, ErrMustContainOneLetter
+			}
+			hasAbbr = true
+			abbr = letter
+		}
+	}
+
+	if !hasAbbr {
+		return Identifier{}, ErrMustContainOneLetter
+	}
+
+	return makeIdentifier(abbr, side, state, terminal), nil
+}
@@ -0,0 +1,104 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		pin  string
+		want string
+	}{
+		{"K", "first normal K"},
+		{"k", "second normal K"},
+		{"+R", "first enhanced R"},
+		{"-p", "second diminished P"},
+		{"K^", "first normal K terminal"},
+		{"+r^", "second enhanced R terminal"},
+	}
+
+	for _, tt := range tests {
+		got := MustParse(tt.pin).Describe()
+		if got != tt.want {
+			t.Errorf("Describe(%s) = %q, want %q", tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeAllDistinct(t *testing.T) {
+	seen := make(map[string]bool, identifierCount)
+	for i := 0; i < identifierCount; i++ {
+		d := fromIndex(i).Describe()
+		if seen[d] {
+			t.Errorf("Describe() = %q is not unique", d)
+		}
+		seen[d] = true
+	}
+}
+
+func TestParseVerbose(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"first normal K", "K"},
+		{"second enhanced R terminal", "+r^"},
+		{"terminal r enhanced second", "+r^"},
+		{"  SECOND   Diminished   p ", "-p"},
+		{"k", "K"},
+		{"K terminal", "K^"},
+		{"second q", "q"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVerbose(tt.input)
+		if err != nil {
+			t.Errorf("ParseVerbose(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseVerbose(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseVerboseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", ErrEmptyInput},
+		{"   ", ErrEmptyInput},
+		{"first normal", ErrMustContainOneLetter},
+		{"K Q", ErrMustContainOneLetter},
+		{"first second K", ErrDuplicateKeyword},
+		{"normal enhanced K", ErrDuplicateKeyword},
+		{"K terminal terminal", ErrDuplicateKeyword},
+		{"white K", ErrUnknownKeyword},
+		{"KK", ErrUnknownKeyword},
+		{"+K", ErrUnknownKeyword},
+		{"first normal 1", ErrUnknownKeyword},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseVerbose(tt.input)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ParseVerbose(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestParseVerboseRoundTrip(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		got, err := ParseVerbose(id.Describe())
+		if err != nil {
+			t.Errorf("ParseVerbose(%q) error = %v", id.Describe(), err)
+			continue
+		}
+		if got != id {
+			t.Errorf("ParseVerbose(%q) = %s, want %s", id.Describe(), got, id)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package pin
+
+import "strings"
+
+// emptySquare marks an empty square in an ASCII diagram.
+const emptySquare = '.'
+
+// RenderASCII returns a plain-text diagram of the board, one line per row
+// from row 0, with the PIN token of each square and a dot for each empty
+// square:
+//
+//	r  n  b  q  k^ b  n  r
+//	p  p  p  p  p  p  p  p
+//	.  .  .  .  .  .  .  .
+//
+// Squares are padded to the width of the longest token on the board and
+// separated by a space, so columns stay aligned; lines carry no trailing
+// spaces and each ends with a newline. The diagram reads back with
+// ParseASCIIDiagram, so positions can be diffed and reviewed as text.
+func (b *Board) RenderASCII() string {
+	width := 1
+	b.Range(func(_, _ int, id Identifier) bool {
+		width = max(width, id.EncodedLen())
+		return width < MaxStringLength
+	})
+
+	var sb strings.Builder
+	sb.Grow(b.rows * (b.cols*(width+1) + 1))
+
+	var buf [MaxStringLength]byte
+	for r := 0; r < b.rows; r++ {
+		for c := 0; c < b.cols; c++ {
+			tok := []byte{emptySquare}
+			if id := b.squares[r*b.cols+c]; !id.IsZero() {
+				tok = id.AppendTo(buf[:0])
+			}
+
+			if c > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.Write(tok)
+
+			// Pad all but the last square, so lines have no trailing spaces
+			if c < b.cols-1 {
+				sb.WriteString(strings.Repeat(" ", width-len(tok)))
+			}
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// ParseASCIIDiagram parses a diagram as written by RenderASCII into a
+// Board, for test fixtures.
+//
+// Squares are separated by any run of spaces or tabs, and leading blank
+// lines, trailing blank lines, and indentation are ignored, so diagrams
+// can be embedded in raw string literals. Lines may end with "\r\n".
+//
+// Invalid input returns a *DecodeError with the line and column of the
+// offending square, wrapping ErrInvalidPlacement if the diagram is empty,
+// has rows of different lengths, or has more than 4096 squares, and the
+// parsing errors of Parse otherwise.
+func ParseASCIIDiagram(s string) (*Board, error) {
+	var (
+		rows  [][]Identifier
+		ended bool // a blank line followed the rows
+	)
+
+	start := 0
+	for _, line := range strings.Split(s, "\n") {
+		end := start + len(strings.TrimSuffix(line, "\r"))
+
+		row, err := parseDiagramRow(s, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case row == nil:
+			ended = len(rows) > 0
+		case ended:
+			// Only blank lines may follow the diagram
+			return nil, diagramError(s, start, end, ErrInvalidPlacement)
+		case len(rows) > 0 && len(row) != len(rows[0]):
+			return nil, diagramError(s, start, end, ErrInvalidPlacement)
+		case (len(rows)+1)*len(row) > maxPlacementSquares:
+			return nil, diagramError(s, start, end, ErrInvalidPlacement)
+		default:
+			rows = append(rows, row)
+		}
+
+		start += len(line) + 1
+	}
+
+	if len(rows) == 0 {
+		return nil, diagramError(s, 0, len(s), ErrInvalidPlacement)
+	}
+
+	b := NewBoard(len(rows), len(rows[0]))
+	for r, row := range rows {
+		for c, id := range row {
+			if !id.IsZero() {
+				b.Set(r, c, id)
+			}
+		}
+	}
+	return b, nil
+}
+
+// parseDiagramRow parses the squares of the line s[start:end], returning
+// nil for a blank line.
+func parseDiagramRow(s string, start, end int) ([]Identifier, error) {
+	var row []Identifier
+
+	for i := start; i < end; {
+		if s[i] == ' ' || s[i] == '\t' {
+			i++
+			continue
+		}
+
+		j := i
+		for j < end && s[j] != ' ' && s[j] != '\t' {
+			j++
+		}
+
+		var id Identifier
+		if tok := s[i:j]; tok != string(emptySquare) {
+			var err error
+			if id, err = Parse(tok); err != nil {
+				return nil, diagramError(s, i, j, err.(*SyntaxError).Err)
+			}
+		}
+		row = append(row, id)
+		i = j
+	}
+
+	return row, nil
+}
+
+// diagramError returns a DecodeError for the segment s[start:end] of a
+// diagram, locating its line and column.
+func diagramError(s string, start, end int, err error) *DecodeError {
+	return tokenDecodeError([]byte(s), start, end, err)
+}
@@ -0,0 +1,119 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderASCII(t *testing.T) {
+	b, _ := MustParseFEEN("r2k^/+P3/4/1-q^2 / C/c").Board()
+
+	want := "" +
+		"r   .   .   k^\n" +
+		"+P  .   .   .\n" +
+		".   .   .   .\n" +
+		".   -q^ .   .\n"
+	if got := b.RenderASCII(); got != want {
+		t.Errorf("RenderASCII() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderASCIINarrow(t *testing.T) {
+	b, _ := MustParseFEEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR / C/c").Board()
+
+	want := "" +
+		"r n b q k b n r\n" +
+		"p p p p p p p p\n" +
+		". . . . . . . .\n" +
+		". . . . . . . .\n" +
+		". . . . . . . .\n" +
+		". . . . . . . .\n" +
+		"P P P P P P P P\n" +
+		"R N B Q K B N R\n"
+	if got := b.RenderASCII(); got != want {
+		t.Errorf("RenderASCII() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseASCIIDiagram(t *testing.T) {
+	b, err := ParseASCIIDiagram(`
+		r  .  k^
+		.  +P .
+		.	.  -q
+	`)
+	if err != nil {
+		t.Fatalf("ParseASCIIDiagram() error = %v", err)
+	}
+
+	want := NewBoard(3, 3)
+	want.Set(0, 0, MustParse("r"))
+	want.Set(0, 2, MustParse("k^"))
+	want.Set(1, 1, MustParse("+P"))
+	want.Set(2, 2, MustParse("-q"))
+	if !b.Equal(want) {
+		t.Errorf("ParseASCIIDiagram() =\n%s\nwant\n%s", b.RenderASCII(), want.RenderASCII())
+	}
+}
+
+func TestASCIIDiagramRoundTrip(t *testing.T) {
+	for _, s := range []string{chessFEEN, shogiFEEN, "k^/K / C/c", "2+B^ / C/c"} {
+		b, _ := MustParseFEEN(s).Board()
+
+		got, err := ParseASCIIDiagram(b.RenderASCII())
+		if err != nil || !got.Equal(b) {
+			t.Errorf("round trip of %q = %v, %v", s, got, err)
+		}
+	}
+
+	// Windows line endings
+	b, err := ParseASCIIDiagram("K .\r\n. k\r\n")
+	if err != nil || b.Rows() != 2 || b.Cols() != 2 || b.Len() != 2 {
+		t.Errorf("ParseASCIIDiagram(CRLF) = %v, %v", b, err)
+	}
+}
+
+func TestParseASCIIDiagramErrors(t *testing.T) {
+	tests := []struct {
+		input        string
+		line, column int
+		token        string
+		err          error
+	}{
+		{"", 1, 1, "", ErrInvalidPlacement},
+		{"\n  \n", 1, 1, "\n  \n", ErrInvalidPlacement},
+		{"K . .\n. k", 2, 1, ". k", ErrInvalidPlacement},
+		{"K .\n\n. k", 3, 1, ". k", ErrInvalidPlacement},
+		{"K .\n. K+", 2, 3, "K+", ErrInvalidTerminalMarker},
+		{"  K .\n  . ..", 2, 5, "..", ErrInvalidStateModifier},
+		{"K * .", 1, 3, "*", ErrMustContainOneLetter},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseASCIIDiagram(tt.input)
+
+		var de *DecodeError
+		if !errors.As(err, &de) {
+			t.Errorf("ParseASCIIDiagram(%q) error = %v, want *DecodeError", tt.input, err)
+			continue
+		}
+		if de.Line != tt.line || de.Column != tt.column || de.Token != tt.token || !errors.Is(err, tt.err) {
+			t.Errorf("ParseASCIIDiagram(%q) error = %d:%d %q %v, want %d:%d %q %v",
+				tt.input, de.Line, de.Column, de.Token, de.Err, tt.line, tt.column, tt.token, tt.err)
+		}
+	}
+}
+
+func TestParseASCIIDiagramTooLarge(t *testing.T) {
+	row := "."
+	for i := 1; i < 64; i++ {
+		row += " ."
+	}
+	diagram := ""
+	for i := 0; i < 65; i++ {
+		diagram += row + "\n"
+	}
+
+	if _, err := ParseASCIIDiagram(diagram); !errors.Is(err, ErrInvalidPlacement) {
+		t.Errorf("ParseASCIIDiagram(65x64) error = %v, want ErrInvalidPlacement", err)
+	}
+}
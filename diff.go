@@ -0,0 +1,66 @@
+package pin
+
+import "strings"
+
+// Attrs is a set of Identifier attributes, as a bitmask.
+type Attrs uint8
+
+// Attributes of an Identifier.
+const (
+	// AttrAbbr is the piece name abbreviation.
+	AttrAbbr Attrs = 1 << iota
+	// AttrSide is the piece side.
+	AttrSide
+	// AttrState is the piece state.
+	AttrState
+	// AttrTerminal is the terminal status.
+	AttrTerminal
+)
+
+// attrNames holds the names of the attributes, in bit order.
+var attrNames = [...]string{"abbr", "side", "state", "terminal"}
+
+// Has reports whether a contains all attributes of attrs.
+func (a Attrs) Has(attrs Attrs) bool {
+	return a&attrs == attrs
+}
+
+// String returns the names of the attributes joined by "|", as in
+// "side|state", or "none" for the empty set.
+func (a Attrs) String() string {
+	if a == 0 {
+		return "none"
+	}
+
+	var names []string
+	for i, name := range attrNames {
+		if a&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Diff returns the attributes in which id and other differ, so
+// move-application code can assert exactly what changed:
+//
+//	MustParse("P").Diff(MustParse("+P")) // AttrState (a promotion)
+//	MustParse("+p").Diff(MustParse("P")) // AttrSide|AttrState (a capture)
+//
+// Identical identifiers return 0.
+func (id Identifier) Diff(other Identifier) Attrs {
+	var a Attrs
+	if !id.SameAbbr(other) {
+		a |= AttrAbbr
+	}
+	if !id.SameSide(other) {
+		a |= AttrSide
+	}
+	if !id.SameState(other) {
+		a |= AttrState
+	}
+	if !id.SameTerminal(other) {
+		a |= AttrTerminal
+	}
+	return a
+}
@@ -0,0 +1,74 @@
+package pin
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Attrs
+	}{
+		{"K", "K", 0},
+		{"P", "+P", AttrState},
+		{"+p", "P", AttrSide | AttrState},
+		{"P", "Q", AttrAbbr},
+		{"K", "K^", AttrTerminal},
+		{"-r^", "K", AttrAbbr | AttrSide | AttrState | AttrTerminal},
+	}
+
+	for _, tt := range tests {
+		a, b := MustParse(tt.a), MustParse(tt.b)
+		if got := a.Diff(b); got != tt.want {
+			t.Errorf("%s.Diff(%s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.Diff(a); got != tt.want {
+			t.Errorf("%s.Diff(%s) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestDiffMatchesAttributes(t *testing.T) {
+	ids := All()
+	for _, a := range ids {
+		for _, b := range ids {
+			d := a.Diff(b)
+			if d.Has(AttrAbbr) != (a.Abbr() != b.Abbr()) ||
+				d.Has(AttrSide) != (a.Side() != b.Side()) ||
+				d.Has(AttrState) != (a.State() != b.State()) ||
+				d.Has(AttrTerminal) != (a.IsTerminal() != b.IsTerminal()) {
+				t.Fatalf("%s.Diff(%s) = %v", a, b, d)
+			}
+			if (d == 0) != (a == b) {
+				t.Fatalf("%s.Diff(%s) = %v, want 0 only for equal identifiers", a, b, d)
+			}
+		}
+	}
+}
+
+func TestAttrsHas(t *testing.T) {
+	a := AttrSide | AttrState
+
+	if !a.Has(AttrSide) || !a.Has(AttrSide|AttrState) || !a.Has(0) {
+		t.Errorf("%v.Has() = false for a subset", a)
+	}
+	if a.Has(AttrAbbr) || a.Has(AttrSide|AttrTerminal) {
+		t.Errorf("%v.Has() = true for a non-subset", a)
+	}
+}
+
+func TestAttrsString(t *testing.T) {
+	tests := []struct {
+		attrs Attrs
+		want  string
+	}{
+		{0, "none"},
+		{AttrAbbr, "abbr"},
+		{AttrSide | AttrState, "side|state"},
+		{AttrAbbr | AttrSide | AttrState | AttrTerminal, "abbr|side|state|terminal"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.attrs.String(); got != tt.want {
+			t.Errorf("Attrs(%d).String() = %q, want %q", uint8(tt.attrs), got, tt.want)
+		}
+	}
+}
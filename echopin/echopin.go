@@ -0,0 +1,47 @@
+// Package echopin binds PIN request parameters in echo handlers.
+//
+// Errors produce the same 400 response body as the httppin package. This
+// package lives in its own module so that the pin package itself keeps no
+// third-party dependencies.
+package echopin
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/sashite/pin.go/v3"
+	"github.com/sashite/pin.go/v3/httppin"
+)
+
+// BindPIN reads and parses the named path parameter, falling back to the
+// query parameter of the same name.
+//
+// On failure, it returns an *echo.HTTPError with status 400 whose message is
+// the httppin.Response body, and whose internal error is the
+// *httppin.ParamError. Returning it from the handler lets echo's error
+// handler write the response:
+//
+//	e.GET("/pieces/:piece", func(c echo.Context) error {
+//		id, err := echopin.BindPIN(c, "piece")
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	})
+func BindPIN(c echo.Context, name string) (pin.Identifier, error) {
+	value := c.Param(name)
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		value = unescaped
+	}
+	if value == "" {
+		value = c.QueryParam(name)
+	}
+
+	id, err := httppin.Parse(name, value)
+	if err != nil {
+		return pin.Identifier{}, echo.NewHTTPError(http.StatusBadRequest, httppin.NewResponse(err)).SetInternal(err)
+	}
+	return id, nil
+}
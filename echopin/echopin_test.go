@@ -0,0 +1,98 @@
+package echopin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/sashite/pin.go/v3"
+	"github.com/sashite/pin.go/v3/httppin"
+)
+
+func newServer() *echo.Echo {
+	e := echo.New()
+	handler := func(c echo.Context) error {
+		id, err := BindPIN(c, "piece")
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, id.String())
+	}
+	e.GET("/pieces/:piece", handler)
+	e.GET("/pieces", handler)
+	return e
+}
+
+// ============================================================================
+// BindPIN Tests
+// ============================================================================
+
+func TestBindPIN(t *testing.T) {
+	tests := []struct {
+		target string
+		body   string
+	}{
+		{"/pieces/-p", "-p"},
+		{"/pieces/%2BK%5E", "+K^"},
+		{"/pieces?piece=k", "k"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		newServer().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s status = %d, want 200", tt.target, w.Code)
+		}
+		if w.Body.String() != tt.body {
+			t.Errorf("GET %s body = %q, want %q", tt.target, w.Body.String(), tt.body)
+		}
+	}
+}
+
+func TestBindPINErrors(t *testing.T) {
+	tests := []struct {
+		target string
+		code   string
+	}{
+		{"/pieces/KK", "invalid_terminal_marker"},
+		{"/pieces", "required"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		newServer().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want 400", tt.target, w.Code)
+		}
+
+		var resp httppin.Response
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("GET %s: decoding body: %v", tt.target, err)
+		}
+		if resp.Error.Code != tt.code || resp.Error.Param != "piece" {
+			t.Errorf("GET %s body = %+v, want code %q for \"piece\"", tt.target, resp.Error, tt.code)
+		}
+	}
+}
+
+func TestBindPINInternalError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/pieces?piece=*K", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	_, err := BindPIN(c, "piece")
+
+	var he *echo.HTTPError
+	if !errors.As(err, &he) || he.Code != http.StatusBadRequest {
+		t.Fatalf("BindPIN() error = %v, want *echo.HTTPError with status 400", err)
+	}
+	if !errors.Is(he.Internal, pin.ErrInvalidStateModifier) {
+		t.Errorf("Internal = %v, want ErrInvalidStateModifier", he.Internal)
+	}
+}
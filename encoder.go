@@ -0,0 +1,105 @@
+package pin
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// Encoder writes PIN identifiers to an output stream.
+//
+// Identifiers are separated by a configurable separator, a newline by
+// default, and the output can be read back with a Decoder. Writes are
+// buffered: call Flush to push them to the underlying writer.
+type Encoder struct {
+	w       *bufio.Writer
+	sep     string
+	started bool
+}
+
+// writerPool holds the buffers of released Encoders.
+var writerPool = sync.Pool{
+	New: func() any { return bufio.NewWriter(nil) },
+}
+
+// NewEncoder returns an Encoder writing to w, with a newline separator.
+//
+// Its buffer comes from a shared pool; Release returns it.
+func NewEncoder(w io.Writer) *Encoder {
+	e := &Encoder{sep: "\n"}
+	e.Reset(w)
+	return e
+}
+
+// Reset discards any unflushed data and makes the Encoder write to w, as if
+// newly created by NewEncoder, so servers can reuse Encoders across
+// requests. The separator set by SetSeparator is kept.
+func (e *Encoder) Reset(w io.Writer) {
+	if e.w == nil {
+		e.w = writerPool.Get().(*bufio.Writer)
+	}
+	e.w.Reset(w)
+	e.started = false
+}
+
+// Release returns the buffer of the Encoder to a shared pool, for reuse by
+// other Encoders, discarding any unflushed data: call Flush first. The
+// Encoder must not be used again until Reset.
+func (e *Encoder) Release() {
+	if e.w == nil {
+		return
+	}
+	e.w.Reset(nil)
+	writerPool.Put(e.w)
+	e.w = nil
+}
+
+// SetSeparator sets the string written between identifiers, such as " ",
+// ",", or ", ".
+//
+// Panics with ErrInvalidSeparator if sep is empty or contains a character
+// other than ASCII whitespace or a comma, since a Decoder could not split
+// the output.
+func (e *Encoder) SetSeparator(sep string) {
+	if sep == "" {
+		panic(ErrInvalidSeparator)
+	}
+	for i := 0; i < len(sep); i++ {
+		if !isSeparator(sep[i]) {
+			panic(ErrInvalidSeparator)
+		}
+	}
+	e.sep = sep
+}
+
+// Encode writes id, preceded by the separator unless it is the first
+// identifier written.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value), writing nothing. Write errors of the underlying writer are
+// reported by Encode or Flush and persist for later calls.
+func (e *Encoder) Encode(id Identifier) error {
+	if !id.isValid() {
+		return ErrInvalidIdentifier
+	}
+
+	if e.started {
+		if _, err := e.w.WriteString(e.sep); err != nil {
+			return err
+		}
+	}
+	e.started = true
+
+	_, err := e.w.Write(id.AppendTo(e.w.AvailableBuffer()))
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (e *Encoder) Flush() error {
+	return e.w.Flush()
+}
+
+// Buffered returns the number of bytes written but not yet flushed.
+func (e *Encoder) Buffered() int {
+	return e.w.Buffered()
+}
@@ -0,0 +1,189 @@
+package pin
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// errWriter fails every write.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		sep  string
+		want string
+	}{
+		{"", "K\n+p^\n-r"},
+		{" ", "K +p^ -r"},
+		{",", "K,+p^,-r"},
+		{", ", "K, +p^, -r"},
+		{"\r\n", "K\r\n+p^\r\n-r"},
+	}
+
+	for _, tt := range tests {
+		var b strings.Builder
+		enc := NewEncoder(&b)
+		if tt.sep != "" {
+			enc.SetSeparator(tt.sep)
+		}
+
+		for _, s := range []string{"K", "+p^", "-r"} {
+			if err := enc.Encode(MustParse(s)); err != nil {
+				t.Fatalf("Encode(%s) error = %v", s, err)
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+
+		if b.String() != tt.want {
+			t.Errorf("output with separator %q = %q, want %q", tt.sep, b.String(), tt.want)
+		}
+	}
+}
+
+func TestEncoderBuffersUntilFlush(t *testing.T) {
+	var b strings.Builder
+	enc := NewEncoder(&b)
+
+	_ = enc.Encode(MustParse("K"))
+	_ = enc.Encode(MustParse("q"))
+	if b.Len() != 0 || enc.Buffered() != 3 {
+		t.Errorf("before Flush: written %d, buffered %d; want 0, 3", b.Len(), enc.Buffered())
+	}
+
+	_ = enc.Flush()
+	if b.String() != "K\nq" || enc.Buffered() != 0 {
+		t.Errorf("after Flush: written %q, buffered %d; want \"K\\nq\", 0", b.String(), enc.Buffered())
+	}
+}
+
+func TestEncoderRejectsZeroIdentifier(t *testing.T) {
+	var b strings.Builder
+	enc := NewEncoder(&b)
+
+	if err := enc.Encode(Identifier{}); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Encode(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+	_ = enc.Encode(MustParse("K"))
+	_ = enc.Flush()
+	if b.String() != "K" {
+		t.Errorf("output = %q, want \"K\"", b.String())
+	}
+}
+
+func TestEncoderWriteError(t *testing.T) {
+	enc := NewEncoder(errWriter{})
+	_ = enc.Encode(MustParse("K"))
+
+	if err := enc.Flush(); err == nil {
+		t.Error("Flush() error = nil, want the write error")
+	}
+	if err := enc.Encode(MustParse("K")); err == nil {
+		t.Error("Encode() after a write error = nil, want the write error")
+	}
+}
+
+func TestEncoderSetSeparatorPanics(t *testing.T) {
+	for _, sep := range []string{"", ";", " | ", "\x00"} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrInvalidSeparator {
+					t.Errorf("SetSeparator(%q) panic = %v, want ErrInvalidSeparator", sep, r)
+				}
+			}()
+			NewEncoder(&strings.Builder{}).SetSeparator(sep)
+		}()
+	}
+}
+
+func TestEncoderDecoderRoundTrip(t *testing.T) {
+	for _, sep := range []string{"\n", " ", ",", ", \t"} {
+		var b strings.Builder
+		enc := NewEncoder(&b)
+		enc.SetSeparator(sep)
+		for i := 0; i < identifierCount; i++ {
+			_ = enc.Encode(fromIndex(i))
+		}
+		if err := enc.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+
+		dec := NewDecoder(strings.NewReader(b.String()))
+		for i := 0; i < identifierCount; i++ {
+			id, err := dec.Decode()
+			if err != nil {
+				t.Fatalf("separator %q: Decode() #%d error = %v", sep, i, err)
+			}
+			if id != fromIndex(i) {
+				t.Errorf("separator %q: Decode() #%d = %s, want %s", sep, i, id, fromIndex(i))
+			}
+		}
+	}
+}
+
+func TestEncoderNoAllocs(t *testing.T) {
+	enc := NewEncoder(io.Discard)
+	id := MustParse("+K^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = enc.Encode(id)
+	})
+	if allocs != 0 {
+		t.Errorf("Encode() allocs = %v, want 0", allocs)
+	}
+}
+
+func TestEncoderReset(t *testing.T) {
+	var first, second strings.Builder
+	enc := NewEncoder(&first)
+	enc.SetSeparator(",")
+	_ = enc.Encode(MustParse("K"))
+
+	// Unflushed data is discarded
+	enc.Reset(&second)
+	_ = enc.Encode(MustParse("q"))
+	_ = enc.Encode(MustParse("+R"))
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if first.String() != "" || second.String() != "q,+R" {
+		t.Errorf("output = %q, %q, want \"\", \"q,+R\"", first.String(), second.String())
+	}
+}
+
+func TestEncoderReleaseAndReset(t *testing.T) {
+	var b strings.Builder
+	enc := NewEncoder(io.Discard)
+	enc.Release()
+	enc.Release()
+
+	enc.Reset(&b)
+	_ = enc.Encode(MustParse("K"))
+	_ = enc.Flush()
+	if b.String() != "K" {
+		t.Errorf("output after Release and Reset = %q, want \"K\"", b.String())
+	}
+}
+
+func TestEncoderPooledLifecycleAllocs(t *testing.T) {
+	id := MustParse("+K^")
+
+	// Only the Encoder itself is allocated; its buffer comes from the pool.
+	allocs := testing.AllocsPerRun(100, func() {
+		enc := NewEncoder(io.Discard)
+		_ = enc.Encode(id)
+		_ = enc.Flush()
+		enc.Release()
+	})
+	if allocs > 1 {
+		t.Errorf("pooled Encoder lifecycle allocates %v times, want at most 1", allocs)
+	}
+}
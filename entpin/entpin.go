@@ -0,0 +1,80 @@
+// Package entpin provides ent schema helpers for PIN identifiers.
+//
+// It lives in its own module so that the pin package itself keeps no
+// third-party dependencies.
+//
+// Example schema:
+//
+//	func (Move) Fields() []ent.Field {
+//		return []ent.Field{
+//			entpin.Field("piece"),
+//		}
+//	}
+//
+//	func (Move) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entpin.PostgresCheck("piece"),
+//		}
+//	}
+package entpin
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// columnType is the database column type for PIN strings.
+const columnType = "varchar(3)"
+
+// Field returns a string field holding a pin.Identifier, stored as its PIN
+// string in a varchar(3) column.
+//
+// Values are validated by pin.Identifier's Scan and Value methods.
+func Field(name string) ent.Field {
+	return field.String(name).
+		GoType(pin.Identifier{}).
+		SchemaType(map[string]string{
+			dialect.MySQL:    columnType,
+			dialect.Postgres: columnType,
+			dialect.SQLite:   columnType,
+		})
+}
+
+// PostgresCheck returns a table annotation adding a named check constraint
+// ("<column>_pin") that restricts column to valid PIN strings on Postgres.
+func PostgresCheck(column string) *entsql.Annotation {
+	return entsql.Checks(map[string]string{
+		column + "_pin": column + " ~ '" + pin.SQLPattern + "'",
+	})
+}
+
+// Mixin adds a PIN field to a schema.
+//
+//	func (Move) Mixin() []ent.Mixin {
+//		return []ent.Mixin{
+//			entpin.Mixin{Name: "piece"},
+//		}
+//	}
+type Mixin struct {
+	mixin.Schema
+
+	// Name is the field name. Defaults to "piece".
+	Name string
+}
+
+// Fields returns the PIN field of the mixin.
+func (m Mixin) Fields() []ent.Field {
+	name := m.Name
+	if name == "" {
+		name = "piece"
+	}
+	return []ent.Field{Field(name)}
+}
+
+// Compile-time interface check.
+var _ ent.Mixin = Mixin{}
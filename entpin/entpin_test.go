@@ -0,0 +1,73 @@
+package entpin
+
+import (
+	"reflect"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// ============================================================================
+// Field Tests
+// ============================================================================
+
+func TestField(t *testing.T) {
+	desc := Field("piece").Descriptor()
+
+	if desc.Err != nil {
+		t.Fatalf("Descriptor().Err = %v", desc.Err)
+	}
+	if desc.Name != "piece" {
+		t.Errorf("Name = %q, want \"piece\"", desc.Name)
+	}
+	if desc.Info.Type != field.TypeString {
+		t.Errorf("Info.Type = %v, want TypeString", desc.Info.Type)
+	}
+	if !desc.Info.RType.TypeEqual(reflect.TypeOf(pin.Identifier{})) {
+		t.Errorf("GoType = %v, want pin.Identifier", desc.Info.Ident)
+	}
+	for _, d := range []string{dialect.MySQL, dialect.Postgres, dialect.SQLite} {
+		if got := desc.SchemaType[d]; got != "varchar(3)" {
+			t.Errorf("SchemaType[%s] = %q, want \"varchar(3)\"", d, got)
+		}
+	}
+}
+
+// ============================================================================
+// PostgresCheck Tests
+// ============================================================================
+
+func TestPostgresCheck(t *testing.T) {
+	ant := PostgresCheck("piece")
+
+	want := `piece ~ '^[-+]?[A-Za-z]\^?$'`
+	if got := ant.Checks["piece_pin"]; got != want {
+		t.Errorf("Checks[\"piece_pin\"] = %q, want %q", got, want)
+	}
+}
+
+// ============================================================================
+// Mixin Tests
+// ============================================================================
+
+func TestMixinDefaultName(t *testing.T) {
+	fields := Mixin{}.Fields()
+
+	if len(fields) != 1 {
+		t.Fatalf("len(Fields()) = %d, want 1", len(fields))
+	}
+	if got := fields[0].Descriptor().Name; got != "piece" {
+		t.Errorf("field name = %q, want \"piece\"", got)
+	}
+}
+
+func TestMixinCustomName(t *testing.T) {
+	fields := Mixin{Name: "promoted_to"}.Fields()
+
+	if got := fields[0].Descriptor().Name; got != "promoted_to" {
+		t.Errorf("field name = %q, want \"promoted_to\"", got)
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/sashite/pin.go/v3/entpin
+
+go 1.23.0
+
+require (
+	entgo.io/ent v0.14.1
+	github.com/sashite/pin.go/v3 v3.0.0
+)
+
+require github.com/google/uuid v1.3.0 // indirect
+
+replace github.com/sashite/pin.go/v3 => ../
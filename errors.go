@@ -1,6 +1,10 @@
 package pin
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
 
 // Parsing errors.
 var (
@@ -30,4 +34,412 @@ var (
 
 	// ErrInvalidState is returned when the state is not Normal, Enhanced, or Diminished.
 	ErrInvalidState = errors.New("pin: invalid state")
+
+	// ErrInvalidIdentifier is returned when an Identifier value is not valid (e.g., the zero value).
+	ErrInvalidIdentifier = errors.New("pin: invalid identifier")
+)
+
+// State transition errors.
+var (
+	// ErrAlreadyEnhanced is returned when promoting an Enhanced identifier.
+	ErrAlreadyEnhanced = errors.New("pin: identifier is already enhanced")
+
+	// ErrAlreadyDiminished is returned when demoting a Diminished identifier.
+	ErrAlreadyDiminished = errors.New("pin: identifier is already diminished")
+)
+
+// Formatting errors.
+var (
+	// ErrInvalidScanVerb is returned when scanning an Identifier with a verb other than %v or %s.
+	ErrInvalidScanVerb = errors.New("pin: invalid scan verb")
+)
+
+// Streaming errors.
+var (
+	// ErrInvalidSeparator is returned when an Encoder separator would not be read back by a Decoder.
+	ErrInvalidSeparator = errors.New("pin: invalid separator")
+
+	// ErrUnexpectedByte is returned when a Tokenizer meets a byte its skip policy rejects.
+	ErrUnexpectedByte = errors.New("pin: unexpected byte")
+
+	// ErrTooManyTokens is returned when a Decoder reaches its token limit.
+	ErrTooManyTokens = errors.New("pin: too many tokens")
+
+	// ErrInputTooLarge is returned when a Decoder input exceeds its byte limit.
+	ErrInputTooLarge = errors.New("pin: input exceeds byte limit")
+)
+
+// Profile errors.
+var (
+	// ErrAbbrNotInProfile is returned when an abbreviation is not allowed by a Profile.
+	ErrAbbrNotInProfile = errors.New("pin: abbr not allowed by profile")
+)
+
+// Board and Zobrist errors.
+var (
+	// ErrInvalidSquare is returned when a square index is out of range, or
+	// board dimensions are not positive.
+	ErrInvalidSquare = errors.New("pin: square index out of range")
+
+	// ErrInvalidCell is returned when a cell coordinate is not a valid CELL
+	// string, or a file or rank index is out of range.
+	ErrInvalidCell = errors.New("pin: invalid cell coordinate")
+)
+
+// Sprite errors.
+var (
+	// ErrInvalidSpriteColumns is returned when a sprite sheet has no columns.
+	ErrInvalidSpriteColumns = errors.New("pin: sprite sheet must have at least one column")
+
+	// ErrInvalidSpriteIndex is returned when a sprite cell is negative, out of
+	// the columns of the sheet, or beyond its largest index.
+	ErrInvalidSpriteIndex = errors.New("pin: sprite index out of range")
+)
+
+// GGN errors.
+var (
+	// ErrGGNNotObject is returned when a GGN document is not a JSON object.
+	ErrGGNNotObject = errors.New("pin: GGN document must be a JSON object")
+)
+
+// JSON errors.
+var (
+	// ErrJSONNotArray is returned when a JSON value is expected to be an array.
+	ErrJSONNotArray = errors.New("pin: JSON value is not an array")
+
+	// ErrJSONNotString is returned when a JSON value is expected to be a string.
+	ErrJSONNotString = errors.New("pin: JSON value is not a string")
+)
+
+// IndexError records the position of an invalid element in a sequence.
+type IndexError struct {
+	// Index is the zero-based position of the element.
+	Index int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the error message, including the element index.
+func (e *IndexError) Error() string {
+	return "pin: element " + strconv.Itoa(e.Index) + ": " + strings.TrimPrefix(e.Err.Error(), "pin: ")
+}
+
+// Unwrap returns the underlying error, so errors.Is works with the sentinels.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// IndexErrors is the list of invalid elements returned by ValidateAll.
+type IndexErrors []*IndexError
+
+// Error returns the messages of all element errors, separated by "; ".
+func (e IndexErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ie := range e {
+		msgs[i] = ie.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the element errors, so errors.Is and errors.As inspect each of them.
+func (e IndexErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, ie := range e {
+		errs[i] = ie
+	}
+	return errs
+}
+
+// SyntaxError records where a PIN string is invalid.
+type SyntaxError struct {
+	// Input is the string being parsed, truncated to 32 bytes.
+	Input string
+	// Offset is the byte offset of the offending byte in Input; it equals
+	// len(Input) when a byte is missing, as for ErrEmptyInput.
+	Offset int
+	// Byte is the offending byte, or 0 when a byte is missing.
+	Byte byte
+	// Err is the parsing sentinel.
+	Err error
+}
+
+// newSyntaxError returns a SyntaxError for the byte at offset in input.
+func newSyntaxError(input string, offset int, err error) *SyntaxError {
+	e := &SyntaxError{Input: input[:min(len(input), maxTokenEcho)], Offset: offset, Err: err}
+	if offset < len(e.Input) {
+		e.Byte = e.Input[offset]
+	}
+	return e
+}
+
+// Error returns the error message, including the input and offset.
+func (e *SyntaxError) Error() string {
+	if e.Input == "" {
+		return e.Err.Error()
+	}
+	return "pin: " + strconv.Quote(e.Input) + " at offset " + strconv.Itoa(e.Offset) + ": " + strings.TrimPrefix(e.Err.Error(), "pin: ")
+}
+
+// Unwrap returns the parsing sentinel, so errors.Is works with it.
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError records an invalid token read by a Decoder or a Tokenizer,
+// or an invalid segment of a bag or a FEEN string.
+type DecodeError struct {
+	// Offset is the byte offset of the token in the input.
+	Offset int64
+	// Line and Column locate the token for readers of the input: Line
+	// counts lines from 1 and Column counts bytes from 1 within the line.
+	// Both are 0 when unknown.
+	Line, Column int
+	// Token is the token as read, truncated to 32 bytes.
+	Token string
+	// Err is the underlying parsing error.
+	Err error
+}
+
+// Error returns the error message, including the token, its offset, and
+// its line and column when known.
+func (e *DecodeError) Error() string {
+	pos := "at offset " + strconv.FormatInt(e.Offset, 10)
+	if e.Line > 0 {
+		pos += " (line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Column) + ")"
+	}
+	return "pin: token " + strconv.Quote(e.Token) + " " + pos + ": " + strings.TrimPrefix(e.Err.Error(), "pin: ")
+}
+
+// Unwrap returns the underlying error, so errors.Is works with the sentinels.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeErrors is the list of invalid tokens skipped by a Decoder in
+// recovery mode.
+type DecodeErrors []*DecodeError
+
+// Error returns the messages of all token errors, separated by "; ".
+func (e DecodeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, de := range e {
+		msgs[i] = de.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the token errors, so errors.Is and errors.As inspect each of them.
+func (e DecodeErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, de := range e {
+		errs[i] = de
+	}
+	return errs
+}
+
+// Database errors.
+var (
+	// ErrUnsupportedScanType is returned when a database value has an unsupported type.
+	ErrUnsupportedScanType = errors.New("pin: unsupported scan source type")
+
+	// ErrNullValue is returned when scanning a NULL database value into an Identifier.
+	ErrNullValue = errors.New("pin: cannot scan NULL into Identifier")
+
+	// ErrInvalidArrayLiteral is returned when a Postgres array literal is malformed.
+	ErrInvalidArrayLiteral = errors.New("pin: invalid array literal")
+
+	// ErrNullArrayElement is returned when a Postgres array contains a NULL element.
+	ErrNullArrayElement = errors.New("pin: NULL array element")
 )
+
+// Binary encoding errors.
+var (
+	// ErrInvalidBinary is returned when decoding malformed binary data.
+	ErrInvalidBinary = errors.New("pin: invalid binary encoding")
+
+	// ErrInvalidCode is returned when a compact code is not below CodeCount.
+	ErrInvalidCode = errors.New("pin: invalid compact code")
+
+	// ErrInvalidPacked is returned when decoding a malformed packed sequence.
+	ErrInvalidPacked = errors.New("pin: invalid packed encoding")
+
+	// ErrEncoderClosed is returned when writing to a closed PackedEncoder.
+	ErrEncoderClosed = errors.New("pin: encoder is closed")
+
+	// ErrInvalidSetHex is returned when decoding a malformed hex Set.
+	ErrInvalidSetHex = errors.New("pin: invalid set hex encoding")
+)
+
+// BSON errors.
+var (
+	// ErrBSONNotString is returned when a BSON value is expected to be a string.
+	ErrBSONNotString = errors.New("pin: BSON value is not a string")
+
+	// ErrInvalidBSONString is returned when a BSON string is malformed.
+	ErrInvalidBSONString = errors.New("pin: malformed BSON string")
+)
+
+// CBOR errors.
+var (
+	// ErrCBORNotString is returned when a CBOR value is expected to be a text string.
+	ErrCBORNotString = errors.New("pin: CBOR value is not a text string")
+
+	// ErrInvalidCBORString is returned when a CBOR text string is malformed.
+	ErrInvalidCBORString = errors.New("pin: malformed CBOR text string")
+)
+
+// Struct validation errors.
+var (
+	// ErrNotStruct is returned when ValidateStruct is given a value that is not a struct.
+	ErrNotStruct = errors.New("pin: value is not a struct")
+
+	// ErrRequired is returned when a required field is empty.
+	ErrRequired = errors.New("pin: required value is missing")
+
+	// ErrUnknownProfile is returned when a struct tag names a profile that was not provided.
+	ErrUnknownProfile = errors.New("pin: unknown profile")
+
+	// ErrInvalidTag is returned when a struct tag contains an unknown option.
+	ErrInvalidTag = errors.New("pin: invalid struct tag")
+
+	// ErrUnsupportedFieldType is returned when a tagged field is not a string, an Identifier, or a list of those.
+	ErrUnsupportedFieldType = errors.New("pin: unsupported field type")
+)
+
+// Verbose parsing errors.
+var (
+	// ErrUnknownKeyword is returned when a long-form description contains an unknown word.
+	ErrUnknownKeyword = errors.New("pin: unknown keyword")
+
+	// ErrDuplicateKeyword is returned when a long-form description sets an attribute twice.
+	ErrDuplicateKeyword = errors.New("pin: duplicate keyword")
+)
+
+// Qualified identifier errors.
+var (
+	// ErrMissingStyle is returned when a qualified PIN has no style separator.
+	ErrMissingStyle = errors.New("pin: missing style qualifier")
+
+	// ErrInvalidStyle is returned when a style is empty or contains a byte
+	// other than a letter, or a digit after the first letter, or when an
+	// SNN style name mixes letter cases.
+	ErrInvalidStyle = errors.New("pin: invalid style qualifier")
+)
+
+// FEEN errors.
+var (
+	// ErrInvalidFEEN is returned when a FEEN string does not have three
+	// fields separated by single spaces.
+	ErrInvalidFEEN = errors.New("pin: FEEN must have three space-separated fields")
+
+	// ErrInvalidPlacement is returned when a FEEN piece placement has an
+	// empty rank or too many squares.
+	ErrInvalidPlacement = errors.New("pin: invalid piece placement")
+
+	// ErrInvalidHands is returned when the pieces in hand of a FEEN string
+	// are not two hands separated by '/'.
+	ErrInvalidHands = errors.New("pin: invalid pieces in hand")
+
+	// ErrInvalidTurn is returned when the style turn of a FEEN string is not
+	// two styles of opposite sides separated by '/'.
+	ErrInvalidTurn = errors.New("pin: invalid style turn")
+)
+
+// SFEN errors.
+var (
+	// ErrInvalidSFEN is returned when an SFEN string does not have four
+	// fields separated by single spaces, or its move number is not positive.
+	ErrInvalidSFEN = errors.New("pin: invalid SFEN string")
+)
+
+// SAN errors.
+var (
+	// ErrInvalidSAN is returned when a string is not a SAN piece letter, or
+	// a SAN move does not start with a piece letter, a file, or castling.
+	ErrInvalidSAN = errors.New("pin: invalid SAN piece")
+)
+
+// Transition errors.
+var (
+	// ErrInvalidTransition is returned when a transition is not valid: its
+	// identifiers have different sides, or a piece moves to its own cell.
+	ErrInvalidTransition = errors.New("pin: invalid transition")
+
+	// ErrTransitionMismatch is returned when a transition cannot be applied
+	// to a board, its source or destination not holding the expected pieces.
+	ErrTransitionMismatch = errors.New("pin: transition does not match board")
+)
+
+// Bag errors.
+var (
+	// ErrInvalidCount is returned when a count prefix is zero, has a leading
+	// zero, or overflows.
+	ErrInvalidCount = errors.New("pin: invalid count")
+
+	// ErrNotEnoughCopies is returned when removing more copies of an
+	// identifier than a Bag holds.
+	ErrNotEnoughCopies = errors.New("pin: not enough copies in bag")
+)
+
+// UnderflowError records an identifier of which a Bag holds fewer copies
+// than are removed.
+type UnderflowError struct {
+	// Identifier is the identifier in short supply.
+	Identifier Identifier
+	// Have is the number of copies held.
+	Have int
+	// Want is the number of copies removed.
+	Want int
+}
+
+// Error returns the error message, including the identifier and counts.
+func (e *UnderflowError) Error() string {
+	return "pin: cannot remove " + strconv.Itoa(e.Want) + " " + e.Identifier.String() +
+		" from bag holding " + strconv.Itoa(e.Have)
+}
+
+// Unwrap returns ErrNotEnoughCopies, so errors.Is works with it.
+func (e *UnderflowError) Unwrap() error {
+	return ErrNotEnoughCopies
+}
+
+// errorCodes maps sentinel errors to their machine-readable codes.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrEmptyInput, "empty_input"},
+	{ErrInputTooLong, "input_too_long"},
+	{ErrMustContainOneLetter, "must_contain_one_letter"},
+	{ErrInvalidStateModifier, "invalid_state_modifier"},
+	{ErrInvalidTerminalMarker, "invalid_terminal_marker"},
+	{ErrInvalidAbbr, "invalid_abbr"},
+	{ErrInvalidSide, "invalid_side"},
+	{ErrInvalidState, "invalid_state"},
+	{ErrInvalidIdentifier, "invalid_identifier"},
+	{ErrAlreadyEnhanced, "already_enhanced"},
+	{ErrAlreadyDiminished, "already_diminished"},
+	{ErrAbbrNotInProfile, "abbr_not_in_profile"},
+	{ErrUnknownKeyword, "unknown_keyword"},
+	{ErrDuplicateKeyword, "duplicate_keyword"},
+	{ErrRequired, "required"},
+	{ErrMissingStyle, "missing_style"},
+	{ErrInvalidStyle, "invalid_style"},
+}
+
+// ErrorCode returns a stable, machine-readable code for err, suitable for API
+// responses (e.g., "invalid_state_modifier" for ErrInvalidStateModifier).
+//
+// Wrapped errors are matched with errors.Is. Returns "" for nil and "unknown"
+// for errors without a code.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec.code
+		}
+	}
+	return "unknown"
+}
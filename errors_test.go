@@ -74,6 +74,7 @@ func TestValidationErrorsExist(t *testing.T) {
 		ErrInvalidAbbr,
 		ErrInvalidSide,
 		ErrInvalidState,
+		ErrInvalidIdentifier,
 	}
 
 	for _, err := range validationErrors {
@@ -91,6 +92,7 @@ func TestValidationErrorMessages(t *testing.T) {
 		{ErrInvalidAbbr, "pin: invalid abbr (must be A-Z)"},
 		{ErrInvalidSide, "pin: invalid side"},
 		{ErrInvalidState, "pin: invalid state"},
+		{ErrInvalidIdentifier, "pin: invalid identifier"},
 	}
 
 	for _, tt := range tests {
@@ -106,6 +108,7 @@ func TestValidationErrorsAreDistinct(t *testing.T) {
 		ErrInvalidAbbr,
 		ErrInvalidSide,
 		ErrInvalidState,
+		ErrInvalidIdentifier,
 	}
 
 	for i, err1 := range validationErrors {
@@ -132,6 +135,54 @@ func TestErrorsIs(t *testing.T) {
 		ErrInvalidAbbr,
 		ErrInvalidSide,
 		ErrInvalidState,
+		ErrInvalidIdentifier,
+		ErrAbbrNotInProfile,
+		ErrGGNNotObject,
+		ErrJSONNotArray,
+		ErrJSONNotString,
+		ErrUnsupportedScanType,
+		ErrNullValue,
+		ErrInvalidArrayLiteral,
+		ErrNullArrayElement,
+		ErrBSONNotString,
+		ErrInvalidBSONString,
+		ErrNotStruct,
+		ErrRequired,
+		ErrUnknownProfile,
+		ErrInvalidTag,
+		ErrUnsupportedFieldType,
+		ErrUnknownKeyword,
+		ErrDuplicateKeyword,
+		ErrAlreadyEnhanced,
+		ErrAlreadyDiminished,
+		ErrInvalidBinary,
+		ErrInvalidScanVerb,
+		ErrCBORNotString,
+		ErrInvalidCBORString,
+		ErrInvalidSeparator,
+		ErrUnexpectedByte,
+		ErrTooManyTokens,
+		ErrInputTooLarge,
+		ErrInvalidCode,
+		ErrInvalidSquare,
+		ErrInvalidPacked,
+		ErrEncoderClosed,
+		ErrInvalidCount,
+		ErrInvalidSetHex,
+		ErrNotEnoughCopies,
+		ErrMissingStyle,
+		ErrInvalidStyle,
+		ErrInvalidFEEN,
+		ErrInvalidPlacement,
+		ErrInvalidHands,
+		ErrInvalidTurn,
+		ErrInvalidCell,
+		ErrInvalidTransition,
+		ErrTransitionMismatch,
+		ErrInvalidSFEN,
+		ErrInvalidSAN,
+		ErrInvalidSpriteColumns,
+		ErrInvalidSpriteIndex,
 	}
 
 	for _, err := range allErrors {
@@ -155,6 +206,54 @@ func TestErrorsHavePinPrefix(t *testing.T) {
 		ErrInvalidAbbr,
 		ErrInvalidSide,
 		ErrInvalidState,
+		ErrInvalidIdentifier,
+		ErrAbbrNotInProfile,
+		ErrGGNNotObject,
+		ErrJSONNotArray,
+		ErrJSONNotString,
+		ErrUnsupportedScanType,
+		ErrNullValue,
+		ErrInvalidArrayLiteral,
+		ErrNullArrayElement,
+		ErrBSONNotString,
+		ErrInvalidBSONString,
+		ErrNotStruct,
+		ErrRequired,
+		ErrUnknownProfile,
+		ErrInvalidTag,
+		ErrUnsupportedFieldType,
+		ErrUnknownKeyword,
+		ErrDuplicateKeyword,
+		ErrAlreadyEnhanced,
+		ErrAlreadyDiminished,
+		ErrInvalidBinary,
+		ErrInvalidScanVerb,
+		ErrCBORNotString,
+		ErrInvalidCBORString,
+		ErrInvalidSeparator,
+		ErrUnexpectedByte,
+		ErrTooManyTokens,
+		ErrInputTooLarge,
+		ErrInvalidCode,
+		ErrInvalidSquare,
+		ErrInvalidPacked,
+		ErrEncoderClosed,
+		ErrInvalidCount,
+		ErrInvalidSetHex,
+		ErrNotEnoughCopies,
+		ErrMissingStyle,
+		ErrInvalidStyle,
+		ErrInvalidFEEN,
+		ErrInvalidPlacement,
+		ErrInvalidHands,
+		ErrInvalidTurn,
+		ErrInvalidCell,
+		ErrInvalidTransition,
+		ErrTransitionMismatch,
+		ErrInvalidSFEN,
+		ErrInvalidSAN,
+		ErrInvalidSpriteColumns,
+		ErrInvalidSpriteIndex,
 	}
 
 	for _, err := range allErrors {
@@ -164,3 +263,151 @@ func TestErrorsHavePinPrefix(t *testing.T) {
 		}
 	}
 }
+
+// ============================================================================
+// UnderflowError Tests
+// ============================================================================
+
+func TestUnderflowErrorMessage(t *testing.T) {
+	err := &UnderflowError{Identifier: MustParse("+p"), Have: 1, Want: 3}
+	want := "pin: cannot remove 3 +p from bag holding 1"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, ErrNotEnoughCopies) {
+		t.Error("errors.Is(UnderflowError, ErrNotEnoughCopies) = false, want true")
+	}
+}
+
+// ============================================================================
+// IndexError Tests
+// ============================================================================
+
+func TestIndexErrorMessage(t *testing.T) {
+	err := &IndexError{Index: 3, Err: ErrInvalidStateModifier}
+
+	want := "pin: element 3: invalid state modifier"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestIndexErrorUnwrap(t *testing.T) {
+	var err error = &IndexError{Index: 0, Err: ErrEmptyInput}
+
+	if !errors.Is(err, ErrEmptyInput) {
+		t.Error("errors.Is(IndexError, ErrEmptyInput) = false, want true")
+	}
+
+	var ie *IndexError
+	if !errors.As(err, &ie) || ie.Index != 0 {
+		t.Errorf("errors.As() = %v, want *IndexError with Index 0", ie)
+	}
+}
+
+func TestIndexErrorsMessage(t *testing.T) {
+	errs := IndexErrors{
+		{Index: 1, Err: ErrEmptyInput},
+		{Index: 4, Err: ErrInvalidStateModifier},
+	}
+
+	want := "pin: element 1: empty input; pin: element 4: invalid state modifier"
+	if errs.Error() != want {
+		t.Errorf("Error() = %q, want %q", errs.Error(), want)
+	}
+	if !errors.Is(errs, ErrInvalidStateModifier) {
+		t.Error("errors.Is(IndexErrors, ErrInvalidStateModifier) = false, want true")
+	}
+}
+
+func TestDecodeErrorsMessage(t *testing.T) {
+	errs := DecodeErrors{
+		{Offset: 0, Token: "++", Err: ErrMustContainOneLetter},
+		{Offset: 5, Token: "X+", Err: ErrInvalidTerminalMarker},
+	}
+
+	want := `pin: token "++" at offset 0: must contain exactly one letter; pin: token "X+" at offset 5: invalid terminal marker`
+	if errs.Error() != want {
+		t.Errorf("Error() = %q, want %q", errs.Error(), want)
+	}
+
+	var de *DecodeError
+	if !errors.As(errs, &de) || de.Offset != 0 {
+		t.Errorf("errors.As(DecodeErrors) = %v, want the first token error", de)
+	}
+}
+
+// ============================================================================
+// SyntaxError Tests
+// ============================================================================
+
+func TestSyntaxErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  *SyntaxError
+		want string
+	}{
+		{&SyntaxError{Input: "K+", Offset: 1, Byte: '+', Err: ErrInvalidTerminalMarker}, `pin: "K+" at offset 1: invalid terminal marker`},
+		{&SyntaxError{Input: "", Offset: 0, Err: ErrEmptyInput}, "pin: empty input"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSyntaxErrorUnwrap(t *testing.T) {
+	_, err := Parse("*K")
+
+	if !errors.Is(err, ErrInvalidStateModifier) {
+		t.Error("errors.Is(SyntaxError, ErrInvalidStateModifier) = false, want true")
+	}
+	if ErrorCode(err) != "invalid_state_modifier" {
+		t.Errorf("ErrorCode() = %q, want \"invalid_state_modifier\"", ErrorCode(err))
+	}
+
+	var se *SyntaxError
+	if !errors.As(err, &se) || se.Input != "*K" || se.Offset != 0 || se.Byte != '*' {
+		t.Errorf("errors.As() = %+v, want *SyntaxError at offset 0 of \"*K\"", se)
+	}
+}
+
+// ============================================================================
+// ErrorCode Tests
+// ============================================================================
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{ErrEmptyInput, "empty_input"},
+		{ErrInputTooLong, "input_too_long"},
+		{ErrMustContainOneLetter, "must_contain_one_letter"},
+		{ErrInvalidStateModifier, "invalid_state_modifier"},
+		{ErrInvalidTerminalMarker, "invalid_terminal_marker"},
+		{ErrInvalidAbbr, "invalid_abbr"},
+		{ErrInvalidSide, "invalid_side"},
+		{ErrInvalidState, "invalid_state"},
+		{ErrInvalidIdentifier, "invalid_identifier"},
+		{ErrAlreadyEnhanced, "already_enhanced"},
+		{ErrAlreadyDiminished, "already_diminished"},
+		{ErrAbbrNotInProfile, "abbr_not_in_profile"},
+		{ErrUnknownKeyword, "unknown_keyword"},
+		{ErrDuplicateKeyword, "duplicate_keyword"},
+		{ErrRequired, "required"},
+		{ErrMissingStyle, "missing_style"},
+		{ErrInvalidStyle, "invalid_style"},
+		{&IndexError{Index: 1, Err: ErrEmptyInput}, "empty_input"},
+		{errors.New("other"), "unknown"},
+	}
+
+	for _, tt := range tests {
+		got := ErrorCode(tt.err)
+		if got != tt.want {
+			t.Errorf("ErrorCode(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package pin
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Exports of Bag and Counter tallies to CSV and JSON, for analysis tools
+// such as pandas or BI dashboards. Sides and states are written as the
+// lowercase keywords of Describe, and rows and keys follow the canonical
+// order, so exports of equal tallies are byte-identical.
+
+// sideNames and stateNames hold the lowercase keywords, by value.
+var (
+	sideNames  = [...]string{"first", "second"}
+	stateNames = [...]string{"normal", "enhanced", "diminished"}
+)
+
+// ============================================================================
+// Bag
+// ============================================================================
+
+// WriteCSV writes one CSV row per distinct identifier of the bag, after a
+// header row:
+//
+//	pin,abbr,side,state,terminal,count
+//	+P,P,first,enhanced,false,2
+//
+// The pin column holds PIN strings unchanged, so it parses back with Parse.
+// Spreadsheets may evaluate a cell starting with '+' or '-' as a formula;
+// use WriteSpreadsheetCSV for files opened in one.
+func (b *Bag) WriteCSV(w io.Writer) error {
+	return b.writeCSV(w, false)
+}
+
+// WriteSpreadsheetCSV is like WriteCSV but guards against formula injection
+// in spreadsheets: the pin cell of an enhanced or diminished identifier is
+// prefixed with an apostrophe, which spreadsheets display as text, so it
+// reads "'+P" and is no longer a PIN string. The other columns are
+// unchanged and still describe each identifier in full.
+func (b *Bag) WriteSpreadsheetCSV(w io.Writer) error {
+	return b.writeCSV(w, true)
+}
+
+// writeCSV writes the rows of WriteCSV, guarding the pin cells if guard is
+// true.
+func (b *Bag) writeCSV(w io.Writer, guard bool) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"pin", "abbr", "side", "state", "terminal", "count"}); err != nil {
+		return err
+	}
+
+	var err error
+	b.Range(func(id Identifier, count int) bool {
+		text := id.String()
+		if guard {
+			text = csvText(text)
+		}
+		err = cw.Write([]string{
+			text,
+			string(id.Abbr()),
+			sideNames[id.Side()],
+			stateNames[id.State()],
+			strconv.FormatBool(id.IsTerminal()),
+			strconv.Itoa(count),
+		})
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvText guards a CSV cell against formula injection, prefixing with an
+// apostrophe a cell that spreadsheets would evaluate.
+func csvText(s string) string {
+	if s != "" && strings.IndexByte("=+-@", s[0]) >= 0 {
+		return "'" + s
+	}
+	return s
+}
+
+// WriteJSON writes the bag as a JSON object followed by a newline, with the
+// total and the count of each distinct identifier:
+//
+//	{"total":6,"pieces":{"N":1,"P":3,"b":2}}
+func (b *Bag) WriteJSON(w io.Writer) error {
+	buf := make([]byte, 0, 32+b.Len()*(MaxQuotedLength+4))
+	buf = append(buf, `{"total":`...)
+	buf = strconv.AppendInt(buf, int64(b.Total()), 10)
+	buf = append(buf, `,"pieces":{`...)
+
+	first := true
+	b.Range(func(id Identifier, count int) bool {
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+
+		buf = id.AppendQuoted(buf)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(count), 10)
+		return true
+	})
+
+	buf = append(buf, "}}\n"...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// ============================================================================
+// Counter
+// ============================================================================
+
+// WriteCSV writes the counter as CSV rows in long format, after a header
+// row: for each side, one row per abbreviation with a nonzero count, then
+// one row per state.
+//
+//	side,dimension,key,count
+//	first,abbr,K,1
+//	first,state,normal,1
+func (c Counter) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"side", "dimension", "key", "count"}); err != nil {
+		return err
+	}
+
+	for side := range c.pieces {
+		for i, count := range c.pieces[side] {
+			if count == 0 {
+				continue
+			}
+			if err := cw.Write([]string{sideNames[side], "abbr", string(rune('A' + i)), strconv.Itoa(count)}); err != nil {
+				return err
+			}
+		}
+		for state, count := range c.states[side] {
+			if err := cw.Write([]string{sideNames[side], "state", stateNames[state], strconv.Itoa(count)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the counter as a JSON summary followed by a newline:
+// the total, then for each side its total, its counts per abbreviation
+// (nonzero only), and its counts per state.
+//
+//	{"total":2,"first":{"total":1,"abbrs":{"K":1},"states":{"normal":1,"enhanced":0,"diminished":0}},"second":{...}}
+func (c Counter) WriteJSON(w io.Writer) error {
+	buf := make([]byte, 0, 256)
+	buf = append(buf, `{"total":`...)
+	buf = strconv.AppendInt(buf, int64(c.Total()), 10)
+
+	for side := range c.pieces {
+		buf = append(buf, `,"`...)
+		buf = append(buf, sideNames[side]...)
+		buf = append(buf, `":{"total":`...)
+		buf = strconv.AppendInt(buf, int64(c.CountBySide(Side(side))), 10)
+
+		buf = append(buf, `,"abbrs":{`...)
+		first := true
+		for i, count := range c.pieces[side] {
+			if count == 0 {
+				continue
+			}
+			if !first {
+				buf = append(buf, ',')
+			}
+			first = false
+
+			buf = append(buf, '"', byte('A'+i), '"', ':')
+			buf = strconv.AppendInt(buf, int64(count), 10)
+		}
+
+		buf = append(buf, `},"states":{`...)
+		for state, count := range c.states[side] {
+			if state > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, '"')
+			buf = append(buf, stateNames[state]...)
+			buf = append(buf, `":`...)
+			buf = strconv.AppendInt(buf, int64(count), 10)
+		}
+		buf = append(buf, "}}"...)
+	}
+
+	buf = append(buf, "}\n"...)
+	_, err := w.Write(buf)
+	return err
+}
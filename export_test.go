@@ -0,0 +1,180 @@
+package pin
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBagWriteCSV(t *testing.T) {
+	hand, _ := ParseBag("2+PN3b^")
+
+	var b strings.Builder
+	if err := hand.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := "pin,abbr,side,state,terminal,count\n" +
+		"N,N,first,normal,false,1\n" +
+		"+P,P,first,enhanced,false,2\n" +
+		"b^,B,second,normal,true,3\n"
+	if b.String() != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestBagWriteCSVRoundTrip(t *testing.T) {
+	hand, _ := ParseBag("2+PN3b^-k")
+
+	var b strings.Builder
+	if err := hand.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.ReadAll() error = %v", err)
+	}
+
+	var got Bag
+	for _, row := range rows[1:] {
+		id, err := Parse(row[0])
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", row[0], err)
+		}
+		count, _ := strconv.Atoi(row[5])
+		got.add(id, count)
+	}
+	if got.String() != hand.String() {
+		t.Errorf("pin column parses back to %q, want %q", got.String(), hand.String())
+	}
+}
+
+func TestBagWriteSpreadsheetCSV(t *testing.T) {
+	hand, _ := ParseBag("-p+R^K")
+
+	var b strings.Builder
+	if err := hand.WriteSpreadsheetCSV(&b); err != nil {
+		t.Fatalf("WriteSpreadsheetCSV() error = %v", err)
+	}
+
+	want := "pin,abbr,side,state,terminal,count\n" +
+		"K,K,first,normal,false,1\n" +
+		"'+R^,R,first,enhanced,true,1\n" +
+		"'-p,P,second,diminished,false,1\n"
+	if b.String() != want {
+		t.Errorf("WriteSpreadsheetCSV() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestCSVText(t *testing.T) {
+	tests := map[string]string{
+		"":   "",
+		"K":  "K",
+		"+P": "'+P",
+		"-p": "'-p",
+		"=1": "'=1",
+		"@A": "'@A",
+	}
+
+	for in, want := range tests {
+		if got := csvText(in); got != want {
+			t.Errorf("csvText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBagWriteJSON(t *testing.T) {
+	tests := []struct {
+		bag  string
+		want string
+	}{
+		{"", `{"total":0,"pieces":{}}` + "\n"},
+		{"3P2bN", `{"total":6,"pieces":{"N":1,"P":3,"b":2}}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		bag, _ := ParseBag(tt.bag)
+
+		var b strings.Builder
+		if err := bag.WriteJSON(&b); err != nil || b.String() != tt.want {
+			t.Errorf("WriteJSON(%q) = %q, %v, want %q", tt.bag, b.String(), err, tt.want)
+		}
+		if !json.Valid([]byte(b.String())) {
+			t.Errorf("WriteJSON(%q) = %s, not valid JSON", tt.bag, b.String())
+		}
+	}
+}
+
+func TestCounterWriteCSV(t *testing.T) {
+	c := NewCounter(MustParse("K"), MustParse("+P"), MustParse("P"), MustParse("-k"))
+
+	var b strings.Builder
+	if err := c.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := "side,dimension,key,count\n" +
+		"first,abbr,K,1\n" +
+		"first,abbr,P,2\n" +
+		"first,state,normal,2\n" +
+		"first,state,enhanced,1\n" +
+		"first,state,diminished,0\n" +
+		"second,abbr,K,1\n" +
+		"second,state,normal,0\n" +
+		"second,state,enhanced,0\n" +
+		"second,state,diminished,1\n"
+	if b.String() != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestCounterWriteJSON(t *testing.T) {
+	c := NewCounter(MustParse("K"), MustParse("+P"), MustParse("P"), MustParse("-k"))
+
+	var b strings.Builder
+	if err := c.WriteJSON(&b); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	want := `{"total":4,` +
+		`"first":{"total":3,"abbrs":{"K":1,"P":2},"states":{"normal":2,"enhanced":1,"diminished":0}},` +
+		`"second":{"total":1,"abbrs":{"K":1},"states":{"normal":0,"enhanced":0,"diminished":1}}}` + "\n"
+	if b.String() != want {
+		t.Errorf("WriteJSON() =\n%s\nwant\n%s", b.String(), want)
+	}
+	if !json.Valid([]byte(b.String())) {
+		t.Errorf("WriteJSON() = %s, not valid JSON", b.String())
+	}
+}
+
+func TestCounterWriteJSONNegative(t *testing.T) {
+	d := NewCounter(MustParse("Q")).Delta(NewCounter(MustParse("P")))
+
+	var b strings.Builder
+	if err := d.WriteJSON(&b); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if !strings.Contains(b.String(), `"abbrs":{"P":-1,"Q":1}`) {
+		t.Errorf("WriteJSON() = %s, want signed counts", b.String())
+	}
+}
+
+func TestExportWriteErrors(t *testing.T) {
+	hand, _ := ParseBag("P")
+	c := hand.Counter()
+
+	for name, err := range map[string]error{
+		"Bag.WriteCSV":            hand.WriteCSV(errWriter{}),
+		"Bag.WriteSpreadsheetCSV": hand.WriteSpreadsheetCSV(errWriter{}),
+		"Bag.WriteJSON":           hand.WriteJSON(errWriter{}),
+		"Counter.WriteCSV":        c.WriteCSV(errWriter{}),
+		"Counter.WriteJSON":       c.WriteJSON(errWriter{}),
+	} {
+		if err == nil {
+			t.Errorf("%s() to a failing writer returned nil error", name)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package pin
+
+import (
+	"strconv"
+	"strings"
+)
+
+// maxPlacementSquares bounds the squares of a FEEN piece placement, so that
+// a short string such as "99999999" cannot allocate a huge board.
+const maxPlacementSquares = 1 << 12
+
+// GameState is a position as described by a FEEN (Forsyth–Edwards Enhanced
+// Notation) string, such as the initial chess position:
+//
+//	rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR / CHESS/chess
+//
+// A FEEN string has three fields separated by single spaces:
+//   - the piece placement: ranks separated by '/', each listing PIN tokens
+//     and counts of consecutive empty squares;
+//   - the pieces in hand: the hand of the first side, '/', and the hand of
+//     the second side, each in the HAND order of a Pool (by decreasing
+//     count) without its "-" for an empty hand;
+//   - the style turn: the SNN style of the side to move, '/', and that of
+//     the other side.
+//
+// See https://sashite.dev/specs/feen/1.0.0/ for the specification.
+type GameState struct {
+	// Placement holds the ranks of the board in the order written, each
+	// listing its squares from left to right. Empty squares hold the zero
+	// Identifier.
+	Placement [][]Identifier
+
+	// Hands holds the pieces in hand of each side, indexed by Side.
+	Hands [2]Bag
+
+	// Active is the style of the side to move, and Inactive that of the
+	// other side.
+	Active, Inactive Style
+}
+
+// ParseFEEN parses a FEEN string into a GameState.
+//
+// Invalid input returns a *DecodeError locating the offending part of s,
+// wrapping one of:
+//   - ErrInvalidFEEN: s does not have three fields
+//   - ErrInvalidPlacement: an empty rank, or more than 4096 squares
+//   - ErrInvalidCount: an empty-square count that is zero or has a
+//     leading zero
+//   - ErrInvalidHands: the pieces in hand are not two hands
+//   - ErrInvalidTurn: the style turn is not two styles of opposite sides
+//   - the parsing errors of Parse, ParseBag, and ParseStyle
+//
+// Canonical strings, as written by GameState.String, round-trip byte for
+// byte. Other valid strings, such as hands listing pieces out of canonical
+// order, parse to the same state as their canonical form.
+func ParseFEEN(s string) (*GameState, error) {
+	fields := strings.Split(s, " ")
+	if len(fields) != 3 {
+		return nil, segmentError(s, 0, len(s), ErrInvalidFEEN)
+	}
+	handsStart := len(fields[0]) + 1
+	turnStart := handsStart + len(fields[1]) + 1
+
+	g := new(GameState)
+
+	var err error
+	if g.Placement, err = parsePlacement(s, 0, handsStart-1); err != nil {
+		return nil, err
+	}
+	if err := g.parseHands(s, handsStart, turnStart-1); err != nil {
+		return nil, err
+	}
+	if err := g.parseTurn(s, turnStart, len(s)); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// MustParseFEEN is like ParseFEEN but panics on error.
+// Use for constants or trusted input.
+func MustParseFEEN(s string) *GameState {
+	g, err := ParseFEEN(s)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// parsePlacement parses the piece placement s[start:end].
+func parsePlacement(s string, start, end int) ([][]Identifier, error) {
+	var (
+		ranks   [][]Identifier
+		rank    []Identifier
+		squares int
+	)
+
+	for i := start; ; {
+		if i == end || s[i] == '/' {
+			if len(rank) == 0 {
+				return nil, segmentError(s, i, min(i+1, end), ErrInvalidPlacement)
+			}
+			ranks = append(ranks, rank)
+			rank = nil
+
+			if i == end {
+				return ranks, nil
+			}
+			i++
+			continue
+		}
+
+		// A count of empty squares
+		if s[i] >= '0' && s[i] <= '9' {
+			j := i
+			for j < end && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			if s[i] == '0' {
+				return nil, segmentError(s, i, j, ErrInvalidCount)
+			}
+			n, err := strconv.Atoi(s[i:j])
+			if err != nil || n > maxPlacementSquares-squares {
+				return nil, segmentError(s, i, j, ErrInvalidPlacement)
+			}
+
+			squares += n
+			for ; n > 0; n-- {
+				rank = append(rank, Identifier{})
+			}
+			i = j
+			continue
+		}
+
+		id, n, err := parsePrefix(s[i:end])
+		if err != nil {
+			return nil, segmentError(s, i, min(i+n+1, end), err)
+		}
+		if squares++; squares > maxPlacementSquares {
+			return nil, segmentError(s, i, i+n, ErrInvalidPlacement)
+		}
+		rank = append(rank, id)
+		i += n
+	}
+}
+
+// parseHands parses the pieces in hand s[start:end] into g.
+func (g *GameState) parseHands(s string, start, end int) error {
+	field := s[start:end]
+
+	sep := strings.IndexByte(field, '/')
+	if sep < 0 || strings.IndexByte(field[sep+1:], '/') >= 0 {
+		return segmentError(s, start, end, ErrInvalidHands)
+	}
+
+	for side, hand := range [2]string{field[:sep], field[sep+1:]} {
+		b, err := ParseBag(hand)
+		if err != nil {
+			// Locate the error in s rather than in the hand
+			de := err.(*DecodeError)
+			offset := start + int(de.Offset)
+			if side == int(Second) {
+				offset += sep + 1
+			}
+			return segmentError(s, offset, offset+len(de.Token), de.Err)
+		}
+		g.Hands[side] = *b
+	}
+
+	return nil
+}
+
+// parseTurn parses the style turn s[start:end] into g.
+func (g *GameState) parseTurn(s string, start, end int) error {
+	field := s[start:end]
+
+	sep := strings.IndexByte(field, '/')
+	if sep < 0 {
+		return segmentError(s, start, end, ErrInvalidTurn)
+	}
+
+	var err error
+	if g.Active, err = parseTurnStyle(s, start, start+sep); err != nil {
+		return err
+	}
+	if g.Inactive, err = parseTurnStyle(s, start+sep+1, end); err != nil {
+		return err
+	}
+	if g.Active.Side() == g.Inactive.Side() {
+		return segmentError(s, start, end, ErrInvalidTurn)
+	}
+
+	return nil
+}
+
+// parseTurnStyle parses the style s[start:end] of the style turn.
+func parseTurnStyle(s string, start, end int) (Style, error) {
+	st, err := ParseStyle(s[start:end])
+	if err != nil {
+		se := err.(*SyntaxError)
+		return Style{}, segmentError(s, start+se.Offset, end, se.Err)
+	}
+	return st, nil
+}
+
+// Turn returns the side to move, as encoded by the case of the active
+// style.
+func (g *GameState) Turn() Side {
+	return g.Active.Side()
+}
+
+// String returns the FEEN string of the state, as written by AppendTo.
+func (g *GameState) String() string {
+	return string(g.AppendTo(nil))
+}
+
+// AppendTo appends the FEEN string of the state to dst and returns the
+// result.
+//
+// The string is canonical: consecutive empty squares are counted together
+// and each hand lists its pieces in HAND order, as Pool.AppendTo does.
+// A state whose ranks are all non-empty and whose styles are valid yields a
+// string that ParseFEEN accepts.
+func (g *GameState) AppendTo(dst []byte) []byte {
+	for r, rank := range g.Placement {
+		if r > 0 {
+			dst = append(dst, '/')
+		}
+
+		empty := 0
+		for _, id := range rank {
+			if id.IsZero() {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				dst = strconv.AppendInt(dst, int64(empty), 10)
+				empty = 0
+			}
+			dst = id.AppendTo(dst)
+		}
+		if empty > 0 {
+			dst = strconv.AppendInt(dst, int64(empty), 10)
+		}
+	}
+
+	dst = append(dst, ' ')
+	dst = appendHand(dst, &g.Hands[First])
+	dst = append(dst, '/')
+	dst = appendHand(dst, &g.Hands[Second])
+
+	dst = append(dst, ' ')
+	dst = g.Active.AppendTo(dst)
+	dst = append(dst, '/')
+	return g.Inactive.AppendTo(dst)
+}
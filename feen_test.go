@@ -0,0 +1,180 @@
+package pin
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	chessFEEN = "rnbqk^bnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQK^BNR / CHESS/chess"
+	shogiFEEN = "lnsgk^gsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGK^GSNL / SHOGI/shogi"
+)
+
+func TestParseFEEN(t *testing.T) {
+	g, err := ParseFEEN(chessFEEN)
+	if err != nil {
+		t.Fatalf("ParseFEEN() error = %v", err)
+	}
+
+	if len(g.Placement) != 8 {
+		t.Fatalf("len(Placement) = %d, want 8", len(g.Placement))
+	}
+	for r, rank := range g.Placement {
+		if len(rank) != 8 {
+			t.Errorf("len(Placement[%d]) = %d, want 8", r, len(rank))
+		}
+	}
+	if got := g.Placement[0][4]; got != MustParse("k^") {
+		t.Errorf("Placement[0][4] = %v, want k^", got)
+	}
+	if got := g.Placement[3][3]; !got.IsZero() {
+		t.Errorf("Placement[3][3] = %v, want empty", got)
+	}
+	if g.Hands[First].Total() != 0 || g.Hands[Second].Total() != 0 {
+		t.Errorf("Hands = %v/%v, want empty", &g.Hands[First], &g.Hands[Second])
+	}
+	if g.Active != MustParseStyle("CHESS") || g.Inactive != MustParseStyle("chess") || g.Turn() != First {
+		t.Errorf("turn = %v/%v (%v), want CHESS/chess (First)", g.Active, g.Inactive, g.Turn())
+	}
+}
+
+func TestParseFEENHands(t *testing.T) {
+	g, err := ParseFEEN("8/8 2PB/3p SHOGI/shogi")
+	if err != nil {
+		t.Fatalf("ParseFEEN() error = %v", err)
+	}
+
+	if got := g.Hands[First].Count(MustParse("P")); got != 2 {
+		t.Errorf("first hand P count = %d, want 2", got)
+	}
+	if got := g.Hands[Second].Count(MustParse("p")); got != 3 {
+		t.Errorf("second hand p count = %d, want 3", got)
+	}
+}
+
+func TestFEENRoundTrip(t *testing.T) {
+	tests := []string{
+		chessFEEN,
+		shogiFEEN,
+		"lnsgk^gsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGK^GSNL 2PB/3p shogi/SHOGI",
+		"4k^3/8/8/8/8/8/8/4K^2+P / chess/CHESS",
+		"3/1-Q1/3 / C/s",
+		"k^K^ / x/X9",
+		"12 / A/a",
+	}
+
+	for _, s := range tests {
+		g, err := ParseFEEN(s)
+		if err != nil {
+			t.Errorf("ParseFEEN(%q) error = %v", s, err)
+			continue
+		}
+		if got := g.String(); got != s {
+			t.Errorf("ParseFEEN(%q).String() = %q", s, got)
+		}
+		if got := string(g.AppendTo([]byte("x"))); got != "x"+s {
+			t.Errorf("AppendTo() = %q, want %q", got, "x"+s)
+		}
+	}
+}
+
+func TestFEENHandsInHANDOrder(t *testing.T) {
+	const s = "8/8 3PN/2pb CHESS/chess"
+	g := MustParseFEEN(s)
+	if got := g.String(); got != s {
+		t.Errorf("String() = %q, want %q", got, s)
+	}
+	if got, want := g.Hands[First].Pool().String(), "3PN"; got != want {
+		t.Errorf("Pool().String() = %q, want %q", got, want)
+	}
+}
+
+func TestFEENCanonicalizes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"8/8 pB2P/ SHOGI/shogi", "8/8 2PBp/ SHOGI/shogi"},
+		{"8/8 N3P/b2p CHESS/chess", "8/8 3PN/2pb CHESS/chess"},
+		{"8/8 P2P/ SHOGI/shogi", "8/8 3P/ SHOGI/shogi"},
+	}
+
+	for _, tt := range tests {
+		if got := MustParseFEEN(tt.input).String(); got != tt.want {
+			t.Errorf("ParseFEEN(%q).String() = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseFEENErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int64
+		token  string
+		err    error
+	}{
+		{"", 0, "", ErrInvalidFEEN},
+		{"8/8 / C/c extra", 0, "8/8 / C/c extra", ErrInvalidFEEN},
+		{"8/8  / C/c", 0, "8/8  / C/c", ErrInvalidFEEN},
+		{" / C/c", 0, "", ErrInvalidPlacement},
+		{"8//8 / C/c", 2, "/", ErrInvalidPlacement},
+		{"8/8/ / C/c", 4, "", ErrInvalidPlacement},
+		{"08 / C/c", 0, "08", ErrInvalidCount},
+		{"4097 / C/c", 0, "4097", ErrInvalidPlacement},
+		{"99999999999999999999 / C/c", 0, "99999999999999999999", ErrInvalidPlacement},
+		{"K+/8 / C/c", 1, "+/", ErrMustContainOneLetter},
+		{"8/*K / C/c", 2, "*", ErrInvalidStateModifier},
+		{"8 P C/c", 2, "P", ErrInvalidHands},
+		{"8 P/p/ C/c", 2, "P/p/", ErrInvalidHands},
+		{"8 P/0p C/c", 4, "0", ErrInvalidCount},
+		{"8 02P/ C/c", 2, "02", ErrInvalidCount},
+		{"8 / Cc", 4, "Cc", ErrInvalidTurn},
+		{"8 / C/C", 4, "C/C", ErrInvalidTurn},
+		{"8 / Chess/c", 5, "hess", ErrInvalidStyle},
+		{"8 / C/", 6, "", ErrInvalidStyle},
+		{"8 / C/c/c", 7, "/c", ErrInvalidStyle},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFEEN(tt.input)
+
+		var de *DecodeError
+		if !errors.As(err, &de) {
+			t.Errorf("ParseFEEN(%q) error = %v, want *DecodeError", tt.input, err)
+			continue
+		}
+		if de.Offset != tt.offset || de.Token != tt.token || !errors.Is(err, tt.err) {
+			t.Errorf("ParseFEEN(%q) error = %d %q %v, want %d %q %v",
+				tt.input, de.Offset, de.Token, de.Err, tt.offset, tt.token, tt.err)
+		}
+		if de.Line != 1 || de.Column != int(de.Offset)+1 {
+			t.Errorf("ParseFEEN(%q) error at %d:%d, want line 1, column %d", tt.input, de.Line, de.Column, de.Offset+1)
+		}
+	}
+}
+
+func TestParseFEENMaxSquares(t *testing.T) {
+	rank := strings.Repeat("P", 64)
+	ranks := make([]string, 64)
+	for i := range ranks {
+		ranks[i] = rank
+	}
+	s := strings.Join(ranks, "/") + " / C/c"
+
+	if _, err := ParseFEEN(s); err != nil {
+		t.Errorf("ParseFEEN(4096 squares) error = %v", err)
+	}
+	if _, err := ParseFEEN("P" + s); !errors.Is(err, ErrInvalidPlacement) {
+		t.Errorf("ParseFEEN(4097 squares) error = %v, want ErrInvalidPlacement", err)
+	}
+}
+
+func TestMustParseFEENPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseFEEN(\"\") did not panic")
+		}
+	}()
+	MustParseFEEN("")
+}
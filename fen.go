@@ -0,0 +1,144 @@
+package pin
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FromFENPlacement converts the piece placement field of a chess FEN
+// string, such as "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR", into a
+// Board, so that chess tooling can adopt this package incrementally.
+//
+// The letters K, Q, R, B, N, and P become identifiers of the same
+// abbreviation, uppercase for the first side (white) and lowercase for the
+// second (black). Kings are terminal pieces, as in FEEN, so "K" becomes
+// "K^". Ranks are read from the eighth, the first row of the board.
+//
+// Invalid input returns a *DecodeError locating the offending part of s,
+// wrapping one of:
+//   - ErrAbbrNotInProfile: a letter outside ChessProfile
+//   - ErrInvalidCount: a count of empty squares with a leading zero
+//   - ErrInvalidPlacement: any other byte, an empty rank, ranks of
+//     different lengths, or more than 4096 squares
+func FromFENPlacement(s string) (*Board, error) {
+	ranks, err := parseProfilePlacement(s, ChessProfile, 'K')
+	if err != nil {
+		return nil, err
+	}
+
+	b := NewBoard(len(ranks), len(ranks[0]))
+	for row, rank := range ranks {
+		for col, id := range rank {
+			if !id.IsZero() {
+				b.Set(row, col, id)
+			}
+		}
+	}
+	return b, nil
+}
+
+// parseProfilePlacement parses the piece placement s of a notation of the
+// Forsyth–Edwards family, such as FEN or SFEN, whose pieces are letters of
+// profile p. A letter may be prefixed by '+' for a promoted form of p, such
+// as shogi "+P". Pieces of abbreviation king are terminal, and all ranks
+// have the same length.
+func parseProfilePlacement(s string, p *Profile, king rune) ([][]Identifier, error) {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '/', c >= '0' && c <= '9':
+		case c == '+':
+			// Only the promoted forms of the profile, such as shogi "+P"
+			var next Identifier
+			if i+1 < len(s) {
+				next = letterIdentifier(s[i+1])
+			}
+			if next.IsZero() || !p.IsDemotable(next.Enhance()) {
+				return nil, segmentError(s, i, min(i+2, len(s)), ErrInvalidPlacement)
+			}
+		default:
+			id := letterIdentifier(c)
+			if id.IsZero() {
+				return nil, segmentError(s, i, i+1, ErrInvalidPlacement)
+			}
+			if !p.Allows(id) {
+				return nil, segmentError(s, i, i+1, ErrAbbrNotInProfile)
+			}
+		}
+	}
+
+	ranks, err := parsePlacement(s, 0, len(s))
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	for _, rank := range ranks {
+		end := start + strings.IndexByte(s[start:]+"/", '/')
+		if len(rank) != len(ranks[0]) {
+			return nil, segmentError(s, start, end, ErrInvalidPlacement)
+		}
+
+		for i, id := range rank {
+			if id.Abbr() == king {
+				rank[i] = id.Terminal()
+			}
+		}
+		start = end + 1
+	}
+
+	return ranks, nil
+}
+
+// letterIdentifier returns the Normal, non-terminal identifier written as
+// the letter c, uppercase for the first side and lowercase for the second,
+// or the zero Identifier if c is not a letter.
+func letterIdentifier(c byte) Identifier {
+	switch {
+	case c >= 'A' && c <= 'Z':
+		return makeIdentifier(rune(c), First, Normal, false)
+	case c >= 'a' && c <= 'z':
+		return makeIdentifier(rune(c-'a'+'A'), Second, Normal, false)
+	default:
+		return Identifier{}
+	}
+}
+
+// ToFENPlacement converts a board into the piece placement field of a chess
+// FEN string, the reverse of FromFENPlacement.
+//
+// Each identifier is written as its letter: FEN records castling rights
+// and en passant targets in other fields, so states and terminal markers
+// are dropped. Runs of empty squares are written as their count.
+//
+// Returns ErrAbbrNotInProfile if the board holds a piece outside
+// ChessProfile.
+func ToFENPlacement(b *Board) (string, error) {
+	dst := make([]byte, 0, b.rows*(b.cols+1))
+
+	for row := 0; row < b.rows; row++ {
+		if row > 0 {
+			dst = append(dst, '/')
+		}
+
+		empty := 0
+		for _, id := range b.squares[row*b.cols : (row+1)*b.cols] {
+			if id.IsZero() {
+				empty++
+				continue
+			}
+			if !ChessProfile.Allows(id) {
+				return "", ErrAbbrNotInProfile
+			}
+			if empty > 0 {
+				dst = strconv.AppendInt(dst, int64(empty), 10)
+				empty = 0
+			}
+			dst = append(dst, id.LetterByte())
+		}
+		if empty > 0 {
+			dst = strconv.AppendInt(dst, int64(empty), 10)
+		}
+	}
+
+	return string(dst), nil
+}
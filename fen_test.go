@@ -0,0 +1,87 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+const startFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR"
+
+func TestFromFENPlacement(t *testing.T) {
+	b, err := FromFENPlacement(startFEN)
+	if err != nil {
+		t.Fatalf("FromFENPlacement() error = %v", err)
+	}
+
+	want, _ := MustParseFEEN(chessFEEN).Board()
+	if !b.Equal(want) {
+		t.Errorf("FromFENPlacement() =\n%s\nwant\n%s", b.RenderASCII(), want.RenderASCII())
+	}
+	if got, _ := b.GetCell(MustParseCell("e8")); got != MustParse("k^") {
+		t.Errorf("GetCell(e8) = %v, want k^", got)
+	}
+}
+
+func TestFromFENPlacementErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int64
+		token  string
+		err    error
+	}{
+		{"", 0, "", ErrInvalidPlacement},
+		{"8/8/8/8/8/8/8/7", 14, "7", ErrInvalidPlacement},
+		{"rnbqkbnr/ppppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR", 9, "ppppppppp", ErrInvalidPlacement},
+		{"8//8", 2, "/", ErrInvalidPlacement},
+		{"rnbqkbnr/+P7", 9, "+P", ErrInvalidPlacement},
+		{"8 w - - 0 1", 1, " ", ErrInvalidPlacement},
+		{"rnbqkbnr/7S", 10, "S", ErrAbbrNotInProfile},
+		{"08/8", 0, "08", ErrInvalidCount},
+	}
+
+	for _, tt := range tests {
+		_, err := FromFENPlacement(tt.input)
+
+		var de *DecodeError
+		if !errors.As(err, &de) || !errors.Is(err, tt.err) {
+			t.Errorf("FromFENPlacement(%q) error = %v, want %v", tt.input, err, tt.err)
+			continue
+		}
+		if de.Offset != tt.offset || de.Token != tt.token {
+			t.Errorf("FromFENPlacement(%q) error at %d %q, want %d %q", tt.input, de.Offset, de.Token, tt.offset, tt.token)
+		}
+	}
+}
+
+func TestToFENPlacement(t *testing.T) {
+	tests := []string{
+		startFEN,
+		"r1bqkb1r/pppp1ppp/2n2n2/4p3/2B1P3/5N2/PPPP1PPP/RNBQK2R",
+		"8/8/8/8/8/8/8/8",
+		"4k3/8/8/8/8/8/8/4K3",
+	}
+
+	for _, fen := range tests {
+		b, err := FromFENPlacement(fen)
+		if err != nil {
+			t.Errorf("FromFENPlacement(%q) error = %v", fen, err)
+			continue
+		}
+		if got, err := ToFENPlacement(b); err != nil || got != fen {
+			t.Errorf("ToFENPlacement(FromFENPlacement(%q)) = %q, %v", fen, got, err)
+		}
+	}
+}
+
+func TestToFENPlacementDropsStates(t *testing.T) {
+	b, _ := MustParseFEEN("+k^3/4/-P3/3+R / C/c").Board()
+
+	if got, err := ToFENPlacement(b); err != nil || got != "k3/4/P3/3R" {
+		t.Errorf("ToFENPlacement() = %q, %v, want k3/4/P3/3R", got, err)
+	}
+
+	b.Set(1, 1, MustParse("S"))
+	if _, err := ToFENPlacement(b); err != ErrAbbrNotInProfile {
+		t.Errorf("ToFENPlacement(shogi piece) error = %v, want ErrAbbrNotInProfile", err)
+	}
+}
@@ -0,0 +1,43 @@
+package pin
+
+// flagHint is appended to parsing errors reported to command-line users.
+const flagHint = " (want a PIN identifier such as K, +r, or -p^)"
+
+// Set parses s into the Identifier, implementing flag.Value, so command-line
+// tools can accept identifiers directly:
+//
+//	var piece pin.Identifier
+//	flag.Var(&piece, "piece", "piece to move")
+//
+// The returned error wraps the parsing sentinel and includes a hint about
+// the expected format.
+func (id *Identifier) Set(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return &flagError{err: err}
+	}
+
+	*id = parsed
+	return nil
+}
+
+// Type returns the type name shown in help output by pflag and Cobra,
+// implementing pflag.Value together with Set and String.
+func (id *Identifier) Type() string {
+	return "pin"
+}
+
+// flagError is a parsing error with a format hint, for command-line users.
+type flagError struct {
+	err error
+}
+
+// Error returns the parsing error message followed by the hint.
+func (e *flagError) Error() string {
+	return e.err.Error() + flagHint
+}
+
+// Unwrap returns the parsing error, so errors.Is works with the sentinels.
+func (e *flagError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,63 @@
+package pin
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+)
+
+var _ flag.Value = (*Identifier)(nil)
+
+func TestFlagSet(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var piece Identifier
+	fs.Var(&piece, "piece", "piece to move")
+
+	if err := fs.Parse([]string{"-piece=+K^"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if piece.String() != "+K^" {
+		t.Errorf("piece = %s, want +K^", piece)
+	}
+}
+
+func TestFlagSetInvalid(t *testing.T) {
+	var out bytes.Buffer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+	piece := MustParse("K")
+	fs.Var(&piece, "piece", "piece to move")
+
+	err := fs.Parse([]string{"-piece", "K+"})
+	if err == nil {
+		t.Fatal("Parse(K+) error = nil")
+	}
+
+	want := `invalid value "K+" for flag -piece: pin: "K+" at offset 1: invalid terminal marker (want a PIN identifier such as K, +r, or -p^)`
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("output = %q, want it to contain %q", out.String(), want)
+	}
+	if piece.String() != "K" {
+		t.Errorf("piece = %s after invalid input, want K unchanged", piece)
+	}
+}
+
+func TestSetErrorWrapsSentinel(t *testing.T) {
+	var id Identifier
+	err := id.Set("")
+	if !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Set(\"\") error = %v, want ErrEmptyInput", err)
+	}
+	if ErrorCode(err) != "empty_input" {
+		t.Errorf("ErrorCode(Set(\"\")) = %q, want empty_input", ErrorCode(err))
+	}
+}
+
+func TestFlagType(t *testing.T) {
+	var id Identifier
+	if got := id.Type(); got != "pin" {
+		t.Errorf("Type() = %q, want \"pin\"", got)
+	}
+}
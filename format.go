@@ -0,0 +1,122 @@
+package pin
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"unicode"
+)
+
+// Format implements fmt.Formatter.
+//
+// Supported verbs:
+//
+//	%s, %v  the PIN string, e.g. +K^
+//	%q      the quoted PIN string, e.g. "+K^"
+//	%+v     the expanded form, e.g. {abbr:K side:First state:Enhanced terminal:true}
+//	%#v     a Go constructor expression, as returned by GoString
+//
+// Width and the '-' flag pad the output as for strings. Other verbs report
+// a bad verb, like fmt does.
+func (id Identifier) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), id.String())
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			pad(f, id.GoString())
+		case f.Flag('+'):
+			pad(f, id.expanded())
+		default:
+			pad(f, id.String())
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(pin.Identifier=%s)", verb, id.String())
+	}
+}
+
+// GoString returns a Go expression constructing the Identifier, implementing
+// fmt.GoStringer, so %#v output can be pasted into code and tests:
+//
+//	pin.NewIdentifierWithOptions('K', pin.First, pin.Enhanced, true)
+//
+// The zero value is printed as pin.Identifier{}.
+func (id Identifier) GoString() string {
+	if id.IsZero() {
+		return "pin.Identifier{}"
+	}
+
+	return "pin.NewIdentifierWithOptions(" + strconv.QuoteRune(id.Abbr()) +
+		", pin." + id.Side().String() +
+		", pin." + id.State().String() +
+		", " + strconv.FormatBool(id.IsTerminal()) + ")"
+}
+
+// expanded returns the attributes of the Identifier in struct-like form.
+func (id Identifier) expanded() string {
+	return "{abbr:" + string(id.Abbr()) +
+		" side:" + id.Side().String() +
+		" state:" + id.State().String() +
+		" terminal:" + strconv.FormatBool(id.IsTerminal()) + "}"
+}
+
+// pad writes s to f, honoring the width and '-' flag of f.
+func pad(f fmt.State, s string) {
+	format := "%"
+	if f.Flag('-') {
+		format += "-"
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	fmt.Fprintf(f, format+"s", s)
+}
+
+// ============================================================================
+// Scanning
+// ============================================================================
+
+// ScanInto returns a fmt.Scanner storing a scanned PIN token into id, for use
+// with fmt.Sscan, fmt.Fscan, and related functions:
+//
+//	var id pin.Identifier
+//	_, err := fmt.Sscan("+k^", pin.ScanInto(&id))
+//
+// Identifier cannot implement fmt.Scanner itself, as its Scan method
+// implements sql.Scanner.
+//
+// The scanner reads one whitespace-delimited token with the %v or %s verb.
+// Invalid tokens return the parsing sentinels.
+func ScanInto(id *Identifier) fmt.Scanner {
+	return scanTarget{id: id}
+}
+
+// scanTarget implements fmt.Scanner for an *Identifier.
+type scanTarget struct {
+	id *Identifier
+}
+
+// Scan implements fmt.Scanner.
+func (t scanTarget) Scan(state fmt.ScanState, verb rune) error {
+	if verb != 'v' && verb != 's' {
+		return ErrInvalidScanVerb
+	}
+
+	tok, err := state.Token(true, func(r rune) bool {
+		return !unicode.IsSpace(r)
+	})
+	if err != nil {
+		return err
+	}
+	if len(tok) == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	parsed, err := ParseBytes(tok)
+	if err != nil {
+		return err
+	}
+
+	*t.id = parsed
+	return nil
+}
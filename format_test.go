@@ -0,0 +1,173 @@
+package pin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ fmt.Formatter  = Identifier{}
+	_ fmt.GoStringer = Identifier{}
+)
+
+func TestFormat(t *testing.T) {
+	id := MustParse("+K^")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%s", "+K^"},
+		{"%v", "+K^"},
+		{"%q", `"+K^"`},
+		{"%+v", "{abbr:K side:First state:Enhanced terminal:true}"},
+		{"%#v", "pin.NewIdentifierWithOptions('K', pin.First, pin.Enhanced, true)"},
+		{"%5s", "  +K^"},
+		{"%-5s|", "+K^  |"},
+		{"%5v", "  +K^"},
+		{"%-5v|", "+K^  |"},
+		{"%7q", `  "+K^"`},
+		{"%d", "%!d(pin.Identifier=+K^)"},
+	}
+
+	for _, tt := range tests {
+		got := fmt.Sprintf(tt.format, id)
+		if got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestFormatExpanded(t *testing.T) {
+	tests := []struct {
+		pin  string
+		want string
+	}{
+		{"K", "{abbr:K side:First state:Normal terminal:false}"},
+		{"-p", "{abbr:P side:Second state:Diminished terminal:false}"},
+		{"r^", "{abbr:R side:Second state:Normal terminal:true}"},
+	}
+
+	for _, tt := range tests {
+		got := fmt.Sprintf("%+v", MustParse(tt.pin))
+		if got != tt.want {
+			t.Errorf("Sprintf(%%+v, %s) = %q, want %q", tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestFormatInCollections(t *testing.T) {
+	ids := []Identifier{MustParse("K"), MustParse("+p")}
+
+	if got := fmt.Sprintf("%v", ids); got != "[K +p]" {
+		t.Errorf("Sprintf(%%v, slice) = %q, want %q", got, "[K +p]")
+	}
+	if got := fmt.Sprintf("%q", ids); got != `["K" "+p"]` {
+		t.Errorf("Sprintf(%%q, slice) = %q, want %q", got, `["K" "+p"]`)
+	}
+}
+
+func TestGoString(t *testing.T) {
+	tests := []struct {
+		id   Identifier
+		want string
+	}{
+		{MustParse("K"), "pin.NewIdentifierWithOptions('K', pin.First, pin.Normal, false)"},
+		{MustParse("-p^"), "pin.NewIdentifierWithOptions('P', pin.Second, pin.Diminished, true)"},
+		{Identifier{}, "pin.Identifier{}"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.id.GoString(); got != tt.want {
+			t.Errorf("GoString() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGoStringInCollections(t *testing.T) {
+	got := fmt.Sprintf("%#v", []Identifier{MustParse("k")})
+	want := "[]pin.Identifier{pin.NewIdentifierWithOptions('K', pin.Second, pin.Normal, false)}"
+	if got != want {
+		t.Errorf("Sprintf(%%#v, slice) = %q, want %q", got, want)
+	}
+}
+
+// ============================================================================
+// Scanning Tests
+// ============================================================================
+
+func TestScanInto(t *testing.T) {
+	var a, b Identifier
+	n, err := fmt.Sscan("  +k^\n-P", ScanInto(&a), ScanInto(&b))
+	if err != nil || n != 2 {
+		t.Fatalf("Sscan() = %d, %v, want 2, nil", n, err)
+	}
+	if a.String() != "+k^" || b.String() != "-P" {
+		t.Errorf("Sscan() = %s %s, want +k^ -P", a, b)
+	}
+}
+
+func TestScanIntoFscan(t *testing.T) {
+	r := strings.NewReader("K q r^\n")
+
+	var got []string
+	for {
+		var id Identifier
+		if _, err := fmt.Fscan(r, ScanInto(&id)); err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				t.Fatalf("Fscan() error = %v", err)
+			}
+			break
+		}
+		got = append(got, id.String())
+	}
+
+	if strings.Join(got, " ") != "K q r^" {
+		t.Errorf("Fscan() read %v, want [K q r^]", got)
+	}
+}
+
+func TestScanIntoSscanf(t *testing.T) {
+	var id Identifier
+	var square string
+	if _, err := fmt.Sscanf("+R at e4", "%v at %s", ScanInto(&id), &square); err != nil {
+		t.Fatalf("Sscanf() error = %v", err)
+	}
+	if id.String() != "+R" || square != "e4" {
+		t.Errorf("Sscanf() = %s, %s, want +R, e4", id, square)
+	}
+}
+
+func TestScanIntoErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		input  string
+		want   error
+	}{
+		{"%v", "K+", ErrInvalidTerminalMarker},
+		{"%v", "KKKK", ErrInputTooLong},
+		{"%v", "1", ErrMustContainOneLetter},
+		{"%d", "K", ErrInvalidScanVerb},
+	}
+
+	for _, tt := range tests {
+		id := MustParse("Q")
+		_, err := fmt.Sscanf(tt.input, tt.format, ScanInto(&id))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Sscanf(%q, %q) error = %v, want %v", tt.input, tt.format, err, tt.want)
+		}
+		if id.String() != "Q" {
+			t.Errorf("Sscanf(%q, %q) modified the target: %s", tt.input, tt.format, id)
+		}
+	}
+}
+
+func TestScanIntoEmpty(t *testing.T) {
+	var id Identifier
+	if _, err := fmt.Sscan("   ", ScanInto(&id)); err == nil {
+		t.Error("Sscan(blank) error = nil, want an EOF error")
+	}
+}
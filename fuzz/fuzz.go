@@ -0,0 +1,168 @@
+// Package fuzz provides native Go fuzz targets and seed corpora for the pin
+// package, so that integrations can fuzz PIN handling with the same checks:
+//
+//	func FuzzPIN(f *testing.F) {
+//		fuzz.AddSeeds(f)
+//		f.Fuzz(fuzz.RoundTrip)
+//	}
+//
+// Each target fails the test when an invariant of the pin package does not
+// hold for its input; none of them panics on invalid input.
+package fuzz
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// parseErrors are the sentinels a parsing error must wrap.
+var parseErrors = []error{
+	pin.ErrEmptyInput,
+	pin.ErrInputTooLong,
+	pin.ErrMustContainOneLetter,
+	pin.ErrInvalidStateModifier,
+	pin.ErrInvalidTerminalMarker,
+}
+
+// Seeds returns a seed corpus: every valid PIN string, in canonical order,
+// followed by invalid strings exercising each parsing error.
+func Seeds() []string {
+	seeds := make([]string, 0, 312+16)
+	for _, lower := range []bool{false, true} {
+		for c := byte('A'); c <= 'Z'; c++ {
+			letter := c
+			if lower {
+				letter += 'a' - 'A'
+			}
+			for _, prefix := range []string{"", "+", "-"} {
+				for _, suffix := range []string{"", "^"} {
+					seeds = append(seeds, prefix+string(letter)+suffix)
+				}
+			}
+		}
+	}
+
+	return append(seeds,
+		"", "+", "^", "1", "KK", "K+", "*K", "+K+", "^K^", "++K", "K^^",
+		"+K^X", "K\x00", "\xd0\x9a", "k\u200b", " K",
+	)
+}
+
+// AddSeeds adds Seeds to the corpus of f.
+func AddSeeds(f *testing.F) {
+	for _, s := range Seeds() {
+		f.Add(s)
+	}
+}
+
+// Parse checks that pin.Parse accepts s exactly when it is canonical, and
+// otherwise returns a *pin.SyntaxError wrapping a parsing sentinel.
+func Parse(t *testing.T, s string) {
+	id, err := pin.Parse(s)
+	if err != nil {
+		checkParseError(t, s, err)
+		return
+	}
+
+	if got := id.String(); got != s {
+		t.Errorf("Parse(%q).String() = %q, want the input", s, got)
+	}
+}
+
+// RoundTrip checks that every valid s survives the text, JSON, binary, and
+// byte-slice encodings unchanged.
+func RoundTrip(t *testing.T, s string) {
+	id, err := pin.Parse(s)
+	if err != nil {
+		return
+	}
+
+	if got, err := pin.ParseBytes([]byte(s)); err != nil || got != id {
+		t.Errorf("ParseBytes(%q) = %v, %v; want %v", s, got, err, id)
+	}
+
+	text, err := id.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText(%q) error = %v", s, err)
+	}
+	var fromText pin.Identifier
+	if err := fromText.UnmarshalText(text); err != nil || fromText != id {
+		t.Errorf("text round trip of %q = %v, %v", s, fromText, err)
+	}
+
+	data, err := id.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON(%q) error = %v", s, err)
+	}
+	var fromJSON pin.Identifier
+	if err := fromJSON.UnmarshalJSON(data); err != nil || fromJSON != id {
+		t.Errorf("JSON round trip of %q = %v, %v", s, fromJSON, err)
+	}
+
+	bin, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(%q) error = %v", s, err)
+	}
+	var fromBinary pin.Identifier
+	if err := fromBinary.UnmarshalBinary(bin); err != nil || fromBinary != id {
+		t.Errorf("binary round trip of %q = %v, %v", s, fromBinary, err)
+	}
+}
+
+// Canonicalize checks that pin.Canonicalize agrees with pin.IsCanonical and
+// is idempotent.
+func Canonicalize(t *testing.T, s string) {
+	c, err := pin.Canonicalize(s)
+	if ok := pin.IsCanonical(s); ok != (err == nil) {
+		t.Fatalf("IsCanonical(%q) = %v, but Canonicalize error = %v", s, ok, err)
+	}
+	if err != nil {
+		checkParseError(t, s, err)
+		return
+	}
+
+	if again, err := pin.Canonicalize(c); err != nil || again != c {
+		t.Errorf("Canonicalize(%q) = %q, %v; want it unchanged", c, again, err)
+	}
+}
+
+// checkParseError checks that err is a *pin.SyntaxError wrapping a parsing
+// sentinel.
+func checkParseError(t *testing.T, s string, err error) {
+	t.Helper()
+
+	var se *pin.SyntaxError
+	if !errors.As(err, &se) {
+		t.Fatalf("Parse(%q) error = %v, want *pin.SyntaxError", s, err)
+	}
+	for _, sentinel := range parseErrors {
+		if errors.Is(err, sentinel) {
+			return
+		}
+	}
+	t.Fatalf("Parse(%q) error = %v, want a parsing sentinel", s, err)
+}
+
+// WriteCorpus writes Seeds to dir in the format of the go command's fuzzing
+// corpus, one file per seed. Use testdata/fuzz/<FuzzTestName> to have
+// "go test" run them as regular test cases.
+func WriteCorpus(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for i, s := range Seeds() {
+		data := "go test fuzz v1\nstring(" + strconv.Quote(s) + ")\n"
+		name := filepath.Join(dir, fmt.Sprintf("seed-%03d", i))
+		if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package fuzz
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+func FuzzParse(f *testing.F) {
+	AddSeeds(f)
+	f.Fuzz(Parse)
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	AddSeeds(f)
+	f.Fuzz(RoundTrip)
+}
+
+func FuzzCanonicalize(f *testing.F) {
+	AddSeeds(f)
+	f.Fuzz(Canonicalize)
+}
+
+func TestSeeds(t *testing.T) {
+	valid := 0
+	seen := make(map[string]bool)
+	for _, s := range Seeds() {
+		if seen[s] {
+			t.Errorf("duplicate seed %q", s)
+		}
+		seen[s] = true
+		if pin.IsValid(s) {
+			valid++
+		}
+	}
+
+	if valid != 312 {
+		t.Errorf("valid seeds = %d, want 312", valid)
+	}
+}
+
+func TestWriteCorpus(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "testdata", "fuzz", "FuzzParse")
+	if err := WriteCorpus(dir); err != nil {
+		t.Fatalf("WriteCorpus() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(Seeds()) {
+		t.Errorf("files = %d, want %d", len(entries), len(Seeds()))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "go test fuzz v1\nstring(\"A\")\n"; string(data) != want {
+		t.Errorf("first file = %q, want %q", data, want)
+	}
+	if !strings.HasPrefix(entries[0].Name(), "seed-") {
+		t.Errorf("file name = %q, want a seed- prefix", entries[0].Name())
+	}
+}
@@ -0,0 +1,221 @@
+package pin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GGNKeyError reports an invalid piece reference found in a GGN document.
+type GGNKeyError struct {
+	// Path is the JSONPath location of the reference, e.g. `$["+K^"]` for
+	// a piece key or `$["P"]["e7"]["e8"][0]["perform"]["e8"]` for a piece
+	// in a move.
+	Path string
+	// Key is the piece reference as written in the document.
+	Key string
+	// Err is the underlying parsing error or ErrAbbrNotInProfile.
+	Err error
+}
+
+// Error returns the error message, including the JSONPath location.
+func (e *GGNKeyError) Error() string {
+	return "pin: GGN key at " + e.Path + ": " + strings.TrimPrefix(e.Err.Error(), "pin: ")
+}
+
+// Unwrap returns the underlying error, so errors.Is works with the sentinels.
+func (e *GGNKeyError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateGGNKeys checks every piece reference of a GGN (General Gameplay
+// Notation) document against the PIN syntax and, if profile is non-nil,
+// against the abbreviations allowed by the profile.
+//
+// GGN documents are JSON objects whose top-level keys are piece identifiers,
+// each mapping source squares to destination squares to a list of moves.
+// Pieces are also referenced within the moves: by the square conditions of
+// "require" and "prevent", other than the "empty" and "enemy" keywords, by
+// the squares of "perform", and by "gain" and "drop". Null references and
+// values of other shapes are left to GGN validators. A reference may be
+// qualified by a style prefix ("C:K"); only the part after the colon is
+// checked, as the style is outside the scope of PIN.
+//
+// All invalid references are reported, in document order. The returned
+// error is non-nil only when data is not a single well-formed JSON object,
+// with nothing but whitespace after it, in which case the report is nil.
+func ValidateGGNKeys(data []byte, profile *Profile) ([]*GGNKeyError, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, ErrGGNNotObject
+	}
+
+	v := ggnValidator{profile: profile}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+
+		// Decoding validates the move tree, walked once the key is checked
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		path := ggnPath("$", key)
+		v.check(path, key)
+		if err := v.moveTree(path, value); err != nil {
+			return nil, err
+		}
+	}
+
+	// Consume the closing brace, which must end the document
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = ErrGGNNotObject
+		}
+		return nil, err
+	}
+
+	return v.report, nil
+}
+
+// ggnValidator collects the invalid piece references of a GGN document.
+type ggnValidator struct {
+	profile *Profile
+	report  []*GGNKeyError
+}
+
+// check records ref at path if it is not a valid piece reference.
+func (v *ggnValidator) check(path, ref string) {
+	if err := validateGGNKey(ref, v.profile); err != nil {
+		v.report = append(v.report, &GGNKeyError{Path: path, Key: ref, Err: err})
+	}
+}
+
+// moveTree checks the piece references of the moves of a piece, indexed by
+// source and destination square.
+func (v *ggnValidator) moveTree(path string, tree json.RawMessage) error {
+	return rangeJSONObject(tree, func(src string, dests json.RawMessage) error {
+		return rangeJSONObject(dests, func(dest string, moves json.RawMessage) error {
+			movesPath := ggnPath(ggnPath(path, src), dest)
+			return rangeJSONArray(moves, func(i int, move json.RawMessage) error {
+				return v.move(movesPath+"["+strconv.Itoa(i)+"]", move)
+			})
+		})
+	})
+}
+
+// move checks the piece references of a single move.
+func (v *ggnValidator) move(path string, move json.RawMessage) error {
+	return rangeJSONObject(move, func(field string, value json.RawMessage) error {
+		fieldPath := ggnPath(path, field)
+		switch field {
+		case "require", "prevent":
+			return rangeJSONObject(value, func(square string, cond json.RawMessage) error {
+				if ref, ok := jsonString(cond); ok && ref != "empty" && ref != "enemy" {
+					v.check(ggnPath(fieldPath, square), ref)
+				}
+				return nil
+			})
+		case "perform":
+			return rangeJSONObject(value, func(square string, piece json.RawMessage) error {
+				if ref, ok := jsonString(piece); ok {
+					v.check(ggnPath(fieldPath, square), ref)
+				}
+				return nil
+			})
+		case "gain", "drop":
+			if ref, ok := jsonString(value); ok {
+				v.check(fieldPath, ref)
+			}
+		}
+		return nil
+	})
+}
+
+// ggnPath returns the JSONPath of the member key of the value at path.
+func ggnPath(path, key string) string {
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+// rangeJSONObject calls fn for each member of the JSON value raw, in
+// document order, if it is an object, stopping at the first error.
+func rangeJSONObject(raw json.RawMessage, fn func(key string, value json.RawMessage) error) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil || tok != json.Delim('{') {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := fn(tok.(string), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rangeJSONArray calls fn for each element of the JSON value raw, in
+// order, if it is an array, stopping at the first error.
+func rangeJSONArray(raw json.RawMessage, fn func(i int, value json.RawMessage) error) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil || tok != json.Delim('[') {
+		return err
+	}
+	for i := 0; dec.More(); i++ {
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := fn(i, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonString returns the string held by the JSON value raw, and false if
+// it is not a string.
+func jsonString(raw json.RawMessage) (string, bool) {
+	var s string
+	if len(raw) == 0 || raw[0] != '"' || json.Unmarshal(raw, &s) != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// validateGGNKey checks a single GGN piece reference.
+func validateGGNKey(key string, profile *Profile) error {
+	// Strip an optional style qualifier
+	if i := strings.IndexByte(key, ':'); i >= 0 {
+		key = key[i+1:]
+	}
+
+	id, err := Parse(key)
+	if err != nil {
+		return err
+	}
+
+	return profile.Check(id)
+}
@@ -0,0 +1,191 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+// ============================================================================
+// Valid Documents
+// ============================================================================
+
+func TestValidateGGNKeysValid(t *testing.T) {
+	doc := `{
+		"K": {"e1": {"e2": []}},
+		"+p^": {"*": {"e5": []}},
+		"C:Q": {"d1": {"d8": []}}
+	}`
+
+	report, err := ValidateGGNKeys([]byte(doc), nil)
+	if err != nil {
+		t.Fatalf("ValidateGGNKeys() error = %v", err)
+	}
+	if len(report) != 0 {
+		t.Errorf("ValidateGGNKeys() report = %v, want empty", report)
+	}
+}
+
+func TestValidateGGNKeysEmptyObject(t *testing.T) {
+	report, err := ValidateGGNKeys([]byte(`{}`), nil)
+	if err != nil || report != nil {
+		t.Errorf("ValidateGGNKeys({}) = %v, %v, want nil, nil", report, err)
+	}
+}
+
+// ============================================================================
+// Invalid Keys
+// ============================================================================
+
+func TestValidateGGNKeysReportsAllInvalidKeys(t *testing.T) {
+	doc := `{"K": {}, "KK": {}, "Q": {}, "*R": {"a1": {"a2": []}}}`
+
+	report, err := ValidateGGNKeys([]byte(doc), nil)
+	if err != nil {
+		t.Fatalf("ValidateGGNKeys() error = %v", err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("len(report) = %d, want 2", len(report))
+	}
+
+	tests := []struct {
+		path string
+		key  string
+		err  error
+	}{
+		{`$["KK"]`, "KK", ErrInvalidTerminalMarker},
+		{`$["*R"]`, "*R", ErrInvalidStateModifier},
+	}
+
+	for i, tt := range tests {
+		got := report[i]
+		if got.Path != tt.path {
+			t.Errorf("report[%d].Path = %q, want %q", i, got.Path, tt.path)
+		}
+		if got.Key != tt.key {
+			t.Errorf("report[%d].Key = %q, want %q", i, got.Key, tt.key)
+		}
+		if !errors.Is(got, tt.err) {
+			t.Errorf("report[%d] = %v, want %v", i, got, tt.err)
+		}
+	}
+}
+
+func TestValidateGGNKeysWithProfile(t *testing.T) {
+	doc := `{"K": {}, "S": {}, "C:g": {}}`
+	p := NewProfile("chess", "KQRBNP")
+
+	report, err := ValidateGGNKeys([]byte(doc), p)
+	if err != nil {
+		t.Fatalf("ValidateGGNKeys() error = %v", err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("len(report) = %d, want 2", len(report))
+	}
+	for _, e := range report {
+		if !errors.Is(e, ErrAbbrNotInProfile) {
+			t.Errorf("%v: want ErrAbbrNotInProfile", e)
+		}
+	}
+}
+
+func TestValidateGGNKeysNestedReferences(t *testing.T) {
+	doc := `{
+		"C:P": {
+			"e7": {
+				"e8": [
+					{
+						"require": {"e8": "empty", "d8": "enemy", "f8": "C:x+"},
+						"prevent": {"a1": "C:K"},
+						"perform": {"e7": null, "e8": "C:S"},
+						"gain": "C:q",
+						"drop": null
+					},
+					{"perform": {"e7": null, "e8": "C:Q"}, "gain": "?"}
+				]
+			},
+			"*": {"e4": [{"perform": {"e4": "C:P"}, "drop": "++P"}]}
+		}
+	}`
+
+	report, err := ValidateGGNKeys([]byte(doc), ChessProfile)
+	if err != nil {
+		t.Fatalf("ValidateGGNKeys() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		key  string
+		err  error
+	}{
+		{`$["C:P"]["e7"]["e8"][0]["require"]["f8"]`, "C:x+", ErrInvalidTerminalMarker},
+		{`$["C:P"]["e7"]["e8"][0]["perform"]["e8"]`, "C:S", ErrAbbrNotInProfile},
+		{`$["C:P"]["e7"]["e8"][1]["gain"]`, "?", ErrMustContainOneLetter},
+		{`$["C:P"]["*"]["e4"][0]["drop"]`, "++P", ErrMustContainOneLetter},
+	}
+
+	if len(report) != len(tests) {
+		t.Fatalf("report = %v, want %d errors", report, len(tests))
+	}
+	for i, tt := range tests {
+		got := report[i]
+		if got.Path != tt.path || got.Key != tt.key || !errors.Is(got, tt.err) {
+			t.Errorf("report[%d] = %s %q %v, want %s %q %v", i, got.Path, got.Key, got.Err, tt.path, tt.key, tt.err)
+		}
+	}
+}
+
+func TestValidateGGNKeysIgnoresOtherShapes(t *testing.T) {
+	doc := `{"K": {"e1": {"e2": [1, {"perform": ["KK"], "gain": 3, "note": "KK"}]}, "e2": "KK"}, "Q": []}`
+
+	report, err := ValidateGGNKeys([]byte(doc), nil)
+	if err != nil || len(report) != 0 {
+		t.Errorf("ValidateGGNKeys() = %v, %v, want empty report", report, err)
+	}
+}
+
+func TestGGNKeyErrorMessage(t *testing.T) {
+	e := &GGNKeyError{Path: `$["KK"]`, Key: "KK", Err: ErrInvalidTerminalMarker}
+
+	want := `pin: GGN key at $["KK"]: invalid terminal marker`
+	if e.Error() != want {
+		t.Errorf("Error() = %q, want %q", e.Error(), want)
+	}
+}
+
+// ============================================================================
+// Malformed Documents
+// ============================================================================
+
+func TestValidateGGNKeysRejectsNonObject(t *testing.T) {
+	inputs := []string{`[]`, `"K"`, `42`, `null`}
+
+	for _, input := range inputs {
+		_, err := ValidateGGNKeys([]byte(input), nil)
+		if !errors.Is(err, ErrGGNNotObject) {
+			t.Errorf("ValidateGGNKeys(%s) error = %v, want ErrGGNNotObject", input, err)
+		}
+	}
+}
+
+func TestValidateGGNKeysTrailingWhitespace(t *testing.T) {
+	if report, err := ValidateGGNKeys([]byte("{\"K\": {}}\n\t "), nil); err != nil || report != nil {
+		t.Errorf("ValidateGGNKeys() = %v, %v, want nil, nil", report, err)
+	}
+	if _, err := ValidateGGNKeys([]byte(`{} {}`), nil); !errors.Is(err, ErrGGNNotObject) {
+		t.Errorf("ValidateGGNKeys({} {}) error = %v, want ErrGGNNotObject", err)
+	}
+}
+
+func TestValidateGGNKeysRejectsMalformedJSON(t *testing.T) {
+	inputs := []string{
+		``, `{`, `{"K": }`, `{"K": {}`,
+		`{"K": {"e1": {"e2": [{"perform": {"e2": "K"`,
+		`{}garbage`, `{} {}`, `{"K": {}}]`,
+	}
+
+	for _, input := range inputs {
+		if _, err := ValidateGGNKeys([]byte(input), nil); err == nil {
+			t.Errorf("ValidateGGNKeys(%q) error = nil, want error", input)
+		}
+	}
+}
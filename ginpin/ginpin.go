@@ -0,0 +1,42 @@
+// Package ginpin binds PIN request parameters in gin handlers.
+//
+// Errors produce the same 400 response body as the httppin package. This
+// package lives in its own module so that the pin package itself keeps no
+// third-party dependencies.
+package ginpin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/sashite/pin.go/v3"
+	"github.com/sashite/pin.go/v3/httppin"
+)
+
+// BindPIN reads and parses the named path parameter, falling back to the
+// query parameter of the same name.
+//
+// On failure, the request is aborted with a 400 JSON response and the
+// *httppin.ParamError is returned:
+//
+//	r.GET("/pieces/:piece", func(c *gin.Context) {
+//		id, err := ginpin.BindPIN(c, "piece")
+//		if err != nil {
+//			return
+//		}
+//		// ...
+//	})
+func BindPIN(c *gin.Context, name string) (pin.Identifier, error) {
+	value := c.Param(name)
+	if value == "" {
+		value = c.Query(name)
+	}
+
+	id, err := httppin.Parse(name, value)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httppin.NewResponse(err))
+		return pin.Identifier{}, err
+	}
+	return id, nil
+}
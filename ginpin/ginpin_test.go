@@ -0,0 +1,82 @@
+package ginpin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/sashite/pin.go/v3/httppin"
+)
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	handler := func(c *gin.Context) {
+		id, err := BindPIN(c, "piece")
+		if err != nil {
+			return
+		}
+		c.String(http.StatusOK, id.String())
+	}
+	r.GET("/pieces/:piece", handler)
+	r.GET("/pieces", handler)
+	return r
+}
+
+// ============================================================================
+// BindPIN Tests
+// ============================================================================
+
+func TestBindPIN(t *testing.T) {
+	tests := []struct {
+		target string
+		body   string
+	}{
+		{"/pieces/-p", "-p"},
+		{"/pieces/%2BK%5E", "+K^"},
+		{"/pieces?piece=k", "k"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s status = %d, want 200", tt.target, w.Code)
+		}
+		if w.Body.String() != tt.body {
+			t.Errorf("GET %s body = %q, want %q", tt.target, w.Body.String(), tt.body)
+		}
+	}
+}
+
+func TestBindPINErrors(t *testing.T) {
+	tests := []struct {
+		target string
+		code   string
+	}{
+		{"/pieces/KK", "invalid_terminal_marker"},
+		{"/pieces", "required"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want 400", tt.target, w.Code)
+		}
+
+		var resp httppin.Response
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("GET %s: decoding body: %v", tt.target, err)
+		}
+		if resp.Error.Code != tt.code || resp.Error.Param != "piece" {
+			t.Errorf("GET %s body = %+v, want code %q for \"piece\"", tt.target, resp.Error, tt.code)
+		}
+	}
+}
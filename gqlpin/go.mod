@@ -0,0 +1,16 @@
+module github.com/sashite/pin.go/v3/gqlpin
+
+go 1.22.5
+
+require (
+	github.com/99designs/gqlgen v0.17.55
+	github.com/sashite/pin.go/v3 v3.0.0
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/sosodev/duration v1.3.1 // indirect
+	github.com/vektah/gqlparser/v2 v2.5.17 // indirect
+)
+
+replace github.com/sashite/pin.go/v3 => ../
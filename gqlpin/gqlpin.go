@@ -0,0 +1,64 @@
+// Package gqlpin provides a gqlgen scalar for PIN identifiers.
+//
+// Declare the scalar in the schema and bind it to this package in
+// gqlgen.yml; gqlgen then resolves MarshalPIN and UnmarshalPIN by name:
+//
+//	# schema.graphql
+//	scalar PIN
+//
+//	# gqlgen.yml
+//	models:
+//	  PIN:
+//	    model: github.com/sashite/pin.go/v3/gqlpin.PIN
+//
+// Fields of type PIN map to pin.Identifier in resolvers, and invalid input
+// is rejected with the pin parsing error before any resolver runs. This
+// package lives in its own module so that the pin package itself keeps no
+// third-party dependencies.
+package gqlpin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// ErrNotString is returned when a PIN input value is not a string.
+var ErrNotString = errors.New("gqlpin: PIN must be a string")
+
+// MarshalPIN writes id as a GraphQL string holding its PIN.
+//
+// The zero Identifier is written as null.
+func MarshalPIN(id pin.Identifier) graphql.Marshaler {
+	if id.IsZero() {
+		return graphql.Null
+	}
+
+	return graphql.WriterFunc(func(w io.Writer) {
+		// PIN characters never need escaping
+		buf := make([]byte, 0, pin.MaxStringLength+2)
+		buf = append(buf, '"')
+		buf = id.AppendTo(buf)
+		buf = append(buf, '"')
+		w.Write(buf)
+	})
+}
+
+// UnmarshalPIN parses a GraphQL input value holding a PIN.
+//
+// Returns pin.ErrNullValue for null, ErrNotString for any other non-string
+// value, or the pin parsing error.
+func UnmarshalPIN(v any) (pin.Identifier, error) {
+	switch v := v.(type) {
+	case string:
+		return pin.Parse(v)
+	case nil:
+		return pin.Identifier{}, pin.ErrNullValue
+	default:
+		return pin.Identifier{}, fmt.Errorf("%w, got %T", ErrNotString, v)
+	}
+}
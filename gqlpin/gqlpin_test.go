@@ -0,0 +1,92 @@
+package gqlpin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// ============================================================================
+// MarshalPIN Tests
+// ============================================================================
+
+func TestMarshalPIN(t *testing.T) {
+	tests := []struct {
+		id   pin.Identifier
+		want string
+	}{
+		{pin.MustParse("K"), `"K"`},
+		{pin.MustParse("+r"), `"+r"`},
+		{pin.MustParse("-p^"), `"-p^"`},
+		{pin.Identifier{}, `null`},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		MarshalPIN(tt.id).MarshalGQL(&buf)
+		if buf.String() != tt.want {
+			t.Errorf("MarshalPIN(%s) wrote %s, want %s", tt.id, buf.String(), tt.want)
+		}
+	}
+}
+
+// ============================================================================
+// UnmarshalPIN Tests
+// ============================================================================
+
+func TestUnmarshalPIN(t *testing.T) {
+	for _, s := range []string{"K", "+r", "-p^"} {
+		id, err := UnmarshalPIN(s)
+		if err != nil {
+			t.Errorf("UnmarshalPIN(%q) error = %v", s, err)
+			continue
+		}
+		if id.String() != s {
+			t.Errorf("UnmarshalPIN(%q) = %q", s, id.String())
+		}
+	}
+}
+
+func TestUnmarshalPINErrors(t *testing.T) {
+	tests := []struct {
+		v    any
+		want error
+	}{
+		{"", pin.ErrEmptyInput},
+		{"*K", pin.ErrInvalidStateModifier},
+		{"1", pin.ErrMustContainOneLetter},
+		{nil, pin.ErrNullValue},
+		{int64(42), ErrNotString},
+		{json.Number("1"), ErrNotString},
+		{[]any{"K"}, ErrNotString},
+	}
+
+	for _, tt := range tests {
+		if _, err := UnmarshalPIN(tt.v); !errors.Is(err, tt.want) {
+			t.Errorf("UnmarshalPIN(%#v) error = %v, want %v", tt.v, err, tt.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := pin.MustParse("+K^")
+
+	var buf bytes.Buffer
+	MarshalPIN(in).MarshalGQL(&buf)
+
+	var v any
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", buf.String(), err)
+	}
+
+	out, err := UnmarshalPIN(v)
+	if err != nil {
+		t.Fatalf("UnmarshalPIN(%#v) error = %v", v, err)
+	}
+	if out != in {
+		t.Errorf("round trip = %s, want %s", out, in)
+	}
+}
@@ -0,0 +1,148 @@
+package pin
+
+import "strconv"
+
+// emptyHand is the HAND string of an empty pool.
+const emptyHand = "-"
+
+// Pool is a pool of pieces in hand, in the Sashité HAND notation shared by
+// the other formats of the ecosystem:
+//
+//	3P2bN
+//
+// Each distinct identifier is written once, prefixed by its count in
+// decimal when greater than one, and an empty pool is written "-".
+//
+// Unlike the run-length form of Bag, which follows the canonical order,
+// HAND lists identifiers by decreasing count, ties in canonical order, so
+// the most numerous pieces come first. This ordering is part of the format
+// and will not change.
+//
+// The zero value is an empty pool ready to use.
+type Pool struct {
+	bag Bag
+}
+
+// NewPool returns a pool holding one copy of each of ids.
+//
+// Panics if an identifier is not valid (e.g., the zero value).
+func NewPool(ids ...Identifier) *Pool {
+	p := new(Pool)
+	for _, id := range ids {
+		p.Add(id)
+	}
+	return p
+}
+
+// Pool returns a pool holding the copies of the bag.
+func (b *Bag) Pool() *Pool {
+	return &Pool{bag: *b}
+}
+
+// Bag returns a bag holding the copies of the pool.
+func (p *Pool) Bag() Bag {
+	return p.bag
+}
+
+// Add inserts one copy of id into the pool.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (p *Pool) Add(id Identifier) {
+	p.bag.Add(id)
+}
+
+// Remove deletes one copy of id from the pool and reports whether one was
+// present, as when a piece is dropped.
+func (p *Pool) Remove(id Identifier) bool {
+	return p.bag.Remove(id)
+}
+
+// Count returns the number of copies of id in the pool.
+func (p *Pool) Count(id Identifier) int {
+	return p.bag.Count(id)
+}
+
+// Total returns the number of copies in the pool, all identifiers included.
+func (p *Pool) Total() int {
+	return p.bag.Total()
+}
+
+// Len returns the number of distinct identifiers in the pool.
+func (p *Pool) Len() int {
+	return p.bag.Len()
+}
+
+// Items returns the distinct identifiers of the pool and their counts, in
+// HAND order.
+func (p *Pool) Items() []Frequency {
+	return byCount(&p.bag)
+}
+
+// Range calls fn for each distinct identifier and its count, in HAND
+// order. If fn returns false, Range stops the iteration.
+func (p *Pool) Range(fn func(id Identifier, count int) bool) {
+	for _, f := range p.Items() {
+		if !fn(f.Identifier, f.Count) {
+			return
+		}
+	}
+}
+
+// String returns the HAND string of the pool, as written by AppendTo.
+func (p *Pool) String() string {
+	return string(p.AppendTo(make([]byte, 0, max(1, p.Len()*(MaxStringLength+2)))))
+}
+
+// AppendTo appends the HAND string of the pool to dst and returns the
+// result.
+func (p *Pool) AppendTo(dst []byte) []byte {
+	if p.Len() == 0 {
+		return append(dst, emptyHand...)
+	}
+	return appendHand(dst, &p.bag)
+}
+
+// appendHand appends the copies of b to dst in HAND order, writing nothing
+// for an empty bag.
+func appendHand(dst []byte, b *Bag) []byte {
+	for _, f := range byCount(b) {
+		if f.Count > 1 {
+			dst = strconv.AppendInt(dst, int64(f.Count), 10)
+		}
+		dst = f.Identifier.AppendTo(dst)
+	}
+	return dst
+}
+
+// ParsePool parses a HAND string into a Pool.
+//
+// Identifiers may come in any order and repeat, their counts adding up, so
+// that hands written by other tools are accepted; String writes them back
+// in HAND order. The empty pool is "-".
+//
+// Invalid input returns a *DecodeError, wrapping ErrEmptyInput for an
+// empty string and the errors of ParseBag otherwise.
+func ParsePool(s string) (*Pool, error) {
+	switch s {
+	case "":
+		return nil, segmentError(s, 0, 0, ErrEmptyInput)
+	case emptyHand:
+		return new(Pool), nil
+	}
+
+	b, err := ParseBag(s)
+	if err != nil {
+		return nil, err
+	}
+	return b.Pool(), nil
+}
+
+// MustParsePool is like ParsePool but panics on error.
+// Use for constants or trusted input.
+func MustParsePool(s string) *Pool {
+	p, err := ParsePool(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
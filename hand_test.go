@@ -0,0 +1,146 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPoolString(t *testing.T) {
+	tests := []struct {
+		ids  []string
+		want string
+	}{
+		{nil, "-"},
+		{[]string{"P"}, "P"},
+		{[]string{"b", "N", "P", "b", "P", "P"}, "3P2bN"},
+		{[]string{"p", "P", "+P", "-p"}, "P+Pp-p"},
+		{[]string{"r", "r", "B", "B"}, "2B2r"},
+	}
+
+	for _, tt := range tests {
+		p := new(Pool)
+		for _, s := range tt.ids {
+			p.Add(MustParse(s))
+		}
+		if got := p.String(); got != tt.want {
+			t.Errorf("Pool%v.String() = %q, want %q", tt.ids, got, tt.want)
+		}
+		if got := string(p.AppendTo([]byte("x"))); got != "x"+tt.want {
+			t.Errorf("Pool%v.AppendTo() = %q, want %q", tt.ids, got, "x"+tt.want)
+		}
+	}
+}
+
+func TestParsePool(t *testing.T) {
+	tests := []struct {
+		input string
+		total int
+		want  string
+	}{
+		{"-", 0, "-"},
+		{"3P2bN", 6, "3P2bN"},
+		{"N2b3P", 6, "3P2bN"},
+		{"P2P", 3, "3P"},
+		{"-p", 1, "-p"},
+	}
+
+	for _, tt := range tests {
+		p, err := ParsePool(tt.input)
+		if err != nil {
+			t.Errorf("ParsePool(%q) error = %v", tt.input, err)
+			continue
+		}
+		if p.Total() != tt.total || p.String() != tt.want {
+			t.Errorf("ParsePool(%q) = %q with %d copies, want %q with %d", tt.input, p, p.Total(), tt.want, tt.total)
+		}
+	}
+}
+
+func TestParsePoolErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int64
+		err    error
+	}{
+		{"", 0, ErrEmptyInput},
+		{"--", 0, ErrMustContainOneLetter},
+		{"0P", 0, ErrInvalidCount},
+		{"2P*", 2, ErrMustContainOneLetter},
+	}
+
+	for _, tt := range tests {
+		_, err := ParsePool(tt.input)
+
+		var de *DecodeError
+		if !errors.As(err, &de) || de.Offset != tt.offset || !errors.Is(err, tt.err) {
+			t.Errorf("ParsePool(%q) error = %v, want %v at offset %d", tt.input, err, tt.err, tt.offset)
+		}
+	}
+}
+
+func TestPoolAddRemove(t *testing.T) {
+	p := NewPool(MustParse("P"), MustParse("P"), MustParse("s"))
+
+	if !p.Remove(MustParse("P")) || p.Count(MustParse("P")) != 1 {
+		t.Errorf("Remove(P) left %d copies, want 1", p.Count(MustParse("P")))
+	}
+	if p.Remove(MustParse("G")) {
+		t.Error("Remove(G) = true on a pool without G")
+	}
+	if p.Total() != 2 || p.Len() != 2 {
+		t.Errorf("Total(), Len() = %d, %d, want 2, 2", p.Total(), p.Len())
+	}
+}
+
+func TestPoolItemsAndRange(t *testing.T) {
+	p := MustParsePool("b3P2N")
+
+	items := p.Items()
+	want := []Frequency{{MustParse("P"), 3}, {MustParse("N"), 2}, {MustParse("b"), 1}}
+	if len(items) != len(want) {
+		t.Fatalf("Items() = %v, want %v", items, want)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("Items()[%d] = %v, want %v", i, items[i], want[i])
+		}
+	}
+
+	n := 0
+	p.Range(func(Identifier, int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("Range() called fn %d times after false, want 1", n)
+	}
+}
+
+func TestPoolBagConversion(t *testing.T) {
+	bag, _ := ParseBag("N3P2b")
+
+	p := bag.Pool()
+	if p.String() != "3P2bN" {
+		t.Errorf("Bag.Pool() = %q, want \"3P2bN\"", p)
+	}
+
+	// The pool holds a copy
+	p.Add(MustParse("N"))
+	if bag.Count(MustParse("N")) != 1 {
+		t.Error("modifying the pool modified the bag")
+	}
+
+	b := p.Bag()
+	if b.String() != "2N3P2b" {
+		t.Errorf("Pool.Bag() = %q, want \"2N3P2b\"", b.String())
+	}
+}
+
+func TestMustParsePoolPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParsePool(\"\") did not panic")
+		}
+	}()
+	MustParsePool("")
+}
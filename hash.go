@@ -0,0 +1,70 @@
+package pin
+
+import "hash/maphash"
+
+// Hash returns a hash of the Identifier under seed.
+//
+// Two identifiers that compare equal with == have the same hash for a given
+// seed, so Hash can back custom hash tables and sharded caches without first
+// converting identifiers to strings. Like the maphash functions it builds on,
+// the result depends on the seed and must not be persisted.
+func Hash(seed maphash.Seed, id Identifier) uint64 {
+	buf := [2]byte{id.abbr, id.attrs}
+	return maphash.Bytes(seed, buf[:])
+}
+
+// Hasher hashes identifiers with a fixed seed.
+//
+// The zero Hasher is not usable; create one with NewHasher or
+// NewHasherWithSeed.
+type Hasher struct {
+	seed maphash.Seed
+}
+
+// NewHasher returns a Hasher with a new random seed.
+func NewHasher() Hasher {
+	return Hasher{seed: maphash.MakeSeed()}
+}
+
+// NewHasherWithSeed returns a Hasher using seed, so identifiers hash
+// consistently with other maphash-based hashes sharing that seed.
+func NewHasherWithSeed(seed maphash.Seed) Hasher {
+	return Hasher{seed: seed}
+}
+
+// Seed returns the seed of the Hasher.
+func (h Hasher) Seed() maphash.Seed {
+	return h.seed
+}
+
+// Hash returns the hash of id under the Hasher's seed.
+func (h Hasher) Hash(id Identifier) uint64 {
+	return Hash(h.seed, id)
+}
+
+// FNV-1a parameters for 64-bit hashes.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash returns a stable hash of the Identifier: the 64-bit FNV-1a hash of
+// its PIN string, as computed by hash/fnv.New64a.
+//
+// Unlike the package-level Hash, the result does not depend on a seed and
+// never changes between runs, platforms, or versions, so it may be persisted
+// or shared between processes and languages. It does not allocate. Invalid
+// identifiers (e.g., the zero value) hash as the empty string.
+func (id Identifier) Hash() uint64 {
+	var s string
+	if id.isValid() {
+		s = identifierStrings[id.index()]
+	}
+
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
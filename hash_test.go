@@ -0,0 +1,108 @@
+package pin
+
+import (
+	"hash/fnv"
+	"hash/maphash"
+	"testing"
+)
+
+func TestHashConsistent(t *testing.T) {
+	seed := maphash.MakeSeed()
+
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		if Hash(seed, id) != Hash(seed, MustParse(id.String())) {
+			t.Errorf("Hash(%s) differs between equal identifiers", id)
+		}
+	}
+}
+
+func TestHashDistinct(t *testing.T) {
+	seed := maphash.MakeSeed()
+	seen := make(map[uint64]Identifier, identifierCount)
+
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		h := Hash(seed, id)
+		if other, ok := seen[h]; ok {
+			t.Errorf("Hash(%s) = Hash(%s)", id, other)
+		}
+		seen[h] = id
+	}
+}
+
+func TestHashDependsOnSeed(t *testing.T) {
+	id := MustParse("K^")
+	if Hash(maphash.MakeSeed(), id) == Hash(maphash.MakeSeed(), id) {
+		t.Error("Hash() is identical under two random seeds")
+	}
+}
+
+func TestHasher(t *testing.T) {
+	seed := maphash.MakeSeed()
+	h := NewHasherWithSeed(seed)
+	id := MustParse("+p")
+
+	if h.Seed() != seed {
+		t.Error("Seed() does not return the seed given to NewHasherWithSeed")
+	}
+	if h.Hash(id) != Hash(seed, id) {
+		t.Errorf("Hasher.Hash(%s) != Hash(seed, %s)", id, id)
+	}
+
+	r := NewHasher()
+	if r.Hash(id) != r.Hash(id) {
+		t.Errorf("NewHasher().Hash(%s) is not stable", id)
+	}
+}
+
+func TestHashNoAllocs(t *testing.T) {
+	h := NewHasher()
+	id := MustParse("+K^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = h.Hash(id)
+	})
+	if allocs != 0 {
+		t.Errorf("Hash() allocates %v times, want 0", allocs)
+	}
+}
+
+func TestIdentifierHashMatchesFNV(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		f := fnv.New64a()
+		f.Write([]byte(id.String()))
+		if got, want := id.Hash(), f.Sum64(); got != want {
+			t.Errorf("%v.Hash() = %#x, want %#x", id, got, want)
+		}
+	}
+}
+
+// Hashes may be persisted; these values must never change.
+func TestIdentifierHashStableValues(t *testing.T) {
+	tests := []struct {
+		id   Identifier
+		want uint64
+	}{
+		{Identifier{}, 0xcbf29ce484222325},
+		{MustParse("K"), 0xaf64064c860233ea},
+	}
+
+	for _, tt := range tests {
+		if got := tt.id.Hash(); got != tt.want {
+			t.Errorf("%#v.Hash() = %#x, want %#x", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifierHashNoAllocs(t *testing.T) {
+	id := MustParse("+K^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = id.Hash()
+	})
+	if allocs != 0 {
+		t.Errorf("Hash allocates %v times, want 0", allocs)
+	}
+}
@@ -0,0 +1,114 @@
+// Package httppin binds PIN request parameters in net/http handlers.
+//
+// Invalid parameters produce a uniform 400 response carrying the
+// machine-readable code from pin.ErrorCode:
+//
+//	{"error": {"code": "invalid_state_modifier", "message": "...", "param": "piece"}}
+//
+// The ginpin and echopin modules build on this package for those frameworks.
+package httppin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// ParamError reports a missing or invalid PIN request parameter.
+type ParamError struct {
+	// Param is the name of the parameter.
+	Param string
+	// Err is the underlying error (pin.ErrRequired or a parsing sentinel).
+	Err error
+}
+
+// Error returns the error message, including the parameter name.
+func (e *ParamError) Error() string {
+	return "pin: parameter " + e.Param + ": " + strings.TrimPrefix(e.Err.Error(), "pin: ")
+}
+
+// Unwrap returns the underlying error, so errors.Is works with the sentinels.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// Response is the JSON body of an error response.
+type Response struct {
+	Error ResponseError `json:"error"`
+}
+
+// ResponseError describes the error in a Response.
+type ResponseError struct {
+	// Code is the machine-readable code from pin.ErrorCode.
+	Code string `json:"code"`
+	// Message is the human-readable error message.
+	Message string `json:"message"`
+	// Param is the offending parameter, if known.
+	Param string `json:"param,omitempty"`
+}
+
+// NewResponse builds the error response body for err.
+func NewResponse(err error) Response {
+	resp := Response{
+		Error: ResponseError{
+			Code:    pin.ErrorCode(err),
+			Message: err.Error(),
+		},
+	}
+
+	var pe *ParamError
+	if errors.As(err, &pe) {
+		resp.Error.Param = pe.Param
+	}
+	return resp
+}
+
+// Lookup returns the raw value of the named parameter: the path value when
+// the request was routed by a pattern declaring it (Go 1.22+ ServeMux), the
+// query parameter otherwise.
+func Lookup(r *http.Request, name string) string {
+	if v := pathValue(r, name); v != "" {
+		return v
+	}
+	return r.URL.Query().Get(name)
+}
+
+// Parse validates a raw parameter value, wrapping errors in *ParamError.
+// An empty value yields pin.ErrRequired.
+func Parse(name, value string) (pin.Identifier, error) {
+	if value == "" {
+		return pin.Identifier{}, &ParamError{Param: name, Err: pin.ErrRequired}
+	}
+
+	id, err := pin.Parse(value)
+	if err != nil {
+		return pin.Identifier{}, &ParamError{Param: name, Err: err}
+	}
+	return id, nil
+}
+
+// BindPIN reads and parses the named path or query parameter.
+//
+// Example:
+//
+//	mux.HandleFunc("GET /pieces/{piece}", func(w http.ResponseWriter, r *http.Request) {
+//		id, err := httppin.BindPIN(r, "piece")
+//		if err != nil {
+//			httppin.WriteError(w, err)
+//			return
+//		}
+//		// ...
+//	})
+func BindPIN(r *http.Request, name string) (pin.Identifier, error) {
+	return Parse(name, Lookup(r, name))
+}
+
+// WriteError writes a 400 Bad Request response whose JSON body describes err.
+func WriteError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(NewResponse(err))
+}
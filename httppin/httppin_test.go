@@ -0,0 +1,88 @@
+package httppin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// ============================================================================
+// BindPIN Tests
+// ============================================================================
+
+func TestBindPINQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pieces?piece=%2BK%5E", nil)
+
+	id, err := BindPIN(r, "piece")
+	if err != nil {
+		t.Fatalf("BindPIN() error = %v", err)
+	}
+	if id.String() != "+K^" {
+		t.Errorf("BindPIN() = %q, want \"+K^\"", id.String())
+	}
+}
+
+func TestBindPINMissing(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pieces", nil)
+
+	_, err := BindPIN(r, "piece")
+
+	var pe *ParamError
+	if !errors.As(err, &pe) || pe.Param != "piece" {
+		t.Fatalf("BindPIN() error = %v, want *ParamError for \"piece\"", err)
+	}
+	if !errors.Is(err, pin.ErrRequired) {
+		t.Errorf("BindPIN() error = %v, want ErrRequired", err)
+	}
+}
+
+func TestBindPINInvalid(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pieces?piece=KK", nil)
+
+	_, err := BindPIN(r, "piece")
+	if !errors.Is(err, pin.ErrInvalidTerminalMarker) {
+		t.Errorf("BindPIN() error = %v, want ErrInvalidTerminalMarker", err)
+	}
+}
+
+// ============================================================================
+// Response Tests
+// ============================================================================
+
+func TestWriteError(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, &ParamError{Param: "piece", Err: pin.ErrInvalidStateModifier})
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+
+	want := ResponseError{
+		Code:    "invalid_state_modifier",
+		Message: "pin: parameter piece: invalid state modifier",
+		Param:   "piece",
+	}
+	if resp.Error != want {
+		t.Errorf("body = %+v, want %+v", resp.Error, want)
+	}
+}
+
+func TestNewResponseWithoutParam(t *testing.T) {
+	resp := NewResponse(pin.ErrEmptyInput)
+
+	if resp.Error.Code != "empty_input" || resp.Error.Param != "" {
+		t.Errorf("NewResponse() = %+v", resp.Error)
+	}
+}
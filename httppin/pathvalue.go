@@ -0,0 +1,10 @@
+//go:build go1.22
+
+package httppin
+
+import "net/http"
+
+// pathValue returns the named wildcard of the request's routing pattern.
+func pathValue(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
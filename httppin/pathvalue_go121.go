@@ -0,0 +1,10 @@
+//go:build !go1.22
+
+package httppin
+
+import "net/http"
+
+// pathValue is a no-op before Go 1.22, whose ServeMux has no path wildcards.
+func pathValue(_ *http.Request, _ string) string {
+	return ""
+}
@@ -0,0 +1,46 @@
+//go:build go1.22
+
+// The module targets Go 1.21, which selects the legacy ServeMux by default.
+//go:debug httpmuxgo121=0
+
+package httppin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBindPINPathValue(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pieces/{piece}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := BindPIN(r, "piece")
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		_, _ = w.Write([]byte(id.String()))
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/pieces/-p", http.StatusOK, "-p"},
+		{"/pieces/k%5E", http.StatusOK, "k^"},
+		{"/pieces/x1", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if w.Code != tt.status {
+			t.Errorf("GET %s status = %d, want %d", tt.path, w.Code, tt.status)
+		}
+		if tt.body != "" && w.Body.String() != tt.body {
+			t.Errorf("GET %s body = %q, want %q", tt.path, w.Body.String(), tt.body)
+		}
+	}
+}
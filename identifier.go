@@ -1,5 +1,10 @@
 package pin
 
+import (
+	"cmp"
+	"slices"
+)
+
 // Identifier represents a parsed PIN (Piece Identifier Notation) identifier.
 //
 // An Identifier encodes four attributes of a piece:
@@ -13,11 +18,29 @@ package pin
 //
 // The zero value is not valid; use NewIdentifier, NewIdentifierWithOptions,
 // or Parse to create valid instances.
+//
+// An Identifier occupies two bytes, so large slices and boards of
+// identifiers stay cache-friendly.
 type Identifier struct {
-	abbr     rune
-	side     Side
-	state    State
-	terminal bool
+	abbr  byte  // uppercase letter A-Z, or 0 for the zero value
+	attrs uint8 // side, state, and terminal status; see the attr constants
+}
+
+// Layout of Identifier.attrs.
+const (
+	attrSide       = 0x01 // 0 = First, 1 = Second
+	attrStateMask  = 0x06 // State, shifted by attrStateShift
+	attrStateShift = 1
+	attrTerminal   = 0x08
+)
+
+// makeIdentifier packs already validated attributes into an Identifier.
+func makeIdentifier(abbr rune, side Side, state State, terminal bool) Identifier {
+	attrs := uint8(side)&attrSide | uint8(state)<<attrStateShift&attrStateMask
+	if terminal {
+		attrs |= attrTerminal
+	}
+	return Identifier{abbr: byte(abbr), attrs: attrs}
 }
 
 // ============================================================================
@@ -57,12 +80,7 @@ func NewIdentifierWithOptions(abbr rune, side Side, state State, terminal bool)
 		panic(ErrInvalidState)
 	}
 
-	return Identifier{
-		abbr:     abbr,
-		side:     side,
-		state:    state,
-		terminal: terminal,
-	}
+	return makeIdentifier(abbr, side, state, terminal)
 }
 
 // ============================================================================
@@ -71,22 +89,28 @@ func NewIdentifierWithOptions(abbr rune, side Side, state State, terminal bool)
 
 // Abbr returns the piece name abbreviation as an uppercase rune (A-Z).
 func (id Identifier) Abbr() rune {
-	return id.abbr
+	return rune(id.abbr)
 }
 
 // Side returns the piece side.
 func (id Identifier) Side() Side {
-	return id.side
+	return Side(id.attrs & attrSide)
 }
 
 // State returns the piece state.
 func (id Identifier) State() State {
-	return id.state
+	return State(id.attrs & attrStateMask >> attrStateShift)
 }
 
 // IsTerminal returns the terminal status.
 func (id Identifier) IsTerminal() bool {
-	return id.terminal
+	return id.attrs&attrTerminal != 0
+}
+
+// IsZero reports whether the Identifier is the zero value, which is not a
+// valid identifier. It lets the "omitzero" JSON option skip unset fields.
+func (id Identifier) IsZero() bool {
+	return id == Identifier{}
 }
 
 // ============================================================================
@@ -100,7 +124,12 @@ func (id Identifier) IsTerminal() bool {
 //	NewIdentifier('K', First).String()                              // "K"
 //	NewIdentifierWithOptions('R', Second, Enhanced, false).String() // "+r"
 //	NewIdentifierWithOptions('K', First, Normal, true).String()     // "K^"
+//
+// Valid identifiers return a precomputed string and never allocate.
 func (id Identifier) String() string {
+	if id.isValid() {
+		return identifierStrings[id.index()]
+	}
 	buf := make([]byte, 0, MaxStringLength)
 	return string(id.AppendTo(buf))
 }
@@ -109,29 +138,68 @@ func (id Identifier) String() string {
 // This is the zero-allocation primitive for high-performance serialization.
 func (id Identifier) AppendTo(dst []byte) []byte {
 	// 1. State prefix
-	switch id.state {
+	switch id.State() {
 	case Enhanced:
 		dst = append(dst, enhancedPrefix)
 	case Diminished:
 		dst = append(dst, diminishedPrefix)
 	}
 
-	// 2. Letter (case determined by side)
-	dst = append(dst, id.Letter()...)
+	// 2. Letter (case determined by side), as a single ASCII byte
+	dst = append(dst, id.LetterByte())
 
 	// 3. Terminal suffix
-	if id.terminal {
+	if id.IsTerminal() {
 		dst = append(dst, terminalSuffix)
 	}
 
 	return dst
 }
 
+// AppendQuoted appends the PIN string representation to dst as a quoted
+// string, as in JSON, and returns the result. PIN characters never need
+// escaping, so the quoted form is valid JSON, Go, and CSV alike.
+func (id Identifier) AppendQuoted(dst []byte) []byte {
+	dst = append(dst, '"')
+	dst = id.AppendTo(dst)
+	return append(dst, '"')
+}
+
+// EncodedLen returns the length of the PIN string representation, between
+// 1 and MaxStringLength, without building it.
+func (id Identifier) EncodedLen() int {
+	n := 1
+	if id.State() != Normal {
+		n++
+	}
+	if id.IsTerminal() {
+		n++
+	}
+	return n
+}
+
+// AppendAllTo appends the PIN strings of ids to dst, separated by sep, and
+// returns the result. A zero sep concatenates the strings, as in hand
+// notations such as "PPb".
+//
+// The buffer grows at most once, so serializing a large slice costs a
+// single pass and no per-identifier allocation.
+func AppendAllTo(dst []byte, ids []Identifier, sep byte) []byte {
+	dst = slices.Grow(dst, MaxEncodedLen(len(ids)))
+	for i, id := range ids {
+		if i > 0 && sep != 0 {
+			dst = append(dst, sep)
+		}
+		dst = id.AppendTo(dst)
+	}
+	return dst
+}
+
 // Letter returns the letter component of the PIN.
 // Returns uppercase for First player, lowercase for Second player.
 func (id Identifier) Letter() string {
-	r := id.abbr
-	if id.side == Second {
+	r := id.Abbr()
+	if id.Side() == Second {
 		r = r - 'A' + 'a'
 	}
 	return string(r)
@@ -140,7 +208,7 @@ func (id Identifier) Letter() string {
 // Prefix returns the state prefix of the PIN.
 // Returns "+" for Enhanced, "-" for Diminished, "" for Normal.
 func (id Identifier) Prefix() string {
-	switch id.state {
+	switch id.State() {
 	case Enhanced:
 		return "+"
 	case Diminished:
@@ -153,34 +221,101 @@ func (id Identifier) Prefix() string {
 // Suffix returns the terminal suffix of the PIN.
 // Returns "^" if terminal, "" otherwise.
 func (id Identifier) Suffix() string {
-	if id.terminal {
+	if id.IsTerminal() {
 		return "^"
 	}
 	return ""
 }
 
+// LetterByte returns the letter component of the PIN as an ASCII byte,
+// like Letter but without allocating.
+func (id Identifier) LetterByte() byte {
+	if id.Side() == Second {
+		return id.abbr + 'a' - 'A'
+	}
+	return id.abbr
+}
+
+// PrefixByte returns the state prefix of the PIN as a byte: '+' for
+// Enhanced, '-' for Diminished, 0 for Normal.
+func (id Identifier) PrefixByte() byte {
+	switch id.State() {
+	case Enhanced:
+		return enhancedPrefix
+	case Diminished:
+		return diminishedPrefix
+	default:
+		return 0
+	}
+}
+
+// SuffixByte returns the terminal suffix of the PIN as a byte: '^' if
+// terminal, 0 otherwise.
+func (id Identifier) SuffixByte() byte {
+	if id.IsTerminal() {
+		return terminalSuffix
+	}
+	return 0
+}
+
 // ============================================================================
 // State Transformations
 // ============================================================================
 
 // Enhance returns a new Identifier with Enhanced state.
 func (id Identifier) Enhance() Identifier {
-	id.state = Enhanced
-	return id
+	return id.withState(Enhanced)
 }
 
 // Diminish returns a new Identifier with Diminished state.
 func (id Identifier) Diminish() Identifier {
-	id.state = Diminished
-	return id
+	return id.withState(Diminished)
 }
 
 // Normalize returns a new Identifier with Normal state.
 func (id Identifier) Normalize() Identifier {
-	id.state = Normal
+	return id.withState(Normal)
+}
+
+// withState returns a copy of the Identifier with the given, already
+// validated, state.
+func (id Identifier) withState(state State) Identifier {
+	id.attrs = id.attrs&^attrStateMask | uint8(state)<<attrStateShift
 	return id
 }
 
+// Promote returns a new Identifier one state step up: Diminished becomes
+// Normal, and Normal becomes Enhanced.
+//
+// Unlike Enhance, Promote never overwrites silently: it returns
+// ErrAlreadyEnhanced if the Identifier is already Enhanced.
+func (id Identifier) Promote() (Identifier, error) {
+	switch id.State() {
+	case Diminished:
+		return id.withState(Normal), nil
+	case Normal:
+		return id.withState(Enhanced), nil
+	default:
+		return id, ErrAlreadyEnhanced
+	}
+}
+
+// Demote returns a new Identifier one state step down: Enhanced becomes
+// Normal, and Normal becomes Diminished.
+//
+// Unlike Diminish, Demote never overwrites silently: it returns
+// ErrAlreadyDiminished if the Identifier is already Diminished.
+func (id Identifier) Demote() (Identifier, error) {
+	switch id.State() {
+	case Enhanced:
+		return id.withState(Normal), nil
+	case Normal:
+		return id.withState(Diminished), nil
+	default:
+		return id, ErrAlreadyDiminished
+	}
+}
+
 // ============================================================================
 // Side Transformations
 // ============================================================================
@@ -188,11 +323,7 @@ func (id Identifier) Normalize() Identifier {
 // Flip returns a new Identifier with the opposite side.
 // First becomes Second, Second becomes First.
 func (id Identifier) Flip() Identifier {
-	if id.side == First {
-		id.side = Second
-	} else {
-		id.side = First
-	}
+	id.attrs ^= attrSide
 	return id
 }
 
@@ -202,13 +333,13 @@ func (id Identifier) Flip() Identifier {
 
 // Terminal returns a new Identifier marked as terminal.
 func (id Identifier) Terminal() Identifier {
-	id.terminal = true
+	id.attrs |= attrTerminal
 	return id
 }
 
 // NonTerminal returns a new Identifier unmarked as terminal.
 func (id Identifier) NonTerminal() Identifier {
-	id.terminal = false
+	id.attrs &^= attrTerminal
 	return id
 }
 
@@ -231,7 +362,7 @@ func (id Identifier) WithAbbr(abbr rune) Identifier {
 		panic(ErrInvalidAbbr)
 	}
 
-	id.abbr = abbr
+	id.abbr = byte(abbr)
 	return id
 }
 
@@ -243,7 +374,7 @@ func (id Identifier) WithSide(side Side) Identifier {
 		panic(ErrInvalidSide)
 	}
 
-	id.side = side
+	id.attrs = id.attrs&^attrSide | uint8(side)
 	return id
 }
 
@@ -255,14 +386,15 @@ func (id Identifier) WithState(state State) Identifier {
 		panic(ErrInvalidState)
 	}
 
-	id.state = state
-	return id
+	return id.withState(state)
 }
 
 // WithTerminal returns a new Identifier with the specified terminal status.
 func (id Identifier) WithTerminal(terminal bool) Identifier {
-	id.terminal = terminal
-	return id
+	if terminal {
+		return id.Terminal()
+	}
+	return id.NonTerminal()
 }
 
 // ============================================================================
@@ -271,17 +403,17 @@ func (id Identifier) WithTerminal(terminal bool) Identifier {
 
 // IsNormal reports whether the Identifier has Normal state.
 func (id Identifier) IsNormal() bool {
-	return id.state == Normal
+	return id.State() == Normal
 }
 
 // IsEnhanced reports whether the Identifier has Enhanced state.
 func (id Identifier) IsEnhanced() bool {
-	return id.state == Enhanced
+	return id.State() == Enhanced
 }
 
 // IsDiminished reports whether the Identifier has Diminished state.
 func (id Identifier) IsDiminished() bool {
-	return id.state == Diminished
+	return id.State() == Diminished
 }
 
 // ============================================================================
@@ -290,12 +422,12 @@ func (id Identifier) IsDiminished() bool {
 
 // IsFirstPlayer reports whether the Identifier belongs to the first player.
 func (id Identifier) IsFirstPlayer() bool {
-	return id.side == First
+	return id.Side() == First
 }
 
 // IsSecondPlayer reports whether the Identifier belongs to the second player.
 func (id Identifier) IsSecondPlayer() bool {
-	return id.side == Second
+	return id.Side() == Second
 }
 
 // ============================================================================
@@ -309,15 +441,153 @@ func (id Identifier) SameAbbr(other Identifier) bool {
 
 // SameSide reports whether two Identifiers have the same side.
 func (id Identifier) SameSide(other Identifier) bool {
-	return id.side == other.side
+	return id.attrs&attrSide == other.attrs&attrSide
 }
 
 // SameState reports whether two Identifiers have the same state.
 func (id Identifier) SameState(other Identifier) bool {
-	return id.state == other.state
+	return id.attrs&attrStateMask == other.attrs&attrStateMask
 }
 
 // SameTerminal reports whether two Identifiers have the same terminal status.
 func (id Identifier) SameTerminal(other Identifier) bool {
-	return id.terminal == other.terminal
+	return id.attrs&attrTerminal == other.attrs&attrTerminal
+}
+
+// SamePiece reports whether two Identifiers denote the same underlying
+// piece: same abbreviation and side, whatever their state and terminal
+// status. A promoted pawn is the same piece as the pawn it was.
+func (id Identifier) SamePiece(other Identifier) bool {
+	return id.abbr == other.abbr && (id.attrs^other.attrs)&attrSide == 0
+}
+
+// EqualIgnoringState reports whether two Identifiers are equal except
+// possibly for their state.
+func (id Identifier) EqualIgnoringState(other Identifier) bool {
+	return id.abbr == other.abbr && (id.attrs^other.attrs)&^attrStateMask == 0
+}
+
+// EqualIgnoringTerminal reports whether two Identifiers are equal except
+// possibly for their terminal status.
+func (id Identifier) EqualIgnoringTerminal(other Identifier) bool {
+	return id.abbr == other.abbr && (id.attrs^other.attrs)&^attrTerminal == 0
+}
+
+// Compare returns -1, 0, or +1 depending on whether a sorts before, with,
+// or after b in canonical order: by side, then abbreviation, then state
+// (Normal, Enhanced, Diminished), then terminal status (non-terminal
+// first). Invalid identifiers, such as the zero value, sort first.
+//
+// The order matches the compact codes and is part of the API: it will not
+// change across versions. Compare suits slices.SortFunc:
+//
+//	slices.SortFunc(ids, pin.Compare)
+func Compare(a, b Identifier) int {
+	return cmp.Compare(a.sortKey(), b.sortKey())
+}
+
+// Less reports whether id sorts before other in canonical order, as
+// defined by Compare.
+func (id Identifier) Less(other Identifier) bool {
+	return id.sortKey() < other.sortKey()
+}
+
+// sortKey returns the position of id in canonical order, shifted by one so
+// that invalid identifiers come first.
+func (id Identifier) sortKey() int {
+	if !id.isValid() {
+		return 0
+	}
+	return id.index() + 1
+}
+
+// EqualString reports whether s is the PIN string representation of the
+// Identifier. It neither parses s nor allocates, which suits hot loops
+// scanning text for specific pieces.
+func (id Identifier) EqualString(s string) bool {
+	return equalToken(id, s)
+}
+
+// EqualBytes is like EqualString but takes a byte slice.
+func (id Identifier) EqualBytes(b []byte) bool {
+	return equalToken(id, b)
+}
+
+// equalToken reports whether tok is the PIN string representation of id.
+func equalToken[T string | []byte](id Identifier, tok T) bool {
+	if len(tok) != id.EncodedLen() {
+		return false
+	}
+
+	i := 0
+	if p := id.PrefixByte(); p != 0 {
+		if tok[0] != p {
+			return false
+		}
+		i++
+	}
+
+	if tok[i] != id.LetterByte() {
+		return false
+	}
+
+	return !id.IsTerminal() || tok[i+1] == id.SuffixByte()
+}
+
+// ============================================================================
+// Canonical Ordering (internal)
+// ============================================================================
+
+// identifierCount is the number of distinct valid identifiers:
+// 26 abbreviations × 2 sides × 3 states × 2 terminal statuses.
+const identifierCount = 312
+
+// isValid reports whether all attributes of the Identifier are valid.
+// It is false for the zero value.
+func (id Identifier) isValid() bool {
+	return isValidAbbr(id.Abbr()) && isValidState(id.State())
+}
+
+// index returns the position of a valid Identifier in canonical order:
+// by side, then abbreviation, then state, then terminal status.
+func (id Identifier) index() int {
+	i := int(id.Side())*26 + int(id.abbr-'A')
+	i = i*3 + int(id.State())
+	i *= 2
+	if id.IsTerminal() {
+		i++
+	}
+	return i
 }
+
+// fromIndex returns the Identifier at position i in canonical order.
+// i must be in the range [0, identifierCount).
+func fromIndex(i int) Identifier {
+	terminal := i%2 == 1
+	i /= 2
+	state := State(i % 3)
+	i /= 3
+	abbr := rune('A' + i%26)
+	side := Side(i / 26)
+
+	return makeIdentifier(abbr, side, state, terminal)
+}
+
+// identifierStrings holds the PIN string of every valid identifier, in
+// canonical order. The strings share a single backing array.
+var identifierStrings = func() (table [identifierCount]string) {
+	var ends [identifierCount]int
+	buf := make([]byte, 0, identifierCount*MaxStringLength)
+	for i := range table {
+		buf = fromIndex(i).AppendTo(buf)
+		ends[i] = len(buf)
+	}
+
+	all := string(buf)
+	start := 0
+	for i, end := range ends {
+		table[i] = all[start:end]
+		start = end
+	}
+	return table
+}()
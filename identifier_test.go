@@ -1,6 +1,10 @@
 package pin
 
-import "testing"
+import (
+	"slices"
+	"testing"
+	"unsafe"
+)
 
 // ============================================================================
 // Constructor Tests
@@ -116,6 +120,26 @@ func TestIdentifierString(t *testing.T) {
 	}
 }
 
+func TestIdentifierStringMatchesAppendTo(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		if got, want := id.String(), string(id.AppendTo(nil)); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestIdentifierStringNoAllocs(t *testing.T) {
+	id := MustParse("+K^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = id.String()
+	})
+	if allocs != 0 {
+		t.Errorf("String allocates %v times, want 0", allocs)
+	}
+}
+
 func TestIdentifierLetter(t *testing.T) {
 	tests := []struct {
 		id   Identifier
@@ -172,6 +196,73 @@ func TestIdentifierSuffix(t *testing.T) {
 	}
 }
 
+func TestIdentifierByteAccessors(t *testing.T) {
+	// The bytes agree with the string accessors, 0 standing for ""
+	for _, id := range All() {
+		var prefix, suffix string
+		if b := id.PrefixByte(); b != 0 {
+			prefix = string(b)
+		}
+		if b := id.SuffixByte(); b != 0 {
+			suffix = string(b)
+		}
+
+		if got := string(id.LetterByte()); got != id.Letter() {
+			t.Errorf("%v.LetterByte() = %q, want %q", id, got, id.Letter())
+		}
+		if prefix != id.Prefix() {
+			t.Errorf("%v.PrefixByte() = %q, want %q", id, prefix, id.Prefix())
+		}
+		if suffix != id.Suffix() {
+			t.Errorf("%v.SuffixByte() = %q, want %q", id, suffix, id.Suffix())
+		}
+	}
+}
+
+func TestIdentifierAppendQuoted(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"K", `"K"`},
+		{"+r", `"+r"`},
+		{"-p^", `"-p^"`},
+	}
+
+	for _, tt := range tests {
+		got := string(MustParse(tt.input).AppendQuoted([]byte("x")))
+		if got != "x"+tt.want {
+			t.Errorf("AppendQuoted() for %q = %q, want %q", tt.input, got, "x"+tt.want)
+		}
+	}
+
+	for _, id := range All() {
+		if got := len(id.AppendQuoted(nil)); got > MaxQuotedLength {
+			t.Errorf("len(%v.AppendQuoted()) = %d, exceeds MaxQuotedLength", id, got)
+		}
+	}
+}
+
+func TestIdentifierEncodedLen(t *testing.T) {
+	for _, id := range All() {
+		if got, want := id.EncodedLen(), len(id.String()); got != want {
+			t.Errorf("%v.EncodedLen() = %d, want %d", id, got, want)
+		}
+	}
+}
+
+func TestIdentifierByteAccessorsNoAllocs(t *testing.T) {
+	id := MustParse("+k^")
+	var sink byte
+	allocs := testing.AllocsPerRun(100, func() {
+		sink = id.PrefixByte() + id.LetterByte() + id.SuffixByte()
+	})
+	if allocs != 0 {
+		t.Errorf("byte accessors allocated %v times, want 0", allocs)
+	}
+	_ = sink
+}
+
 // ============================================================================
 // AppendTo Tests
 // ============================================================================
@@ -187,6 +278,55 @@ func TestIdentifierAppendTo(t *testing.T) {
 	}
 }
 
+func TestAppendAllTo(t *testing.T) {
+	ids := []Identifier{MustParse("+K^"), MustParse("p"), MustParse("-r")}
+
+	tests := []struct {
+		dst  string
+		ids  []Identifier
+		sep  byte
+		want string
+	}{
+		{"", ids, ' ', "+K^ p -r"},
+		{"", ids, ',', "+K^,p,-r"},
+		{"", ids, 0, "+K^p-r"},
+		{"x=", ids, '/', "x=+K^/p/-r"},
+		{"x", nil, ' ', "x"},
+		{"", ids[:1], ' ', "+K^"},
+	}
+
+	for _, tt := range tests {
+		if got := string(AppendAllTo([]byte(tt.dst), tt.ids, tt.sep)); got != tt.want {
+			t.Errorf("AppendAllTo(%q, %v, %q) = %q, want %q", tt.dst, tt.ids, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestAppendAllToAllocatesOnce(t *testing.T) {
+	ids := All()
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = AppendAllTo(nil, ids, ' ')
+	})
+	if allocs != 1 {
+		t.Errorf("AppendAllTo allocates %v times, want 1", allocs)
+	}
+}
+
+func TestIdentifierAppendToNoAllocs(t *testing.T) {
+	buf := make([]byte, 0, MaxStringLength)
+
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		allocs := testing.AllocsPerRun(10, func() {
+			buf = id.AppendTo(buf[:0])
+		})
+		if allocs != 0 {
+			t.Fatalf("%v.AppendTo allocates %v times, want 0", id, allocs)
+		}
+	}
+}
+
 func TestIdentifierAppendToMultiple(t *testing.T) {
 	buf := make([]byte, 0, 32)
 
@@ -267,6 +407,52 @@ func TestIdentifierNormalize(t *testing.T) {
 	}
 }
 
+func TestIdentifierPromote(t *testing.T) {
+	tests := []struct {
+		pin     string
+		want    string
+		wantErr error
+	}{
+		{"-p", "p", nil},
+		{"K", "+K", nil},
+		{"-R^", "R^", nil},
+		{"+b", "+b", ErrAlreadyEnhanced},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.pin).Promote()
+		if err != tt.wantErr {
+			t.Errorf("%s.Promote() error = %v, want %v", tt.pin, err, tt.wantErr)
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s.Promote() = %s, want %s", tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifierDemote(t *testing.T) {
+	tests := []struct {
+		pin     string
+		want    string
+		wantErr error
+	}{
+		{"+p", "p", nil},
+		{"K", "-K", nil},
+		{"+R^", "R^", nil},
+		{"-b", "-b", ErrAlreadyDiminished},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.pin).Demote()
+		if err != tt.wantErr {
+			t.Errorf("%s.Demote() error = %v, want %v", tt.pin, err, tt.wantErr)
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s.Demote() = %s, want %s", tt.pin, got, tt.want)
+		}
+	}
+}
+
 // ============================================================================
 // Side Transformation Tests
 // ============================================================================
@@ -606,6 +792,189 @@ func TestIdentifierSameTerminal(t *testing.T) {
 	}
 }
 
+func TestIdentifierEquivalencePredicates(t *testing.T) {
+	tests := []struct {
+		a, b                               string
+		piece, ignoreState, ignoreTerminal bool
+	}{
+		{"P", "P", true, true, true},
+		{"P", "+P", true, true, false},
+		{"P", "P^", true, false, true},
+		{"-P", "+P^", true, false, false},
+		{"P", "p", false, false, false},
+		{"P", "Q", false, false, false},
+	}
+
+	for _, tt := range tests {
+		a, b := MustParse(tt.a), MustParse(tt.b)
+		for _, pair := range [][2]Identifier{{a, b}, {b, a}} {
+			x, y := pair[0], pair[1]
+			if got := x.SamePiece(y); got != tt.piece {
+				t.Errorf("%s.SamePiece(%s) = %v, want %v", x, y, got, tt.piece)
+			}
+			if got := x.EqualIgnoringState(y); got != tt.ignoreState {
+				t.Errorf("%s.EqualIgnoringState(%s) = %v, want %v", x, y, got, tt.ignoreState)
+			}
+			if got := x.EqualIgnoringTerminal(y); got != tt.ignoreTerminal {
+				t.Errorf("%s.EqualIgnoringTerminal(%s) = %v, want %v", x, y, got, tt.ignoreTerminal)
+			}
+		}
+	}
+}
+
+func TestIdentifierEquivalencePredicatesMatchDiff(t *testing.T) {
+	for _, a := range All() {
+		for _, b := range All() {
+			d := a.Diff(b)
+			if a.SamePiece(b) != (d&^(AttrState|AttrTerminal) == 0) ||
+				a.EqualIgnoringState(b) != (d&^AttrState == 0) ||
+				a.EqualIgnoringTerminal(b) != (d&^AttrTerminal == 0) {
+				t.Fatalf("predicates disagree with Diff for %s, %s", a, b)
+			}
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Each entry sorts strictly before the next
+	order := []string{"K", "K^", "+K", "-K", "-K^", "P", "a", "z", "-z^"}
+
+	for i, a := range order {
+		for j, b := range order {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := Compare(MustParse(a), MustParse(b)); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+			}
+			if got := MustParse(a).Less(MustParse(b)); got != (i < j) {
+				t.Errorf("%s.Less(%s) = %v, want %v", a, b, got, i < j)
+			}
+		}
+	}
+}
+
+func TestCompareMatchesCodes(t *testing.T) {
+	ids := All()
+	for i := 1; i < len(ids); i++ {
+		if Compare(ids[i-1], ids[i]) >= 0 {
+			t.Errorf("Compare(%s, %s) >= 0, want < 0", ids[i-1], ids[i])
+		}
+	}
+}
+
+func TestCompareInvalidSortsFirst(t *testing.T) {
+	var zero Identifier
+	if Compare(zero, MustParse("A")) != -1 || Compare(zero, zero) != 0 || !zero.Less(MustParse("A")) {
+		t.Error("zero Identifier does not sort first")
+	}
+}
+
+func TestCompareSortFunc(t *testing.T) {
+	ids := []Identifier{MustParse("p"), MustParse("+K"), MustParse("K"), MustParse("b^"), MustParse("B")}
+	slices.SortFunc(ids, Compare)
+
+	var got []string
+	for _, id := range ids {
+		got = append(got, id.String())
+	}
+	if want := []string{"B", "K", "+K", "b^", "p"}; !slices.Equal(got, want) {
+		t.Errorf("SortFunc(Compare) = %v, want %v", got, want)
+	}
+}
+
+func TestIdentifierEqualString(t *testing.T) {
+	tests := []struct {
+		pin   string
+		token string
+		want  bool
+	}{
+		{"K", "K", true},
+		{"K", "k", false},
+		{"K", "K^", false},
+		{"K", "", false},
+		{"+r^", "+r^", true},
+		{"+r^", "-r^", false},
+		{"+r^", "+r", false},
+		{"+r^", "+R^", false},
+		{"+r^", "+r+", false},
+		{"-p", "-p", true},
+		{"-p", "p-", false},
+		{"k^", "k^^", false},
+	}
+
+	for _, tt := range tests {
+		id := MustParse(tt.pin)
+		if got := id.EqualString(tt.token); got != tt.want {
+			t.Errorf("%s.EqualString(%q) = %v, want %v", tt.pin, tt.token, got, tt.want)
+		}
+		if got := id.EqualBytes([]byte(tt.token)); got != tt.want {
+			t.Errorf("%s.EqualBytes(%q) = %v, want %v", tt.pin, tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifierEqualStringAll(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		for j := 0; j < identifierCount; j++ {
+			other := fromIndex(j).String()
+			if got := id.EqualString(other); got != (i == j) {
+				t.Errorf("%s.EqualString(%q) = %v, want %v", id, other, got, i == j)
+			}
+		}
+	}
+}
+
+func TestIdentifierEqualStringNoAllocs(t *testing.T) {
+	id := MustParse("+K^")
+	buf := []byte("+K^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = id.EqualString("+K^")
+		_ = id.EqualBytes(buf)
+	})
+	if allocs != 0 {
+		t.Errorf("EqualString/EqualBytes allocate %v times, want 0", allocs)
+	}
+}
+
+func TestIdentifierSize(t *testing.T) {
+	if size := unsafe.Sizeof(Identifier{}); size != 2 {
+		t.Errorf("unsafe.Sizeof(Identifier{}) = %d, want 2", size)
+	}
+}
+
+func TestIdentifierAttributesAllIdentifiers(t *testing.T) {
+	for _, abbr := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+		for _, side := range []Side{First, Second} {
+			for _, state := range []State{Normal, Enhanced, Diminished} {
+				for _, terminal := range []bool{false, true} {
+					id := NewIdentifierWithOptions(abbr, side, state, terminal)
+					if id.Abbr() != abbr || id.Side() != side || id.State() != state || id.IsTerminal() != terminal {
+						t.Errorf("NewIdentifierWithOptions(%q, %v, %v, %v) = %+v", abbr, side, state, terminal, id)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestIdentifierIsZero(t *testing.T) {
+	if !(Identifier{}).IsZero() {
+		t.Error("Identifier{}.IsZero() = false, want true")
+	}
+	for i := 0; i < identifierCount; i++ {
+		if fromIndex(i).IsZero() {
+			t.Errorf("%s.IsZero() = true, want false", fromIndex(i))
+		}
+	}
+}
+
 // ============================================================================
 // Value Semantics Tests
 // ============================================================================
@@ -655,3 +1024,49 @@ func TestIdentifierChaining(t *testing.T) {
 		t.Errorf("chained result = %q, want %q", id.String(), want)
 	}
 }
+
+// ============================================================================
+// Canonical Ordering Tests
+// ============================================================================
+
+func TestIdentifierIndexRoundTrip(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+		if !id.isValid() {
+			t.Fatalf("fromIndex(%d) = %+v is not valid", i, id)
+		}
+		if got := id.index(); got != i {
+			t.Errorf("fromIndex(%d).index() = %d", i, got)
+		}
+	}
+}
+
+func TestIdentifierIndexOrder(t *testing.T) {
+	tests := []struct {
+		pin  string
+		want int
+	}{
+		{"A", 0},
+		{"A^", 1},
+		{"+A", 2},
+		{"-A^", 5},
+		{"B", 6},
+		{"Z", 150},
+		{"a", 156},
+		{"-z^", 311},
+	}
+
+	for _, tt := range tests {
+		got := MustParse(tt.pin).index()
+		if got != tt.want {
+			t.Errorf("MustParse(%q).index() = %d, want %d", tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifierZeroValueIsNotValid(t *testing.T) {
+	var id Identifier
+	if id.isValid() {
+		t.Error("zero Identifier isValid() = true, want false")
+	}
+}
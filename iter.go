@@ -0,0 +1,111 @@
+//go:build go1.23
+
+package pin
+
+// Range-over-func iterators are available from Go 1.23. Earlier toolchains
+// build without them.
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// ParseSeq returns an iterator over the identifiers read from r, separated
+// by whitespace or commas as for a Decoder:
+//
+//	for id, err := range pin.ParseSeq(r) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+//
+// Invalid tokens are yielded as *DecodeError and iteration continues with
+// the next token. Any other read error is yielded once and ends the
+// sequence, which ends silently at io.EOF.
+//
+// Each range takes a Decoder from the pool and releases it when the
+// sequence ends or the loop stops early.
+func ParseSeq(r io.Reader) iter.Seq2[Identifier, error] {
+	return func(yield func(Identifier, error) bool) {
+		d := NewDecoder(r)
+		defer d.Release()
+
+		for id, err := range d.All() {
+			if !yield(id, err) {
+				return
+			}
+		}
+	}
+}
+
+// ParseStrings returns an iterator parsing each string of seq, yielding the
+// identifier or the parsing error of each, in order.
+func ParseStrings(seq iter.Seq[string]) iter.Seq2[Identifier, error] {
+	return func(yield func(Identifier, error) bool) {
+		for s := range seq {
+			if !yield(Parse(s)) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the remaining identifiers of the Decoder,
+// with the error semantics of ParseSeq.
+func (d *Decoder) All() iter.Seq2[Identifier, error] {
+	return func(yield func(Identifier, error) bool) {
+		for {
+			id, err := d.Decode()
+			if err == io.EOF {
+				return
+			}
+			if !yield(id, err) {
+				return
+			}
+
+			var de *DecodeError
+			if err != nil && !errors.As(err, &de) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the remaining tokens of the Tokenizer.
+// Bytes rejected by the skip policy are yielded as *DecodeError and
+// iteration continues.
+func (t *Tokenizer) All() iter.Seq2[Token, error] {
+	return func(yield func(Token, error) bool) {
+		for {
+			tok, err := t.Next()
+			if err == io.EOF || !yield(tok, err) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the elements of the set, in canonical order.
+func (s Set) All() iter.Seq[Identifier] {
+	return s.Range
+}
+
+// All returns an iterator over the identifiers and values of the map, in
+// canonical order.
+func (m *Map[V]) All() iter.Seq2[Identifier, V] {
+	return m.Range
+}
+
+// All returns an iterator over the distinct identifiers of the bag and
+// their counts, in canonical order.
+func (b *Bag) All() iter.Seq2[Identifier, int] {
+	return b.Range
+}
+
+// All returns an iterator over the distinct identifiers of the pool and
+// their counts, in HAND order.
+func (p *Pool) All() iter.Seq2[Identifier, int] {
+	return p.Range
+}
@@ -0,0 +1,147 @@
+//go:build go1.23
+
+package pin
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestParseSeq(t *testing.T) {
+	var got []string
+	var errs []error
+	for id, err := range ParseSeq(strings.NewReader("K, +p^ X+ -r")) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, id.String())
+	}
+
+	if strings.Join(got, " ") != "K +p^ -r" {
+		t.Errorf("identifiers = %v, want [K +p^ -r]", got)
+	}
+	var de *DecodeError
+	if len(errs) != 1 || !errors.As(errs[0], &de) || de.Token != "X+" {
+		t.Errorf("errors = %v, want one *DecodeError for \"X+\"", errs)
+	}
+}
+
+func TestParseSeqStopsOnReadError(t *testing.T) {
+	readErr := errors.New("disk on fire")
+	r := io.MultiReader(strings.NewReader("K q "), iotest.ErrReader(readErr))
+
+	n := 0
+	var last error
+	for _, err := range ParseSeq(r) {
+		n++
+		last = err
+	}
+
+	if n != 3 || !errors.Is(last, readErr) {
+		t.Errorf("yielded %d values ending with %v, want 3 ending with the read error", n, last)
+	}
+}
+
+func TestParseSeqBreak(t *testing.T) {
+	n := 0
+	for range ParseSeq(strings.NewReader("K Q R B N P")) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("iterations = %d, want 2", n)
+	}
+}
+
+func TestParseStrings(t *testing.T) {
+	var got []string
+	for id, err := range ParseStrings(slices.Values([]string{"K", "K+", "-p"})) {
+		if err != nil {
+			got = append(got, ErrorCode(err))
+			continue
+		}
+		got = append(got, id.String())
+	}
+
+	if want := "K invalid_terminal_marker -p"; strings.Join(got, " ") != want {
+		t.Errorf("ParseStrings() = %v, want %s", got, want)
+	}
+}
+
+func TestTokenizerAll(t *testing.T) {
+	tz := NewTokenizer("+r1k*")
+	tz.SetSkip(SkipOnly("1"))
+
+	var got []string
+	for tok, err := range tz.All() {
+		if errors.Is(err, ErrUnexpectedByte) {
+			got = append(got, "!")
+			continue
+		}
+		got = append(got, tok.ID.String())
+	}
+
+	if want := "+r k !"; strings.Join(got, " ") != want {
+		t.Errorf("Tokenizer.All() = %v, want %s", got, want)
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	s := NewSet(MustParse("p"), MustParse("K"), MustParse("+K"))
+
+	got := slices.Collect(s.All())
+	if !slices.Equal(got, s.SortedSlice()) {
+		t.Errorf("Set.All() = %v, want %v", got, s.SortedSlice())
+	}
+}
+
+func TestMapAll(t *testing.T) {
+	var m Map[int]
+	m.Set(MustParse("p"), 1)
+	m.Set(MustParse("K"), 2)
+
+	var got []string
+	for id, v := range m.All() {
+		got = append(got, id.String()+"="+strconv.Itoa(v))
+	}
+	if want := []string{"K=2", "p=1"}; !slices.Equal(got, want) {
+		t.Errorf("Map.All() = %v, want %v", got, want)
+	}
+}
+
+func TestBagAll(t *testing.T) {
+	var b Bag
+	b.Add(MustParse("p"))
+	b.Add(MustParse("P"))
+	b.Add(MustParse("p"))
+
+	var got []string
+	for id, count := range b.All() {
+		got = append(got, id.String()+strconv.Itoa(count))
+	}
+
+	if want := []string{"P1", "p2"}; !slices.Equal(got, want) {
+		t.Errorf("Bag.All() = %v, want %v", got, want)
+	}
+}
+
+func TestPoolAll(t *testing.T) {
+	p := NewPool(MustParse("p"), MustParse("P"), MustParse("p"))
+
+	var got []string
+	for id, count := range p.All() {
+		got = append(got, id.String()+strconv.Itoa(count))
+	}
+
+	if want := []string{"p2", "P1"}; !slices.Equal(got, want) {
+		t.Errorf("Pool.All() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,218 @@
+package pin
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// Marshaling
+// ============================================================================
+
+// MarshalJSON encodes the Identifier as a JSON string, implementing
+// json.Marshaler.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value); use the "omitzero" option to skip unset fields.
+func (id Identifier) MarshalJSON() ([]byte, error) {
+	if !id.isValid() {
+		return nil, ErrInvalidIdentifier
+	}
+
+	return id.AppendQuoted(make([]byte, 0, MaxQuotedLength)), nil
+}
+
+// UnmarshalJSON decodes a JSON string into the Identifier, implementing
+// json.Unmarshaler. The object form written by IdentifierObject is accepted
+// as well.
+//
+// A JSON null leaves the Identifier unchanged. Any other value returns
+// ErrJSONNotString; invalid strings return a *SyntaxError
+// wrapping the parsing sentinels.
+func (id *Identifier) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		return id.unmarshalJSONObject(data)
+	}
+	if len(data) == 0 || data[0] != '"' {
+		return ErrJSONNotString
+	}
+
+	// Fast path: a closed string without escape sequences. Anything else,
+	// including unterminated strings, is left to encoding/json to decode or
+	// reject.
+	closed := len(data) >= 2 && data[len(data)-1] == '"'
+	raw := data[1:]
+	if closed {
+		raw = data[1 : len(data)-1]
+	}
+	if !closed || bytes.IndexByte(raw, '\\') >= 0 {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		raw = []byte(s)
+	}
+
+	parsed, err := ParseBytes(raw)
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// ============================================================================
+// Object Form
+// ============================================================================
+
+// IdentifierObject is an Identifier that marshals to a JSON object with one
+// field per attribute, for consumers that query the fields individually:
+//
+//	{"abbr":"K","side":"first","state":"enhanced","terminal":true}
+//
+// Opt in by declaring fields as IdentifierObject, converting to and from
+// Identifier as needed:
+//
+//	type Event struct {
+//		Piece pin.IdentifierObject `json:"piece"`
+//	}
+//
+//	e := Event{Piece: pin.IdentifierObject(id)}
+//
+// Both IdentifierObject and Identifier unmarshal either form.
+type IdentifierObject Identifier
+
+// jsonObject is the object form of an Identifier.
+type jsonObject struct {
+	Abbr     string `json:"abbr"`
+	Side     string `json:"side"`
+	State    string `json:"state"`
+	Terminal bool   `json:"terminal"`
+}
+
+// MarshalJSON encodes the Identifier as a JSON object, implementing
+// json.Marshaler. Side and state are lowercase keywords, as in Describe.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value).
+func (o IdentifierObject) MarshalJSON() ([]byte, error) {
+	id := Identifier(o)
+	if !id.isValid() {
+		return nil, ErrInvalidIdentifier
+	}
+
+	b := make([]byte, 0, len(`{"abbr":"K","side":"second","state":"diminished","terminal":false}`))
+	b = append(b, `{"abbr":"`...)
+	b = append(b, byte(id.Abbr()))
+	b = append(b, `","side":"`...)
+	b = append(b, strings.ToLower(id.Side().String())...)
+	b = append(b, `","state":"`...)
+	b = append(b, strings.ToLower(id.State().String())...)
+	b = append(b, `","terminal":`...)
+	b = strconv.AppendBool(b, id.IsTerminal())
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON decodes either a JSON string or a JSON object into the
+// Identifier, implementing json.Unmarshaler.
+func (o *IdentifierObject) UnmarshalJSON(data []byte) error {
+	return (*Identifier)(o).UnmarshalJSON(data)
+}
+
+// unmarshalJSONObject decodes the object form into the Identifier.
+//
+// "abbr" (an uppercase letter) and "side" are required; "state" defaults to
+// "normal" and "terminal" to false. Unknown fields are ignored.
+func (id *Identifier) unmarshalJSONObject(data []byte) error {
+	var obj jsonObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	if len(obj.Abbr) != 1 || !isValidAbbr(rune(obj.Abbr[0])) {
+		return ErrInvalidAbbr
+	}
+
+	var (
+		side  Side
+		state State
+	)
+	switch obj.Side {
+	case "first":
+		side = First
+	case "second":
+		side = Second
+	default:
+		return ErrInvalidSide
+	}
+
+	switch obj.State {
+	case "normal", "":
+		state = Normal
+	case "enhanced":
+		state = Enhanced
+	case "diminished":
+		state = Diminished
+	default:
+		return ErrInvalidState
+	}
+
+	*id = makeIdentifier(rune(obj.Abbr[0]), side, state, obj.Terminal)
+	return nil
+}
+
+// ============================================================================
+// Streaming Arrays
+// ============================================================================
+
+// DecodeJSONArray reads a JSON array of PIN strings from dec and calls fn for
+// each parsed Identifier, in order, without materializing the array.
+//
+// The decoder must be positioned at the start of the array; on success it is
+// left just after the closing bracket, so the surrounding document can be
+// decoded further.
+//
+// Invalid elements are reported as *IndexError wrapping the parsing sentinel,
+// or ErrJSONNotString for non-string elements. An error returned by fn stops
+// the decoding and is returned unchanged.
+func DecodeJSONArray(dec *json.Decoder, fn func(Identifier) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return ErrJSONNotArray
+	}
+
+	for i := 0; dec.More(); i++ {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		s, ok := tok.(string)
+		if !ok {
+			return &IndexError{Index: i, Err: ErrJSONNotString}
+		}
+
+		id, err := Parse(s)
+		if err != nil {
+			return &IndexError{Index: i, Err: err}
+		}
+
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+
+	// Consume the closing bracket
+	_, err = dec.Token()
+	return err
+}
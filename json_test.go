@@ -0,0 +1,364 @@
+package pin
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// DecodeJSONArray Tests
+// ============================================================================
+
+func TestDecodeJSONArray(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`["K", "+r", "-p^"]`))
+
+	var got []string
+	err := DecodeJSONArray(dec, func(id Identifier) error {
+		got = append(got, id.String())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DecodeJSONArray() error = %v", err)
+	}
+
+	want := []string{"K", "+r", "-p^"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("DecodeJSONArray() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeJSONArrayEmpty(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`[]`))
+
+	calls := 0
+	err := DecodeJSONArray(dec, func(Identifier) error {
+		calls++
+		return nil
+	})
+	if err != nil || calls != 0 {
+		t.Errorf("DecodeJSONArray([]) = %v with %d calls, want nil with 0 calls", err, calls)
+	}
+}
+
+func TestDecodeJSONArrayLeavesDecoderUsable(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"pieces": ["K", "k"], "turn": "first"}`))
+
+	// Walk to the "pieces" value
+	for _, want := range []any{json.Delim('{'), "pieces"} {
+		tok, err := dec.Token()
+		if err != nil || tok != want {
+			t.Fatalf("Token() = %v, %v, want %v", tok, err, want)
+		}
+	}
+
+	n := 0
+	if err := DecodeJSONArray(dec, func(Identifier) error { n++; return nil }); err != nil {
+		t.Fatalf("DecodeJSONArray() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("DecodeJSONArray() decoded %d identifiers, want 2", n)
+	}
+
+	tok, err := dec.Token()
+	if err != nil || tok != "turn" {
+		t.Errorf("Token() after array = %v, %v, want \"turn\"", tok, err)
+	}
+}
+
+func TestDecodeJSONArrayInvalidElement(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`["K", "Q", "*R", "B"]`))
+
+	n := 0
+	err := DecodeJSONArray(dec, func(Identifier) error { n++; return nil })
+
+	var ie *IndexError
+	if !errors.As(err, &ie) || ie.Index != 2 {
+		t.Fatalf("DecodeJSONArray() error = %v, want *IndexError at index 2", err)
+	}
+	if !errors.Is(err, ErrInvalidStateModifier) {
+		t.Errorf("DecodeJSONArray() error = %v, want ErrInvalidStateModifier", err)
+	}
+	if n != 2 {
+		t.Errorf("fn called %d times before error, want 2", n)
+	}
+}
+
+func TestDecodeJSONArrayNonStringElement(t *testing.T) {
+	inputs := []string{`["K", 1]`, `["K", null]`, `["K", ["k"]]`, `["K", {}]`}
+
+	for _, input := range inputs {
+		dec := json.NewDecoder(strings.NewReader(input))
+		err := DecodeJSONArray(dec, func(Identifier) error { return nil })
+		if !errors.Is(err, ErrJSONNotString) {
+			t.Errorf("DecodeJSONArray(%s) error = %v, want ErrJSONNotString", input, err)
+		}
+	}
+}
+
+func TestDecodeJSONArrayNotArray(t *testing.T) {
+	inputs := []string{`"K"`, `{"K": 1}`, `null`}
+
+	for _, input := range inputs {
+		dec := json.NewDecoder(strings.NewReader(input))
+		err := DecodeJSONArray(dec, func(Identifier) error { return nil })
+		if !errors.Is(err, ErrJSONNotArray) {
+			t.Errorf("DecodeJSONArray(%s) error = %v, want ErrJSONNotArray", input, err)
+		}
+	}
+}
+
+func TestDecodeJSONArrayCallbackError(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`["K", "Q", "R"]`))
+	stop := errors.New("stop")
+
+	err := DecodeJSONArray(dec, func(id Identifier) error {
+		if id.Abbr() == 'Q' {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("DecodeJSONArray() error = %v, want callback error", err)
+	}
+}
+
+func TestDecodeJSONArrayTruncated(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`["K", "Q"`))
+
+	if err := DecodeJSONArray(dec, func(Identifier) error { return nil }); err == nil {
+		t.Error("DecodeJSONArray() on truncated input error = nil, want error")
+	}
+}
+
+// ============================================================================
+// Marshaling Tests
+// ============================================================================
+
+func TestMarshalJSON(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+
+		got, err := json.Marshal(id)
+		if err != nil {
+			t.Errorf("Marshal(%s) error = %v", id, err)
+			continue
+		}
+		if want := `"` + id.String() + `"`; string(got) != want {
+			t.Errorf("Marshal(%s) = %s, want %s", id, got, want)
+		}
+	}
+}
+
+func TestMarshalJSONInvalid(t *testing.T) {
+	_, err := json.Marshal(Identifier{})
+	if !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Marshal(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestMarshalJSONInStruct(t *testing.T) {
+	type piece struct {
+		ID     Identifier   `json:"id"`
+		Hand   []Identifier `json:"hand"`
+		Origin *Identifier  `json:"origin,omitempty"`
+	}
+
+	in := piece{ID: MustParse("+k^"), Hand: []Identifier{MustParse("P"), MustParse("p")}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"id":"+k^","hand":["P","p"]}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var out piece
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if out.ID != in.ID || len(out.Hand) != 2 || out.Hand[1] != in.Hand[1] {
+		t.Errorf("Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`"K"`, "K"},
+		{`"+k^"`, "+k^"},
+		{`"-p"`, "-p"},
+		{`"+k^"`, "+k^"},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		if err := json.Unmarshal([]byte(tt.input), &id); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", tt.input, err)
+			continue
+		}
+		if id.String() != tt.want {
+			t.Errorf("Unmarshal(%s) = %s, want %s", tt.input, id, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalJSONNull(t *testing.T) {
+	id := MustParse("K")
+	if err := json.Unmarshal([]byte(`null`), &id); err != nil {
+		t.Fatalf("Unmarshal(null) error = %v", err)
+	}
+	if id.String() != "K" {
+		t.Errorf("Unmarshal(null) = %s, want K unchanged", id)
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{`""`, ErrEmptyInput},
+		{`"K+"`, ErrInvalidTerminalMarker},
+		{`"KKKK"`, ErrInputTooLong},
+		{`"1"`, ErrMustContainOneLetter},
+		{`"KKKK"`, ErrInputTooLong},
+		{`3`, ErrJSONNotString},
+		{`["K"]`, ErrJSONNotString},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		err := json.Unmarshal([]byte(tt.input), &id)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Unmarshal(%s) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalJSONDirectMalformed(t *testing.T) {
+	// encoding/json validates documents before calling UnmarshalJSON, but
+	// direct callers may pass anything
+	for _, input := range []string{`"Kx`, `"K`, `"`, `"K"x`} {
+		id := MustParse("q")
+		if err := id.UnmarshalJSON([]byte(input)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %v, want error", input, id)
+		}
+		if id != MustParse("q") {
+			t.Errorf("UnmarshalJSON(%s) changed the Identifier to %v", input, id)
+		}
+	}
+
+	var id Identifier
+	if err := id.UnmarshalJSON([]byte(`"+K^"`)); err != nil || id != MustParse("+K^") {
+		t.Errorf("UnmarshalJSON(\"+K^\") = %v, %v", id, err)
+	}
+}
+
+// ============================================================================
+// Object Form Tests
+// ============================================================================
+
+func TestIdentifierObjectMarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"K", `{"abbr":"K","side":"first","state":"normal","terminal":false}`},
+		{"+r^", `{"abbr":"R","side":"second","state":"enhanced","terminal":true}`},
+		{"-p", `{"abbr":"P","side":"second","state":"diminished","terminal":false}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(IdentifierObject(MustParse(tt.input)))
+		if err != nil {
+			t.Errorf("Marshal(%s) error = %v", tt.input, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal(%s) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifierObjectMarshalJSONInvalid(t *testing.T) {
+	_, err := json.Marshal(IdentifierObject{})
+	if !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Marshal(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestIdentifierObjectRoundTrip(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		want := fromIndex(i)
+
+		data, err := json.Marshal(IdentifierObject(want))
+		if err != nil {
+			t.Fatalf("Marshal(%s) error = %v", want, err)
+		}
+
+		var obj IdentifierObject
+		if err := json.Unmarshal(data, &obj); err != nil {
+			t.Fatalf("Unmarshal(%s) into IdentifierObject error = %v", data, err)
+		}
+		var id Identifier
+		if err := json.Unmarshal(data, &id); err != nil {
+			t.Fatalf("Unmarshal(%s) into Identifier error = %v", data, err)
+		}
+
+		if Identifier(obj) != want || id != want {
+			t.Errorf("round trip of %s = %s, %s", want, Identifier(obj), id)
+		}
+	}
+}
+
+func TestIdentifierObjectUnmarshalString(t *testing.T) {
+	var obj IdentifierObject
+	if err := json.Unmarshal([]byte(`"-p^"`), &obj); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := Identifier(obj).String(); got != "-p^" {
+		t.Errorf("Unmarshal() = %s, want -p^", got)
+	}
+}
+
+func TestUnmarshalJSONObjectDefaults(t *testing.T) {
+	var id Identifier
+	if err := json.Unmarshal([]byte(`{"side":"second","abbr":"K","extra":1}`), &id); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if id.String() != "k" {
+		t.Errorf("Unmarshal() = %s, want k", id)
+	}
+}
+
+func TestUnmarshalJSONObjectErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{`{}`, ErrInvalidAbbr},
+		{`{"abbr":"k","side":"first"}`, ErrInvalidAbbr},
+		{`{"abbr":"KQ","side":"first"}`, ErrInvalidAbbr},
+		{`{"abbr":"K"}`, ErrInvalidSide},
+		{`{"abbr":"K","side":"First"}`, ErrInvalidSide},
+		{`{"abbr":"K","side":"first","state":"promoted"}`, ErrInvalidState},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		err := json.Unmarshal([]byte(tt.input), &id)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Unmarshal(%s) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+
+	var id Identifier
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`{"abbr":"K","side":"first","terminal":"yes"}`), &id); !errors.As(err, &typeErr) {
+		t.Errorf("Unmarshal(terminal string) error = %v, want *json.UnmarshalTypeError", err)
+	}
+}
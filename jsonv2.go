@@ -0,0 +1,59 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package pin
+
+// The encoding/json/v2 interfaces are implemented from Go 1.27, where the
+// package API is final. Earlier toolchains build without them.
+
+import "encoding/json/jsontext"
+
+// MarshalJSONTo writes the Identifier as a JSON string, implementing the
+// encoding/json/v2 MarshalerTo interface without intermediate allocations.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value); use the "omitzero" option to skip unset fields.
+func (id Identifier) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !id.isValid() {
+		return ErrInvalidIdentifier
+	}
+
+	var buf [MaxQuotedLength]byte
+	return enc.WriteValue(id.AppendQuoted(buf[:0]))
+}
+
+// UnmarshalJSONFrom reads a JSON string from dec and parses it, implementing
+// the encoding/json/v2 UnmarshalerFrom interface. The object form written by
+// IdentifierObject is accepted as well.
+//
+// A JSON null leaves the Identifier unchanged. Any other value returns
+// ErrJSONNotString.
+func (id *Identifier) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	v, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case 'n':
+		return nil
+	case '{':
+		return id.unmarshalJSONObject(v)
+	case '"':
+	default:
+		return ErrJSONNotString
+	}
+
+	var buf [MaxStringLength]byte
+	s, err := jsontext.AppendUnquote(buf[:0], v)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := ParseBytes(s)
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
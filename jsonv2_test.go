@@ -0,0 +1,121 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package pin
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"testing"
+)
+
+func TestMarshalJSONTo(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+
+		got, err := json.Marshal(id)
+		if err != nil {
+			t.Errorf("Marshal(%s) error = %v", id, err)
+			continue
+		}
+		if want := `"` + id.String() + `"`; string(got) != want {
+			t.Errorf("Marshal(%s) = %s, want %s", id, got, want)
+		}
+	}
+}
+
+func TestMarshalJSONToInvalid(t *testing.T) {
+	_, err := json.Marshal(Identifier{})
+	if !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Marshal(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestMarshalJSONToOmitZero(t *testing.T) {
+	type move struct {
+		Piece    Identifier `json:"piece"`
+		Captured Identifier `json:"captured,omitzero"`
+	}
+
+	got, err := json.Marshal(move{Piece: MustParse("+P")})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"piece":"+P"}`; string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestUnmarshalJSONFrom(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`"K"`, "K"},
+		{`"+r^"`, "+r^"},
+		{`"+p"`, "+p"},
+		{`{"abbr":"R","side":"second","state":"enhanced","terminal":true}`, "+r^"},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		if err := json.Unmarshal([]byte(tt.input), &id); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", tt.input, err)
+			continue
+		}
+		if id.String() != tt.want {
+			t.Errorf("Unmarshal(%s) = %s, want %s", tt.input, id, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalJSONFromNull(t *testing.T) {
+	id := MustParse("K")
+	if err := json.Unmarshal([]byte(`null`), &id); err != nil {
+		t.Fatalf("Unmarshal(null) error = %v", err)
+	}
+	if id.String() != "K" {
+		t.Errorf("Unmarshal(null) = %s, want K unchanged", id)
+	}
+}
+
+func TestUnmarshalJSONFromErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{`""`, ErrEmptyInput},
+		{`"K+"`, ErrInvalidTerminalMarker},
+		{`"KKKK"`, ErrInputTooLong},
+		{`3`, ErrJSONNotString},
+		{`["K"]`, ErrJSONNotString},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		err := json.Unmarshal([]byte(tt.input), &id)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Unmarshal(%s) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestMarshalJSONToNoAllocs(t *testing.T) {
+	var enc jsontext.Encoder
+	id := MustParse("+K^")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		enc.Reset(discard{})
+		if err := id.MarshalJSONTo(&enc); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("MarshalJSONTo() allocates %v times, want 0", allocs)
+	}
+}
+
+// discard is an io.Writer that drops its input.
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
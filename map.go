@@ -0,0 +1,60 @@
+package pin
+
+// Map is a map from Identifiers to values of type V.
+//
+// Map is backed by a fixed array with one slot per valid identifier, indexed
+// by the compact code, so lookups and updates never hash nor allocate.
+// Iteration follows the canonical order, like Set.
+//
+// The zero value is an empty map ready to use. A Map holds 312 values of
+// type V, so pass it by pointer when V is large.
+type Map[V any] struct {
+	values [identifierCount]V
+	keys   Set
+}
+
+// Get returns the value stored for id, and whether one is stored.
+func (m *Map[V]) Get(id Identifier) (V, bool) {
+	if !m.keys.Contains(id) {
+		var zero V
+		return zero, false
+	}
+	return m.values[id.index()], true
+}
+
+// Set stores v for id, replacing any previous value.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (m *Map[V]) Set(id Identifier, v V) {
+	m.keys.Add(id)
+	m.values[id.index()] = v
+}
+
+// Delete removes the value stored for id, if any.
+func (m *Map[V]) Delete(id Identifier) {
+	if !m.keys.Contains(id) {
+		return
+	}
+
+	m.keys.Remove(id)
+	var zero V
+	m.values[id.index()] = zero
+}
+
+// Len returns the number of identifiers with a stored value.
+func (m *Map[V]) Len() int {
+	return m.keys.Len()
+}
+
+// Keys returns the set of identifiers with a stored value.
+func (m *Map[V]) Keys() Set {
+	return m.keys
+}
+
+// Range calls fn for each identifier and its value, in canonical order.
+// If fn returns false, Range stops the iteration.
+func (m *Map[V]) Range(fn func(id Identifier, v V) bool) {
+	m.keys.Range(func(id Identifier) bool {
+		return fn(id, m.values[id.index()])
+	})
+}
@@ -0,0 +1,135 @@
+package pin
+
+import "testing"
+
+func TestMapZeroValueIsEmpty(t *testing.T) {
+	var m Map[int]
+
+	if v, ok := m.Get(MustParse("K")); ok || v != 0 {
+		t.Errorf("zero Map Get(K) = %v, %v, want 0, false", v, ok)
+	}
+	if m.Len() != 0 {
+		t.Errorf("zero Map Len() = %d, want 0", m.Len())
+	}
+}
+
+func TestMapSetGet(t *testing.T) {
+	var m Map[string]
+	m.Set(MustParse("K"), "king")
+	m.Set(MustParse("+K^"), "enhanced king")
+	m.Set(MustParse("K"), "King")
+
+	tests := []struct {
+		pin    string
+		want   string
+		wantOK bool
+	}{
+		{"K", "King", true},
+		{"+K^", "enhanced king", true},
+		{"k", "", false},
+		{"K^", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := m.Get(MustParse(tt.pin))
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Get(%q) = %q, %v, want %q, %v", tt.pin, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+}
+
+func TestMapStoresZeroValues(t *testing.T) {
+	var m Map[int]
+	m.Set(MustParse("p"), 0)
+
+	if _, ok := m.Get(MustParse("p")); !ok {
+		t.Error("Get(p) ok = false after Set(p, 0)")
+	}
+}
+
+func TestMapDelete(t *testing.T) {
+	var m Map[int]
+	m.Set(MustParse("K"), 1)
+	m.Set(MustParse("Q"), 9)
+
+	m.Delete(MustParse("K"))
+	m.Delete(MustParse("K"))
+	m.Delete(MustParse("R"))
+	m.Delete(Identifier{})
+
+	if _, ok := m.Get(MustParse("K")); ok {
+		t.Error("Get(K) ok = true after Delete(K)")
+	}
+	if v, ok := m.Get(MustParse("Q")); !ok || v != 9 {
+		t.Errorf("Get(Q) = %v, %v, want 9, true", v, ok)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", m.Len())
+	}
+}
+
+func TestMapGetZeroIdentifier(t *testing.T) {
+	var m Map[int]
+	m.Set(MustParse("A"), 1)
+
+	if _, ok := m.Get(Identifier{}); ok {
+		t.Error("Get(Identifier{}) ok = true, want false")
+	}
+}
+
+func TestMapSetPanicsOnZeroIdentifier(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+
+	var m Map[int]
+	m.Set(Identifier{}, 1)
+}
+
+func TestMapRangeCanonicalOrder(t *testing.T) {
+	var m Map[int]
+	for i, s := range []string{"p", "K", "+K", "a"} {
+		m.Set(MustParse(s), i)
+	}
+
+	var got []string
+	m.Range(func(id Identifier, v int) bool {
+		got = append(got, id.String())
+		return true
+	})
+
+	want := []string{"K", "+K", "a", "p"}
+	if len(got) != len(want) {
+		t.Fatalf("Range visited %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Range visited %v, want %v", got, want)
+			break
+		}
+	}
+	if keys := m.Keys().SortedSlice(); len(keys) != len(want) {
+		t.Errorf("Keys() = %v, want %v", keys, want)
+	}
+}
+
+func TestMapRangeStopsEarly(t *testing.T) {
+	var m Map[bool]
+	for _, id := range All() {
+		m.Set(id, true)
+	}
+
+	n := 0
+	m.Range(func(Identifier, bool) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Errorf("Range visited %d entries, want 3", n)
+	}
+}
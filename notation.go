@@ -0,0 +1,67 @@
+package pin
+
+// Notation is the interface shared by the values of Sashité notations
+// (PIN, CELL, HAND, FEEN, ...), so that generic tooling such as validators,
+// encoders, and command-line tools can handle them uniformly.
+//
+// String returns the canonical string form of the value, and AppendTo
+// appends that same form to dst and returns the result, growing dst only
+// as needed. Neither method modifies the value. Implementations may be
+// immutable values, such as Identifier and Cell, or pointers to mutable
+// values, such as *GameState and *Pool, whose form reflects their current
+// content; AppendTo may allocate beyond growing dst, as Pool.AppendTo does
+// to order its pieces.
+//
+// Identifier, QualifiedIdentifier, Style, *GameState, *Pool, Cell, and
+// Transition implement Notation.
+type Notation interface {
+	String() string
+	AppendTo(dst []byte) []byte
+}
+
+// Parser is the constructor convention shared by Sashité notations: a
+// function converting a string to a value, such as Parse.
+//
+// A conforming Parser returns an error wrapping the package's sentinels for
+// invalid input, and round-trips canonical input: for every canonical
+// string s, parsing s succeeds and the result's String method returns s.
+type Parser[T Notation] func(s string) (T, error)
+
+// Validate returns nil if s is valid under the Parser, or the parsing error.
+func (p Parser[T]) Validate(s string) error {
+	_, err := p(s)
+	return err
+}
+
+// Must is like calling the Parser but panics on error.
+func (p Parser[T]) Must(s string) T {
+	v, err := p(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Compile-time checks that this package follows the convention.
+var (
+	_ Notation           = Identifier{}
+	_ Parser[Identifier] = Parse
+
+	_ Notation                    = QualifiedIdentifier{}
+	_ Parser[QualifiedIdentifier] = ParseQualified
+
+	_ Notation      = Style{}
+	_ Parser[Style] = ParseStyle
+
+	_ Notation           = (*GameState)(nil)
+	_ Parser[*GameState] = ParseFEEN
+
+	_ Notation      = (*Pool)(nil)
+	_ Parser[*Pool] = ParsePool
+
+	_ Notation     = Cell{}
+	_ Parser[Cell] = ParseCell
+
+	_ Notation           = Transition{}
+	_ Parser[Transition] = ParseTransition
+)
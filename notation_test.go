@@ -0,0 +1,60 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+// roundTrip is a generic check usable with any Sashité notation.
+func roundTrip[T Notation](p Parser[T], s string) (string, error) {
+	v, err := p(s)
+	if err != nil {
+		return "", err
+	}
+	if string(v.AppendTo(nil)) != v.String() {
+		return "", errors.New("AppendTo and String disagree")
+	}
+	return v.String(), nil
+}
+
+func TestNotationRoundTrip(t *testing.T) {
+	p := Parser[Identifier](Parse)
+
+	for i := 0; i < identifierCount; i++ {
+		s := fromIndex(i).String()
+		got, err := roundTrip(p, s)
+		if err != nil {
+			t.Errorf("roundTrip(%q) error = %v", s, err)
+			continue
+		}
+		if got != s {
+			t.Errorf("roundTrip(%q) = %q", s, got)
+		}
+	}
+}
+
+func TestParserValidate(t *testing.T) {
+	p := Parser[Identifier](Parse)
+
+	if err := p.Validate("+K^"); err != nil {
+		t.Errorf("Validate(+K^) = %v, want nil", err)
+	}
+	if err := p.Validate("K+"); !errors.Is(err, ErrInvalidTerminalMarker) {
+		t.Errorf("Validate(K+) = %v, want ErrInvalidTerminalMarker", err)
+	}
+}
+
+func TestParserMust(t *testing.T) {
+	p := Parser[Identifier](Parse)
+
+	if got := p.Must("k^"); got.String() != "k^" {
+		t.Errorf("Must(k^) = %s, want k^", got)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrEmptyInput) {
+			t.Errorf("Must(\"\") panic = %v, want ErrEmptyInput", err)
+		}
+	}()
+	p.Must("")
+}
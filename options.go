@@ -0,0 +1,69 @@
+package pin
+
+import "strings"
+
+// ParseOptions configures ParseWithOptions. The zero value parses exactly
+// like Parse; each field narrows or relaxes the grammar for a dialect, so
+// new flags can be added without changing the signature of Parse.
+type ParseOptions struct {
+	// TrimSpace ignores ASCII whitespace around the PIN string.
+	TrimSpace bool
+
+	// DisallowStates rejects state modifiers, for games without enhanced
+	// or diminished pieces.
+	DisallowStates bool
+
+	// DisallowTerminal rejects terminal markers, for games without
+	// terminal pieces.
+	DisallowTerminal bool
+
+	// Profile, if not nil, rejects abbreviations outside the Profile.
+	Profile *Profile
+}
+
+// asciiSpace lists the whitespace bytes ignored by ParseOptions.TrimSpace.
+const asciiSpace = " \t\n\r\v\f"
+
+// ParseWithOptions is like Parse but applies opts.
+//
+// Example:
+//
+//	ParseWithOptions(" K^ ", ParseOptions{TrimSpace: true})    // K^
+//	ParseWithOptions("+P", ParseOptions{DisallowStates: true}) // ErrInvalidStateModifier
+//	ParseWithOptions("G", ParseOptions{Profile: ChessProfile}) // ErrAbbrNotInProfile
+//
+// Returns a *SyntaxError if the string is not accepted, wrapping one of the
+// parsing sentinels or ErrAbbrNotInProfile. Offsets refer to s, before
+// trimming.
+func ParseWithOptions(s string, opts ParseOptions) (Identifier, error) {
+	input := s
+	lead := 0
+	if opts.TrimSpace {
+		trimmed := strings.TrimLeft(s, asciiSpace)
+		lead = len(s) - len(trimmed)
+		s = strings.TrimRight(trimmed, asciiSpace)
+	}
+
+	if len(s) > MaxStringLength {
+		return Identifier{}, newSyntaxError(input, lead+MaxStringLength, ErrInputTooLong)
+	}
+	id, offset, err := parseBytes([]byte(s))
+	if err != nil {
+		return Identifier{}, newSyntaxError(input, lead+offset, err)
+	}
+
+	letter := lead
+	if id.State() != Normal {
+		letter++
+	}
+	switch {
+	case opts.DisallowStates && id.State() != Normal:
+		return Identifier{}, newSyntaxError(input, lead, ErrInvalidStateModifier)
+	case opts.DisallowTerminal && id.IsTerminal():
+		return Identifier{}, newSyntaxError(input, letter+1, ErrInvalidTerminalMarker)
+	case !opts.Profile.Allows(id):
+		return Identifier{}, newSyntaxError(input, letter, ErrAbbrNotInProfile)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,59 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseWithOptionsZeroValueMatchesParse(t *testing.T) {
+	inputs := []string{"K", "+r^", "-p", "", " K", "K+", "KQR", "+K^X"}
+
+	for _, s := range inputs {
+		want, wantErr := Parse(s)
+		got, err := ParseWithOptions(s, ParseOptions{})
+		if got != want || (err == nil) != (wantErr == nil) || ErrorCode(err) != ErrorCode(wantErr) {
+			t.Errorf("ParseWithOptions(%q) = %v, %v, want %v, %v", s, got, err, want, wantErr)
+		}
+	}
+}
+
+func TestParseWithOptions(t *testing.T) {
+	tests := []struct {
+		input      string
+		opts       ParseOptions
+		want       string
+		wantErr    error
+		wantOffset int
+	}{
+		{" K^ ", ParseOptions{TrimSpace: true}, "K^", nil, 0},
+		{"\t+r\n", ParseOptions{TrimSpace: true}, "+r", nil, 0},
+		{"  ", ParseOptions{TrimSpace: true}, "", ErrEmptyInput, 2},
+		{" K+ ", ParseOptions{TrimSpace: true}, "", ErrInvalidTerminalMarker, 2},
+		{" +K^X", ParseOptions{TrimSpace: true}, "", ErrInputTooLong, 4},
+		{" K", ParseOptions{}, "", ErrInvalidStateModifier, 0},
+		{"K^", ParseOptions{DisallowStates: true}, "K^", nil, 0},
+		{"+P", ParseOptions{DisallowStates: true}, "", ErrInvalidStateModifier, 0},
+		{"-p^", ParseOptions{DisallowStates: true}, "", ErrInvalidStateModifier, 0},
+		{"+K", ParseOptions{DisallowTerminal: true}, "+K", nil, 0},
+		{"K^", ParseOptions{DisallowTerminal: true}, "", ErrInvalidTerminalMarker, 1},
+		{" +k^", ParseOptions{TrimSpace: true, DisallowTerminal: true}, "", ErrInvalidTerminalMarker, 3},
+		{"q", ParseOptions{Profile: ChessProfile}, "q", nil, 0},
+		{"G", ParseOptions{Profile: ChessProfile}, "", ErrAbbrNotInProfile, 0},
+		{"+g^", ParseOptions{Profile: ChessProfile}, "", ErrAbbrNotInProfile, 1},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWithOptions(tt.input, tt.opts)
+		if tt.wantErr == nil {
+			if err != nil || got.String() != tt.want {
+				t.Errorf("ParseWithOptions(%q, %+v) = %v, %v, want %s", tt.input, tt.opts, got, err, tt.want)
+			}
+			continue
+		}
+
+		var se *SyntaxError
+		if !errors.As(err, &se) || !errors.Is(err, tt.wantErr) || se.Input != tt.input || se.Offset != tt.wantOffset {
+			t.Errorf("ParseWithOptions(%q, %+v) error = %v, want %v at offset %d", tt.input, tt.opts, err, tt.wantErr, tt.wantOffset)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package pin
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+)
+
+// Packed encoding.
+//
+// The packed form stores each identifier as its 9-bit compact code
+// (CodeCount = 312 < 512), about a third of the size of newline-separated
+// text, for archiving large game corpora. Its layout is part of the
+// package's compatibility guarantee:
+//
+//	header:  'P', version 1
+//	body:    one 9-bit code per identifier, most significant bit first
+//	trailer: the 9-bit end code 511, then zero bits up to a byte boundary
+//
+// The end code lets the form be written and read as a stream, without
+// knowing the number of identifiers in advance.
+const (
+	packedMagic   = 'P'
+	packedVersion = 1
+	packedEnd     = 0x1ff
+	packedBits    = 9
+)
+
+// AppendPacked appends the packed form of ids to dst.
+//
+// Returns ErrInvalidIdentifier if an identifier is invalid (e.g., the zero
+// value), leaving dst unchanged.
+func AppendPacked(dst []byte, ids []Identifier) ([]byte, error) {
+	for _, id := range ids {
+		if !id.isValid() {
+			return dst, ErrInvalidIdentifier
+		}
+	}
+
+	dst = append(dst, packedMagic, packedVersion)
+	var p bitPacker
+	for _, id := range ids {
+		dst = p.push(dst, uint16(id.index()))
+	}
+	dst = p.push(dst, packedEnd)
+	return p.flush(dst), nil
+}
+
+// UnmarshalPacked decodes the packed form produced by AppendPacked or a
+// PackedEncoder.
+//
+// Returns ErrInvalidPacked if data is malformed or followed by extra bytes,
+// or io.ErrUnexpectedEOF if it is truncated.
+func UnmarshalPacked(data []byte) ([]Identifier, error) {
+	if err := checkPackedHeader(data); err != nil {
+		return nil, err
+	}
+
+	var (
+		ids []Identifier
+		u   bitUnpacker
+	)
+	data = data[2:]
+	for {
+		code, ok := u.pull(&data)
+		if !ok {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if code == packedEnd {
+			break
+		}
+		if code >= CodeCount {
+			return nil, ErrInvalidPacked
+		}
+		ids = append(ids, fromIndex(int(code)))
+	}
+
+	if u.acc != 0 || len(data) != 0 {
+		return nil, ErrInvalidPacked
+	}
+	return ids, nil
+}
+
+// EncodeSequence returns the packed form of ids as unpadded URL-safe
+// base64, so piece sets fit in URLs and query parameters:
+//
+//	EncodeSequence([]Identifier{MustParse("K"), MustParse("q")}) // "UAEePz_g"
+//
+// Returns ErrInvalidIdentifier if an identifier is invalid (e.g., the zero
+// value).
+func EncodeSequence(ids []Identifier) (string, error) {
+	data, err := AppendPacked(nil, ids)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeSequence decodes a string produced by EncodeSequence.
+//
+// Returns ErrInvalidPacked if s is not unpadded URL-safe base64 or does not
+// hold a valid packed sequence.
+func DecodeSequence(s string) ([]Identifier, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidPacked
+	}
+
+	ids, err := UnmarshalPacked(data)
+	if err != nil {
+		return nil, ErrInvalidPacked
+	}
+	return ids, nil
+}
+
+// checkPackedHeader validates the header at the start of data.
+func checkPackedHeader(data []byte) error {
+	if len(data) < 2 {
+		if len(data) == 1 && data[0] != packedMagic {
+			return ErrInvalidPacked
+		}
+		return io.ErrUnexpectedEOF
+	}
+	if data[0] != packedMagic || data[1] != packedVersion {
+		return ErrInvalidPacked
+	}
+	return nil
+}
+
+// ============================================================================
+// Streaming
+// ============================================================================
+
+// PackedEncoder writes identifiers in packed form to an output stream.
+//
+// Writes are buffered; Close writes the trailer and flushes the output.
+type PackedEncoder struct {
+	w      *bufio.Writer
+	p      bitPacker
+	header bool
+	closed bool
+}
+
+// NewPackedEncoder returns a PackedEncoder writing to w.
+func NewPackedEncoder(w io.Writer) *PackedEncoder {
+	return &PackedEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes the code of id.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value), writing nothing, or ErrEncoderClosed after Close. Write errors of
+// the underlying writer persist for later calls.
+func (e *PackedEncoder) Encode(id Identifier) error {
+	if !id.isValid() {
+		return ErrInvalidIdentifier
+	}
+	return e.write(uint16(id.index()))
+}
+
+// Close writes the end code and the padding, and flushes the output. It
+// does not close the underlying writer. Closing twice returns
+// ErrEncoderClosed.
+func (e *PackedEncoder) Close() error {
+	if err := e.write(packedEnd); err != nil {
+		return err
+	}
+	e.closed = true
+
+	var buf [1]byte
+	if _, err := e.w.Write(e.p.flush(buf[:0])); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// write writes a 9-bit code, preceded by the header for the first one.
+func (e *PackedEncoder) write(code uint16) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	var buf [4]byte
+	b := buf[:0]
+	if !e.header {
+		b = append(b, packedMagic, packedVersion)
+		e.header = true
+	}
+	_, err := e.w.Write(e.p.push(b, code))
+	return err
+}
+
+// PackedDecoder reads identifiers in packed form from an input stream.
+type PackedDecoder struct {
+	r      *bufio.Reader
+	u      bitUnpacker
+	header bool
+	done   bool
+}
+
+// NewPackedDecoder returns a PackedDecoder reading from r.
+//
+// The PackedDecoder buffers its input and may read data from r beyond the
+// end of the packed form.
+func NewPackedDecoder(r io.Reader) *PackedDecoder {
+	return &PackedDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next identifier.
+//
+// It returns io.EOF after the end code, ErrInvalidPacked for a malformed
+// input, or io.ErrUnexpectedEOF if the input ends before the end code.
+func (d *PackedDecoder) Decode() (Identifier, error) {
+	if d.done {
+		return Identifier{}, io.EOF
+	}
+
+	if !d.header {
+		var h [2]byte
+		n, err := io.ReadFull(d.r, h[:])
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return Identifier{}, err
+		}
+		if err := checkPackedHeader(h[:n]); err != nil {
+			return Identifier{}, err
+		}
+		d.header = true
+	}
+
+	for d.u.n < packedBits {
+		b, err := d.r.ReadByte()
+		if err == io.EOF {
+			return Identifier{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return Identifier{}, err
+		}
+		d.u.feed(b)
+	}
+
+	code := d.u.take()
+	switch {
+	case code == packedEnd:
+		if d.u.acc != 0 {
+			return Identifier{}, ErrInvalidPacked
+		}
+		d.done = true
+		return Identifier{}, io.EOF
+	case code >= CodeCount:
+		return Identifier{}, ErrInvalidPacked
+	}
+	return fromIndex(int(code)), nil
+}
+
+// ============================================================================
+// Bit Packing (internal)
+// ============================================================================
+
+// bitPacker accumulates 9-bit codes into bytes, most significant bit first.
+type bitPacker struct {
+	acc uint32 // pending bits, in the low n bits
+	n   uint
+}
+
+// push appends the complete bytes formed by adding code to dst.
+func (p *bitPacker) push(dst []byte, code uint16) []byte {
+	p.acc = p.acc<<packedBits | uint32(code)
+	p.n += packedBits
+	for p.n >= 8 {
+		p.n -= 8
+		dst = append(dst, byte(p.acc>>p.n))
+	}
+	p.acc &= 1<<p.n - 1
+	return dst
+}
+
+// flush appends the pending bits to dst, padded with zero bits.
+func (p *bitPacker) flush(dst []byte) []byte {
+	if p.n > 0 {
+		dst = append(dst, byte(p.acc<<(8-p.n)))
+	}
+	p.acc, p.n = 0, 0
+	return dst
+}
+
+// bitUnpacker splits bytes into 9-bit codes, most significant bit first.
+type bitUnpacker struct {
+	acc uint32 // pending bits, in the low n bits
+	n   uint
+}
+
+// feed adds the bits of b.
+func (u *bitUnpacker) feed(b byte) {
+	u.acc = u.acc<<8 | uint32(b)
+	u.n += 8
+}
+
+// take removes and returns the next code; at least 9 bits must be pending.
+func (u *bitUnpacker) take() uint16 {
+	u.n -= packedBits
+	code := uint16(u.acc >> u.n)
+	u.acc &= 1<<u.n - 1
+	return code
+}
+
+// pull takes the next code, feeding bytes from *data as needed. It reports
+// false if *data runs out first.
+func (u *bitUnpacker) pull(data *[]byte) (uint16, bool) {
+	for u.n < packedBits {
+		if len(*data) == 0 {
+			return 0, false
+		}
+		u.feed((*data)[0])
+		*data = (*data)[1:]
+	}
+	return u.take(), true
+}
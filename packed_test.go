@@ -0,0 +1,222 @@
+package pin
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPackedRoundTrip(t *testing.T) {
+	inputs := [][]Identifier{
+		nil,
+		{MustParse("K")},
+		{MustParse("K"), MustParse("+p^")},
+		All(),
+	}
+
+	for _, ids := range inputs {
+		data, err := AppendPacked(nil, ids)
+		if err != nil {
+			t.Fatalf("AppendPacked(%v) error = %v", ids, err)
+		}
+		if want := 2 + (len(ids)+1)*9/8 + min(1, (len(ids)+1)*9%8); len(data) != want {
+			t.Errorf("len(AppendPacked(%d ids)) = %d, want %d", len(ids), len(data), want)
+		}
+
+		got, err := UnmarshalPacked(data)
+		if err != nil || !equalIdentifiers(got, ids) {
+			t.Errorf("UnmarshalPacked() = %v, %v, want %v", got, err, ids)
+		}
+	}
+}
+
+// The packed layout is stable across versions; these bytes must never change.
+func TestPackedLayout(t *testing.T) {
+	// K = 60 = 0b000111100, -z^ = 311 = 0b100110111, end = 0b111111111
+	data, _ := AppendPacked([]byte("x"), []Identifier{MustParse("K"), MustParse("-z^")})
+	want := []byte{'x', 'P', 1, 0b00011110, 0b01001101, 0b11111111, 0b11100000}
+	if !bytes.Equal(data, want) {
+		t.Errorf("AppendPacked() = %08b, want %08b", data, want)
+	}
+}
+
+func TestAppendPackedRejectsZeroIdentifier(t *testing.T) {
+	dst := []byte("x")
+	got, err := AppendPacked(dst, []Identifier{MustParse("K"), {}})
+	if !errors.Is(err, ErrInvalidIdentifier) || string(got) != "x" {
+		t.Errorf("AppendPacked() = %q, %v, want \"x\", ErrInvalidIdentifier", got, err)
+	}
+}
+
+func TestUnmarshalPackedErrors(t *testing.T) {
+	valid, _ := AppendPacked(nil, []Identifier{MustParse("K"), MustParse("q")})
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, io.ErrUnexpectedEOF},
+		{"magic only", []byte{'P'}, io.ErrUnexpectedEOF},
+		{"bad magic", []byte{'X', 1, 0xff, 0x80}, ErrInvalidPacked},
+		{"bad version", []byte{'P', 2, 0xff, 0x80}, ErrInvalidPacked},
+		{"no end code", valid[:len(valid)-1], io.ErrUnexpectedEOF},
+		{"trailing byte", append(append([]byte{}, valid...), 0), ErrInvalidPacked},
+		{"nonzero padding", []byte{'P', 1, 0xff, 0xc0}, ErrInvalidPacked},
+		{"unassigned code", []byte{'P', 1, 0x9c, 0x7f, 0xc0}, ErrInvalidPacked}, // 312
+	}
+
+	for _, tt := range tests {
+		if _, err := UnmarshalPacked(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: UnmarshalPacked() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestPackedEncoderDecoder(t *testing.T) {
+	ids := All()
+
+	var buf bytes.Buffer
+	enc := NewPackedEncoder(&buf)
+	for _, id := range ids {
+		if err := enc.Encode(id); err != nil {
+			t.Fatalf("Encode(%v) error = %v", id, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want, _ := AppendPacked(nil, ids)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatal("PackedEncoder output differs from AppendPacked")
+	}
+
+	dec := NewPackedDecoder(&buf)
+	var got []Identifier
+	for {
+		id, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		got = append(got, id)
+	}
+	if !equalIdentifiers(got, ids) {
+		t.Error("PackedDecoder did not round-trip All()")
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Decode() after end = %v, want io.EOF", err)
+	}
+}
+
+func TestPackedEncoderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewPackedEncoder(&buf).Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if ids, err := UnmarshalPacked(buf.Bytes()); err != nil || len(ids) != 0 {
+		t.Errorf("UnmarshalPacked() = %v, %v, want no identifiers", ids, err)
+	}
+}
+
+func TestPackedEncoderErrors(t *testing.T) {
+	enc := NewPackedEncoder(io.Discard)
+	if err := enc.Encode(Identifier{}); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Encode(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+	_ = enc.Close()
+	if err := enc.Encode(MustParse("K")); !errors.Is(err, ErrEncoderClosed) {
+		t.Errorf("Encode() after Close error = %v, want ErrEncoderClosed", err)
+	}
+	if err := enc.Close(); !errors.Is(err, ErrEncoderClosed) {
+		t.Errorf("second Close() error = %v, want ErrEncoderClosed", err)
+	}
+}
+
+func TestPackedDecoderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, io.ErrUnexpectedEOF},
+		{"bad magic", []byte{'X', 1}, ErrInvalidPacked},
+		{"truncated", []byte{'P', 1, 0x1e}, io.ErrUnexpectedEOF},
+		{"nonzero padding", []byte{'P', 1, 0xff, 0xc0}, ErrInvalidPacked},
+	}
+
+	for _, tt := range tests {
+		dec := NewPackedDecoder(bytes.NewReader(tt.data))
+		var err error
+		for err == nil {
+			_, err = dec.Decode()
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: Decode() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestPackedSizeVersusText(t *testing.T) {
+	ids := All()
+	text := AppendAllTo(nil, ids, '\n')
+	packed, _ := AppendPacked(nil, ids)
+
+	if 5*len(packed) > 2*len(text) {
+		t.Errorf("packed size %d exceeds 40%% of text size %d", len(packed), len(text))
+	}
+}
+
+// equalIdentifiers reports whether two slices hold the same identifiers.
+func equalIdentifiers(a, b []Identifier) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSequenceRoundTrip(t *testing.T) {
+	for _, ids := range [][]Identifier{nil, {MustParse("K")}, All()} {
+		s, err := EncodeSequence(ids)
+		if err != nil {
+			t.Fatalf("EncodeSequence() error = %v", err)
+		}
+		if strings.ContainsAny(s, "+/=") {
+			t.Errorf("EncodeSequence() = %q, want URL-safe unpadded base64", s)
+		}
+
+		got, err := DecodeSequence(s)
+		if err != nil || !equalIdentifiers(got, ids) {
+			t.Errorf("DecodeSequence(%q) = %v, %v, want %v", s, got, err, ids)
+		}
+	}
+}
+
+func TestEncodeSequenceStable(t *testing.T) {
+	s, _ := EncodeSequence([]Identifier{MustParse("K"), MustParse("q")})
+	if s != "UAEePz_g" {
+		t.Errorf("EncodeSequence([K q]) = %q, want \"UAEePz_g\"", s)
+	}
+}
+
+func TestSequenceErrors(t *testing.T) {
+	if _, err := EncodeSequence([]Identifier{{}}); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("EncodeSequence(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+
+	for _, s := range []string{"", "UAEePz_g=", "UAEeNP/A", "UAEe", "!!!!"} {
+		if _, err := DecodeSequence(s); !errors.Is(err, ErrInvalidPacked) {
+			t.Errorf("DecodeSequence(%q) error = %v, want ErrInvalidPacked", s, err)
+		}
+	}
+}
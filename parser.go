@@ -1,5 +1,7 @@
 package pin
 
+import "strings"
+
 // Parse converts a PIN string into an Identifier.
 //
 // The parser uses byte-level validation to ensure security against
@@ -11,7 +13,7 @@ package pin
 //   - With terminal marker: "K^", "k^"
 //   - Combined: "+K^", "-k^"
 //
-// Returns an error if the string is not valid:
+// Returns a *SyntaxError if the string is not valid, wrapping one of:
 //   - ErrEmptyInput: empty string
 //   - ErrInputTooLong: exceeds 3 characters
 //   - ErrMustContainOneLetter: no letter found
@@ -19,19 +21,58 @@ package pin
 //   - ErrInvalidTerminalMarker: invalid suffix character
 func Parse(s string) (Identifier, error) {
 	// Validate input length
-	if len(s) == 0 {
-		return Identifier{}, ErrEmptyInput
-	}
 	if len(s) > MaxStringLength {
-		return Identifier{}, ErrInputTooLong
+		return Identifier{}, newSyntaxError(s, MaxStringLength, ErrInputTooLong)
 	}
 
 	// Convert to bytes for safe parsing
 	// This also ensures we reject multi-byte UTF-8 characters
-	bytes := []byte(s)
+	id, offset, err := parseBytes([]byte(s))
+	if err != nil {
+		return Identifier{}, newSyntaxError(s, offset, err)
+	}
+	return id, nil
+}
+
+// ParseInto is like Parse but stores the result in *dst, which is left
+// unchanged on error.
+//
+// It never allocates, including on error, so it suits hot loops parsing
+// tokens into preallocated storage. For that reason its errors are the bare
+// sentinels listed by Parse, without the *SyntaxError that locates them.
+func ParseInto(s string, dst *Identifier) error {
+	if len(s) > MaxStringLength {
+		return ErrInputTooLong
+	}
+
+	id, _, err := parseBytes([]byte(s))
+	if err != nil {
+		return err
+	}
+	*dst = id
+	return nil
+}
+
+// ParseBytes is like Parse but reads a byte slice, such as a token from a
+// network buffer or a bufio.Scanner, without converting it to a string.
+//
+// It does not allocate on success and does not retain bytes.
+func ParseBytes(bytes []byte) (Identifier, error) {
+	id, offset, err := parseBytes(bytes)
+	if err != nil {
+		// Only the echoed part of the input is converted
+		return Identifier{}, newSyntaxError(string(bytes[:min(len(bytes), maxTokenEcho)]), offset, err)
+	}
+	return id, nil
+}
 
+// parseBytes parses a PIN token, returning the parsing sentinel and the
+// offset of the offending byte on error.
+func parseBytes(bytes []byte) (Identifier, int, error) {
 	// Dispatch based on length
 	switch len(bytes) {
+	case 0:
+		return Identifier{}, 0, ErrEmptyInput
 	case 1:
 		return parseLength1(bytes[0])
 	case 2:
@@ -39,117 +80,202 @@ func Parse(s string) (Identifier, error) {
 	case 3:
 		return parseLength3(bytes[0], bytes[1], bytes[2])
 	default:
-		// Should not reach here due to length check above
-		return Identifier{}, ErrInputTooLong
+		return Identifier{}, MaxStringLength, ErrInputTooLong
 	}
 }
 
 // parseLength1 handles single-byte input (letter only).
-func parseLength1(b byte) (Identifier, error) {
+func parseLength1(b byte) (Identifier, int, error) {
 	abbr, side, ok := classifyLetter(b)
 	if !ok {
-		return Identifier{}, ErrMustContainOneLetter
+		return Identifier{}, 0, ErrMustContainOneLetter
 	}
 
-	return Identifier{
-		abbr:     abbr,
-		side:     side,
-		state:    Normal,
-		terminal: false,
-	}, nil
+	return makeIdentifier(abbr, side, Normal, false), 0, nil
 }
 
 // parseLength2 handles two-byte input (modifier+letter or letter+terminal).
-func parseLength2(first, second byte) (Identifier, error) {
+func parseLength2(first, second byte) (Identifier, int, error) {
 	// Try: modifier + letter
 	if state, ok := classifyModifier(first); ok {
 		abbr, side, ok := classifyLetter(second)
 		if !ok {
-			return Identifier{}, ErrMustContainOneLetter
+			return Identifier{}, 1, ErrMustContainOneLetter
 		}
-		return Identifier{
-			abbr:     abbr,
-			side:     side,
-			state:    state,
-			terminal: false,
-		}, nil
+		return makeIdentifier(abbr, side, state, false), 0, nil
 	}
 
 	// Try: letter + terminal
 	abbr, side, ok := classifyLetter(first)
 	if !ok {
 		// First byte is not a letter and not a modifier
-		if isTerminalMarker(first) {
-			return Identifier{}, ErrInvalidStateModifier
-		}
-		return Identifier{}, ErrInvalidStateModifier
+		return Identifier{}, 0, ErrInvalidStateModifier
 	}
 
 	if !isTerminalMarker(second) {
-		return Identifier{}, ErrInvalidTerminalMarker
+		return Identifier{}, 1, ErrInvalidTerminalMarker
 	}
 
-	return Identifier{
-		abbr:     abbr,
-		side:     side,
-		state:    Normal,
-		terminal: true,
-	}, nil
+	return makeIdentifier(abbr, side, Normal, true), 0, nil
 }
 
 // parseLength3 handles three-byte input (modifier+letter+terminal).
-func parseLength3(first, second, third byte) (Identifier, error) {
+func parseLength3(first, second, third byte) (Identifier, int, error) {
 	// Must be: modifier + letter + terminal
 	state, ok := classifyModifier(first)
 	if !ok {
 		// First byte is not a valid modifier
 		if _, _, isLetter := classifyLetter(first); isLetter {
-			return Identifier{}, ErrInvalidTerminalMarker
+			// A letter may only be followed by a single terminal marker
+			if isTerminalMarker(second) {
+				return Identifier{}, 2, ErrInvalidTerminalMarker
+			}
+			return Identifier{}, 1, ErrInvalidTerminalMarker
 		}
-		return Identifier{}, ErrInvalidStateModifier
+		return Identifier{}, 0, ErrInvalidStateModifier
 	}
 
 	abbr, side, ok := classifyLetter(second)
 	if !ok {
-		return Identifier{}, ErrMustContainOneLetter
+		return Identifier{}, 1, ErrMustContainOneLetter
 	}
 
 	if !isTerminalMarker(third) {
-		return Identifier{}, ErrInvalidTerminalMarker
+		return Identifier{}, 2, ErrInvalidTerminalMarker
+	}
+
+	return makeIdentifier(abbr, side, state, true), 0, nil
+}
+
+// ParsePrefix parses the longest PIN at the start of s and returns the
+// remainder, so that notations embedding PIN tokens without delimiters can
+// be parsed piece by piece.
+//
+// Example:
+//
+//	id, rest, err := ParsePrefix("+K^3p") // +K^, "3p", nil
+//	id, rest, err = ParsePrefix("KQ")     // K, "Q", nil
+//
+// On error, rest is s and err is a *SyntaxError wrapping ErrEmptyInput for
+// an empty string, ErrInvalidStateModifier if s starts with an invalid
+// character followed by a letter, or ErrMustContainOneLetter if no letter
+// follows the optional state modifier.
+func ParsePrefix(s string) (id Identifier, rest string, err error) {
+	id, n, err := parsePrefix(s)
+	if err != nil {
+		return Identifier{}, s, newSyntaxError(s, n, err)
+	}
+	return id, s[n:], nil
+}
+
+// parsePrefix parses the longest PIN at the start of s. It returns the
+// number of bytes consumed, or the parsing sentinel and the offset of the
+// offending byte.
+func parsePrefix(s string) (Identifier, int, error) {
+	if len(s) == 0 {
+		return Identifier{}, 0, ErrEmptyInput
+	}
+
+	i := 0
+	state, hasModifier := classifyModifier(s[0])
+	if hasModifier {
+		i++
+	}
+
+	if i == len(s) {
+		return Identifier{}, i, ErrMustContainOneLetter
+	}
+	abbr, side, ok := classifyLetter(s[i])
+	if !ok {
+		// Same diagnosis as Parse for an invalid prefix before a letter
+		if !hasModifier && len(s) > 1 {
+			if _, _, isLetter := classifyLetter(s[1]); isLetter {
+				return Identifier{}, 0, ErrInvalidStateModifier
+			}
+		}
+		return Identifier{}, i, ErrMustContainOneLetter
+	}
+	i++
+
+	terminal := i < len(s) && isTerminalMarker(s[i])
+	if terminal {
+		i++
 	}
 
-	return Identifier{
-		abbr:     abbr,
-		side:     side,
-		state:    state,
-		terminal: true,
-	}, nil
+	return makeIdentifier(abbr, side, state, terminal), i, nil
 }
 
+// ParseAll parses a list of PIN strings separated by sep, such as "," or
+// " ". Whitespace around each element is ignored.
+//
+// An empty sep selects the default: elements separated by any run of ASCII
+// whitespace or commas, as read by a Decoder.
+//
+// Example:
+//
+//	ParseAll("K, +p^, -r", ",") // [K +p^ -r]
+//	ParseAll("K +p^,-r", "")    // [K +p^ -r]
+//
+// It returns either every identifier or an *IndexError wrapping the parsing
+// error of the first invalid element. With an explicit sep, empty elements
+// (including an empty s) are invalid; with the default, an empty s yields
+// no identifiers.
+func ParseAll(s, sep string) ([]Identifier, error) {
+	var elems []string
+	if sep == "" {
+		elems = strings.FieldsFunc(s, func(r rune) bool {
+			return r < 0x80 && isSeparator(byte(r))
+		})
+	} else {
+		elems = strings.Split(s, sep)
+	}
+
+	ids := make([]Identifier, len(elems))
+	for i, e := range elems {
+		id, err := Parse(strings.TrimSpace(e))
+		if err != nil {
+			return nil, &IndexError{Index: i, Err: err}
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// Byte classification tables, indexed by input byte, so that classifying a
+// byte is a single load instead of a chain of comparisons.
+var (
+	// letterTable holds the uppercase letter of each ASCII letter in bits
+	// 0-6, and its side in bit 7; other bytes map to 0.
+	letterTable = func() (table [256]uint8) {
+		for b := 'A'; b <= 'Z'; b++ {
+			table[b] = uint8(b) | uint8(First)<<7
+			table[b-'A'+'a'] = uint8(b) | uint8(Second)<<7
+		}
+		return table
+	}()
+
+	// modifierTable holds the state of each state modifier in bits 0-6,
+	// with bit 7 set; other bytes map to 0.
+	modifierTable = func() (table [256]uint8) {
+		table[enhancedPrefix] = 0x80 | uint8(Enhanced)
+		table[diminishedPrefix] = 0x80 | uint8(Diminished)
+		return table
+	}()
+)
+
 // classifyLetter checks if a byte is a valid ASCII letter.
 // Returns the uppercase abbreviation, side, and whether it's valid.
 func classifyLetter(b byte) (rune, Side, bool) {
-	switch {
-	case b >= 'A' && b <= 'Z':
-		return rune(b), First, true
-	case b >= 'a' && b <= 'z':
-		return rune(b - 'a' + 'A'), Second, true
-	default:
-		return 0, 0, false
-	}
+	e := letterTable[b]
+	return rune(e & 0x7f), Side(e >> 7), e != 0
 }
 
 // classifyModifier checks if a byte is a valid state modifier.
-// Returns the state and whether it's valid.
+// Returns the state (Normal if invalid) and whether it's valid.
 func classifyModifier(b byte) (State, bool) {
-	switch b {
-	case '+':
-		return Enhanced, true
-	case '-':
-		return Diminished, true
-	default:
-		return Normal, false
-	}
+	m := modifierTable[b]
+	return State(m & 0x7f), m != 0
 }
 
 // isTerminalMarker checks if a byte is the terminal marker.
@@ -174,8 +300,51 @@ func Validate(s string) error {
 	return err
 }
 
+// ValidateAll checks every string of ss and reports all invalid ones, rather
+// than stopping at the first.
+//
+// Returns nil if all strings are valid, or IndexErrors listing each invalid
+// string in order, with its index and parsing error.
+func ValidateAll(ss []string) error {
+	var errs IndexErrors
+	for i, s := range ss {
+		if _, err := Parse(s); err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
 // IsValid reports whether s is a valid PIN identifier.
+// It never allocates.
 func IsValid(s string) bool {
-	_, err := Parse(s)
+	if len(s) > MaxStringLength {
+		return false
+	}
+	_, _, err := parseBytes([]byte(s))
 	return err == nil
 }
+
+// Canonicalize parses s and returns the canonical PIN string of the
+// resulting Identifier, or the parsing error.
+//
+// PIN has a single spelling per identifier, so a valid s is returned with
+// the same content. The result does not share memory with s, which lets
+// callers keep normalized tokens without retaining a larger input buffer.
+func Canonicalize(s string) (string, error) {
+	id, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// IsCanonical reports whether s is the canonical PIN string of an
+// Identifier. It is equivalent to IsValid, since every valid PIN string is
+// canonical, and never allocates.
+func IsCanonical(s string) bool {
+	return IsValid(s)
+}
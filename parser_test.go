@@ -2,6 +2,7 @@ package pin
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -473,6 +474,47 @@ func TestParseRejectsZeroWidthCharacters(t *testing.T) {
 	}
 }
 
+// Every parsing entry point works on bytes and must reject multi-byte
+// characters the same way.
+func TestParseEntryPointsRejectMultiByteInput(t *testing.T) {
+	inputs := []string{
+		"\xD0\x9A",     // Cyrillic 'К' (U+041A)
+		"+\xD0\x9A",    // modifier + Cyrillic 'К'
+		"\xCE\x91^",    // Greek 'Α' (U+0391) + terminal marker
+		"\xEF\xBC\xAB", // Full-width 'K' (U+FF2B)
+		"K\xCC\x81",    // 'K' + combining acute accent (U+0301)
+	}
+
+	for _, input := range inputs {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", input)
+		}
+		if _, err := ParseBytes([]byte(input)); err == nil {
+			t.Errorf("ParseBytes(%q) succeeded, want error", input)
+		}
+		if _, err := ParseWithOptions(input, ParseOptions{TrimSpace: true}); err == nil {
+			t.Errorf("ParseWithOptions(%q) succeeded, want error", input)
+		}
+		if id, rest, err := ParsePrefix(input); err == nil && rest == "" {
+			t.Errorf("ParsePrefix(%q) = %v, consuming the whole input", input, id)
+		}
+	}
+}
+
+func TestParseNoAllocs(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		s := fromIndex(i).String()
+		allocs := testing.AllocsPerRun(10, func() {
+			_, _ = Parse(s)
+			_, _, _ = ParsePrefix(s)
+			_, _ = ParseWithOptions(s, ParseOptions{})
+		})
+		if allocs != 0 {
+			t.Fatalf("parsing %q allocates %v times, want 0", s, allocs)
+		}
+	}
+}
+
 // ============================================================================
 // Round-Trip Tests
 // ============================================================================
@@ -566,3 +608,434 @@ func TestParseOneOverMaxLength(t *testing.T) {
 		t.Errorf("Parse(%q) error = %v, want ErrInputTooLong", input, err)
 	}
 }
+
+// ============================================================================
+// ParseBytes
+// ============================================================================
+
+func TestParseBytesMatchesParse(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		want := fromIndex(i)
+
+		got, err := ParseBytes([]byte(want.String()))
+		if err != nil {
+			t.Errorf("ParseBytes(%q) error = %v", want.String(), err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseBytes(%q) = %s, want %s", want.String(), got, want)
+		}
+	}
+}
+
+func TestParseBytesErrors(t *testing.T) {
+	tests := []struct {
+		input []byte
+		want  error
+	}{
+		{nil, ErrEmptyInput},
+		{[]byte{}, ErrEmptyInput},
+		{[]byte("+K^X"), ErrInputTooLong},
+		{[]byte("1"), ErrMustContainOneLetter},
+		{[]byte("*K"), ErrInvalidStateModifier},
+		{[]byte("K+"), ErrInvalidTerminalMarker},
+		{[]byte{'K', 0}, ErrInvalidTerminalMarker},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseBytes(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("ParseBytes(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestParseBytesNoAllocs(t *testing.T) {
+	buf := []byte("+K^ -p")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ParseBytes(buf[:3])
+		_, _ = ParseBytes(buf[4:])
+	})
+	if allocs != 0 {
+		t.Errorf("ParseBytes() allocs = %v, want 0", allocs)
+	}
+}
+
+// ============================================================================
+// ParsePrefix
+// ============================================================================
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantID   string
+		wantRest string
+	}{
+		{"K", "K", ""},
+		{"+K^", "+K^", ""},
+		{"+K^3p", "+K^", "3p"},
+		{"KQ", "K", "Q"},
+		{"k^^", "k^", "^"},
+		{"-p+r", "-p", "+r"},
+		{"r/8", "r", "/8"},
+		{"K ", "K", " "},
+	}
+
+	for _, tt := range tests {
+		id, rest, err := ParsePrefix(tt.input)
+		if err != nil {
+			t.Errorf("ParsePrefix(%q) error = %v", tt.input, err)
+			continue
+		}
+		if id.String() != tt.wantID || rest != tt.wantRest {
+			t.Errorf("ParsePrefix(%q) = %s, %q; want %s, %q", tt.input, id, rest, tt.wantID, tt.wantRest)
+		}
+	}
+}
+
+func TestParsePrefixAllIdentifiers(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		want := fromIndex(i)
+
+		id, rest, err := ParsePrefix(want.String() + ",")
+		if err != nil || id != want || rest != "," {
+			t.Errorf("ParsePrefix(%q) = %s, %q, %v", want.String()+",", id, rest, err)
+		}
+	}
+}
+
+func TestParsePrefixErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", ErrEmptyInput},
+		{"+", ErrMustContainOneLetter},
+		{"-1", ErrMustContainOneLetter},
+		{"^K", ErrInvalidStateModifier},
+		{"*K", ErrInvalidStateModifier},
+		{"3p", ErrInvalidStateModifier},
+		{"33", ErrMustContainOneLetter},
+		{"++K", ErrMustContainOneLetter},
+	}
+
+	for _, tt := range tests {
+		_, rest, err := ParsePrefix(tt.input)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ParsePrefix(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+		if rest != tt.input {
+			t.Errorf("ParsePrefix(%q) rest = %q, want the input", tt.input, rest)
+		}
+	}
+}
+
+// ============================================================================
+// ParseAll
+// ============================================================================
+
+func TestParseAll(t *testing.T) {
+	tests := []struct {
+		input string
+		sep   string
+		want  []string
+	}{
+		{"K,+p^,-r", ",", []string{"K", "+p^", "-r"}},
+		{"K, +p^ , -r", ",", []string{"K", "+p^", "-r"}},
+		{"K +p^ -r", " ", []string{"K", "+p^", "-r"}},
+		{"K;q", ";", []string{"K", "q"}},
+		{"K", ",", []string{"K"}},
+		{"K +p^,-r", "", []string{"K", "+p^", "-r"}},
+		{" K\n\t+p^ ,, -r\n", "", []string{"K", "+p^", "-r"}},
+		{"", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		ids, err := ParseAll(tt.input, tt.sep)
+		if err != nil {
+			t.Errorf("ParseAll(%q, %q) error = %v", tt.input, tt.sep, err)
+			continue
+		}
+
+		got := make([]string, len(ids))
+		for i, id := range ids {
+			got[i] = id.String()
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") || len(got) != len(tt.want) {
+			t.Errorf("ParseAll(%q, %q) = %v, want %v", tt.input, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestParseAllErrors(t *testing.T) {
+	tests := []struct {
+		input     string
+		sep       string
+		wantIndex int
+		wantErr   error
+	}{
+		{"K,X+,q", ",", 1, ErrInvalidTerminalMarker},
+		{"K,,q", ",", 1, ErrEmptyInput},
+		{"", ",", 0, ErrEmptyInput},
+		{"K q 1", "", 2, ErrMustContainOneLetter},
+		{"K +K^^", "", 1, ErrInputTooLong},
+		{"K\u00a0q", "", 0, ErrInputTooLong}, // no-break space is not a separator
+	}
+
+	for _, tt := range tests {
+		ids, err := ParseAll(tt.input, tt.sep)
+		if ids != nil {
+			t.Errorf("ParseAll(%q, %q) = %v, want nil", tt.input, tt.sep, ids)
+		}
+
+		var ie *IndexError
+		if !errors.As(err, &ie) {
+			t.Errorf("ParseAll(%q, %q) error = %v, want *IndexError", tt.input, tt.sep, err)
+			continue
+		}
+		if ie.Index != tt.wantIndex || !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseAll(%q, %q) error = %v, want index %d and %v", tt.input, tt.sep, err, tt.wantIndex, tt.wantErr)
+		}
+	}
+}
+
+// ============================================================================
+// ParseInto
+// ============================================================================
+
+func TestParseInto(t *testing.T) {
+	var id Identifier
+	if err := ParseInto("+K^", &id); err != nil {
+		t.Fatalf("ParseInto() error = %v", err)
+	}
+	if id.String() != "+K^" {
+		t.Errorf("ParseInto() stored %s, want +K^", id)
+	}
+}
+
+func TestParseIntoErrorLeavesDst(t *testing.T) {
+	id := MustParse("q")
+	if err := ParseInto("K+", &id); err != ErrInvalidTerminalMarker {
+		t.Errorf("ParseInto() error = %v, want ErrInvalidTerminalMarker", err)
+	}
+	if err := ParseInto("KKKK", &id); err != ErrInputTooLong {
+		t.Errorf("ParseInto(KKKK) error = %v, want ErrInputTooLong", err)
+	}
+	if id.String() != "q" {
+		t.Errorf("ParseInto() changed dst to %s on error", id)
+	}
+}
+
+func TestParseIntoNoAllocs(t *testing.T) {
+	inputs := []string{"K", "+r", "-p^", "k^", "", "KKKK", "1", "*K", "K+"}
+	dst := make([]Identifier, len(inputs))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for i, s := range inputs {
+			_ = ParseInto(s, &dst[i])
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("ParseInto() allocs = %v, want 0", allocs)
+	}
+}
+
+// ============================================================================
+// SyntaxError Offsets
+// ============================================================================
+
+func TestParseSyntaxError(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantErr    error
+		wantOffset int
+		wantByte   byte
+	}{
+		{"", ErrEmptyInput, 0, 0},
+		{"1", ErrMustContainOneLetter, 0, '1'},
+		{"*K", ErrInvalidStateModifier, 0, '*'},
+		{"+1", ErrMustContainOneLetter, 1, '1'},
+		{"K+", ErrInvalidTerminalMarker, 1, '+'},
+		{"KQR", ErrInvalidTerminalMarker, 1, 'Q'},
+		{"K^^", ErrInvalidTerminalMarker, 2, '^'},
+		{"^K^", ErrInvalidStateModifier, 0, '^'},
+		{"+^K", ErrMustContainOneLetter, 1, '^'},
+		{"+K+", ErrInvalidTerminalMarker, 2, '+'},
+		{"+K^X", ErrInputTooLong, 3, 'X'},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("Parse(%q) error = %v, want *SyntaxError", tt.input, err)
+			continue
+		}
+		if !errors.Is(err, tt.wantErr) || se.Input != tt.input || se.Offset != tt.wantOffset || se.Byte != tt.wantByte {
+			t.Errorf("Parse(%q) error = %+v, want %v at offset %d (%q)", tt.input, se, tt.wantErr, tt.wantOffset, tt.wantByte)
+		}
+	}
+}
+
+func TestParseSyntaxErrorTruncatesInput(t *testing.T) {
+	long := strings.Repeat("K", 100)
+
+	for _, parse := range []func() error{
+		func() error { _, err := Parse(long); return err },
+		func() error { _, err := ParseBytes([]byte(long)); return err },
+	} {
+		var se *SyntaxError
+		if err := parse(); !errors.As(err, &se) {
+			t.Fatalf("error = %v, want *SyntaxError", err)
+		}
+		if se.Input != long[:32] || se.Offset != MaxStringLength || se.Byte != 'K' {
+			t.Errorf("error = %+v, want the first 32 bytes at offset %d", se, MaxStringLength)
+		}
+	}
+}
+
+func TestParsePrefixSyntaxError(t *testing.T) {
+	_, _, err := ParsePrefix("+")
+
+	var se *SyntaxError
+	if !errors.As(err, &se) || se.Offset != 1 || se.Byte != 0 {
+		t.Errorf("ParsePrefix(\"+\") error = %+v, want *SyntaxError at offset 1 with no byte", se)
+	}
+}
+
+// ============================================================================
+// Canonical Form
+// ============================================================================
+
+func TestCanonicalize(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		s := fromIndex(i).String()
+
+		got, err := Canonicalize(s)
+		if err != nil || got != s {
+			t.Errorf("Canonicalize(%q) = %q, %v", s, got, err)
+		}
+		if !IsCanonical(s) {
+			t.Errorf("IsCanonical(%q) = false, want true", s)
+		}
+	}
+}
+
+func TestCanonicalizeInvalid(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", ErrEmptyInput},
+		{" K", ErrInvalidStateModifier},
+		{"K+", ErrInvalidTerminalMarker},
+		{"+K^ ", ErrInputTooLong},
+	}
+
+	for _, tt := range tests {
+		got, err := Canonicalize(tt.input)
+		if got != "" || !errors.Is(err, tt.want) {
+			t.Errorf("Canonicalize(%q) = %q, %v; want \"\", %v", tt.input, got, err, tt.want)
+		}
+		if IsCanonical(tt.input) {
+			t.Errorf("IsCanonical(%q) = true, want false", tt.input)
+		}
+	}
+}
+
+func TestIsCanonicalNoAllocs(t *testing.T) {
+	inputs := []string{"K", "+r^", "", "K+", "*K", strings.Repeat("K", 64)}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, s := range inputs {
+			_ = IsCanonical(s)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("IsCanonical() allocs = %v, want 0", allocs)
+	}
+}
+
+// ============================================================================
+// ValidateAll
+// ============================================================================
+
+func TestValidateAllValid(t *testing.T) {
+	for _, ss := range [][]string{nil, {}, {"K", "+r", "-p^"}} {
+		if err := ValidateAll(ss); err != nil {
+			t.Errorf("ValidateAll(%q) = %v, want nil", ss, err)
+		}
+	}
+}
+
+func TestValidateAllReportsEveryInvalidEntry(t *testing.T) {
+	ss := []string{"K", "", "+r", "K+", "1", "-p^"}
+
+	err := ValidateAll(ss)
+	var errs IndexErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ValidateAll() = %v, want IndexErrors", err)
+	}
+
+	want := []struct {
+		index int
+		err   error
+	}{
+		{1, ErrEmptyInput},
+		{3, ErrInvalidTerminalMarker},
+		{4, ErrMustContainOneLetter},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("len(IndexErrors) = %d, want %d: %v", len(errs), len(want), err)
+	}
+	for i, w := range want {
+		if errs[i].Index != w.index || !errors.Is(errs[i], w.err) {
+			t.Errorf("IndexErrors[%d] = %v, want element %d: %v", i, errs[i], w.index, w.err)
+		}
+	}
+}
+
+// ============================================================================
+// Byte Classification
+// ============================================================================
+
+func TestClassifyLetterAllBytes(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		b := byte(i)
+		abbr, side, ok := classifyLetter(b)
+
+		switch {
+		case b >= 'A' && b <= 'Z':
+			if !ok || abbr != rune(b) || side != First {
+				t.Errorf("classifyLetter(%q) = %q, %v, %v, want %q, First, true", b, abbr, side, ok, b)
+			}
+		case b >= 'a' && b <= 'z':
+			if !ok || abbr != rune(b-'a'+'A') || side != Second {
+				t.Errorf("classifyLetter(%q) = %q, %v, %v, want %q, Second, true", b, abbr, side, ok, b-'a'+'A')
+			}
+		default:
+			if ok {
+				t.Errorf("classifyLetter(%q) = %q, %v, true, want false", b, abbr, side)
+			}
+		}
+	}
+}
+
+func TestClassifyModifierAllBytes(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		b := byte(i)
+		state, ok := classifyModifier(b)
+
+		want, wantOK := Normal, false
+		switch b {
+		case '+':
+			want, wantOK = Enhanced, true
+		case '-':
+			want, wantOK = Diminished, true
+		}
+		if state != want || ok != wantOK {
+			t.Errorf("classifyModifier(%q) = %v, %v, want %v, %v", b, state, ok, want, wantOK)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// Package pgxpin provides a pgx v5 codec for PIN identifiers.
+//
+// It lives in its own module so that the pin package itself keeps no
+// third-party dependencies.
+//
+// Identifiers are best stored in a Postgres domain, so the database enforces
+// the PIN syntax:
+//
+//	_, err := conn.Exec(ctx, pgxpin.DomainSQL("pin"))
+//
+// Each connection then registers the codec for the domain and its array type:
+//
+//	err := pgxpin.LoadAndRegister(ctx, conn, "pin")
+//
+// With pgxpool, call LoadAndRegister from the AfterConnect hook.
+//
+// Postgres describes the result columns of a domain with the OID of its base
+// type, varchar, rather than the OID of the domain, so the codec registered
+// for the domain is not selected when scanning them. Such columns are
+// scanned as varchar: a *pin.Identifier target is then filled through its
+// sql.Scanner implementation, which parses the string. The codec applies
+// where Postgres reports the domain's own OID, such as parameters it infers
+// to be of the domain type.
+//
+// The codec encodes and decodes identifiers directly from the wire bytes, in
+// both the text and binary formats (which are identical for text types), so
+// no intermediate strings are allocated per row.
+package pgxpin
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// DomainSQL returns the statement creating a Postgres domain named name
+// that holds PIN strings.
+func DomainSQL(name string) string {
+	return "CREATE DOMAIN " + pgx.Identifier{name}.Sanitize() +
+		" AS varchar(3) CHECK (VALUE ~ '" + pin.SQLPattern + "')"
+}
+
+// Register registers the codec in m for the Postgres type with the given
+// name and OID, and makes it the default type for pin.Identifier values.
+func Register(m *pgtype.Map, name string, oid uint32) {
+	m.RegisterType(&pgtype.Type{Name: name, OID: oid, Codec: Codec{}})
+	m.RegisterDefaultPgType(pin.Identifier{}, name)
+}
+
+// LoadAndRegister looks up the domain (or other text-like type) named name
+// and registers the codec for it on conn, along with its array type so that
+// []pin.Identifier values map onto name[] columns.
+func LoadAndRegister(ctx context.Context, conn *pgx.Conn, name string) error {
+	t, err := conn.LoadType(ctx, name)
+	if err != nil {
+		return fmt.Errorf("pgxpin: load type %q: %w", name, err)
+	}
+	Register(conn.TypeMap(), name, t.OID)
+
+	arrayName := "_" + name
+	at, err := conn.LoadType(ctx, arrayName)
+	if err != nil {
+		return fmt.Errorf("pgxpin: load type %q: %w", arrayName, err)
+	}
+	conn.TypeMap().RegisterType(at)
+	conn.TypeMap().RegisterDefaultPgType([]pin.Identifier{}, arrayName)
+
+	return nil
+}
+
+// ============================================================================
+// Codec
+// ============================================================================
+
+// Codec is a pgtype.Codec for PIN identifiers stored in a text-like type.
+//
+// It encodes pin.Identifier, pin.NullIdentifier, and string values, and
+// scans into *pin.Identifier, *pin.NullIdentifier, and *string.
+type Codec struct{}
+
+// FormatSupported reports whether format is the text or binary format.
+func (Codec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || format == pgtype.BinaryFormatCode
+}
+
+// PreferredFormat returns the binary format.
+func (Codec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+// PlanEncode returns a plan for encoding value, or nil if unsupported.
+func (c Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if !c.FormatSupported(format) {
+		return nil
+	}
+
+	switch value.(type) {
+	case pin.Identifier:
+		return encodePlanIdentifier{}
+	case pin.NullIdentifier:
+		return encodePlanNullIdentifier{}
+	case string:
+		return encodePlanString{}
+	}
+
+	return nil
+}
+
+// PlanScan returns a plan for scanning into target, or nil if unsupported.
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if !c.FormatSupported(format) {
+		return nil
+	}
+
+	switch target.(type) {
+	case *pin.Identifier:
+		return scanPlanIdentifier{}
+	case *pin.NullIdentifier:
+		return scanPlanNullIdentifier{}
+	case *string:
+		return scanPlanString{}
+	}
+
+	return nil
+}
+
+// DecodeDatabaseSQLValue returns src as a string, for database/sql.
+func (Codec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+	return string(src), nil
+}
+
+// DecodeValue returns src as a pin.Identifier, or nil for NULL.
+func (Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var id pin.Identifier
+	if err := id.UnmarshalText(src); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// ============================================================================
+// Encode Plans
+// ============================================================================
+
+type encodePlanIdentifier struct{}
+
+func (encodePlanIdentifier) Encode(value any, buf []byte) ([]byte, error) {
+	return value.(pin.Identifier).AppendText(buf)
+}
+
+type encodePlanNullIdentifier struct{}
+
+func (encodePlanNullIdentifier) Encode(value any, buf []byte) ([]byte, error) {
+	n := value.(pin.NullIdentifier)
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Identifier.AppendText(buf)
+}
+
+type encodePlanString struct{}
+
+func (encodePlanString) Encode(value any, buf []byte) ([]byte, error) {
+	s := value.(string)
+	if err := pin.Validate(s); err != nil {
+		return nil, err
+	}
+	return append(buf, s...), nil
+}
+
+// ============================================================================
+// Scan Plans
+// ============================================================================
+
+type scanPlanIdentifier struct{}
+
+func (scanPlanIdentifier) Scan(src []byte, target any) error {
+	if src == nil {
+		return pin.ErrNullValue
+	}
+	return target.(*pin.Identifier).UnmarshalText(src)
+}
+
+type scanPlanNullIdentifier struct{}
+
+func (scanPlanNullIdentifier) Scan(src []byte, target any) error {
+	n := target.(*pin.NullIdentifier)
+	if src == nil {
+		*n = pin.NullIdentifier{}
+		return nil
+	}
+
+	if err := n.Identifier.UnmarshalText(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+type scanPlanString struct{}
+
+func (scanPlanString) Scan(src []byte, target any) error {
+	if src == nil {
+		return pin.ErrNullValue
+	}
+	*target.(*string) = string(src)
+	return nil
+}
@@ -0,0 +1,200 @@
+package pgxpin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/sashite/pin.go/v3"
+)
+
+// Arbitrary OIDs standing in for a domain and its array type.
+const (
+	testOID      = 100000
+	testArrayOID = 100001
+)
+
+// newMap returns a type map with the codec registered for testOID.
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m, "pin", testOID)
+
+	elem, _ := m.TypeForOID(testOID)
+	m.RegisterType(&pgtype.Type{Name: "_pin", OID: testArrayOID, Codec: &pgtype.ArrayCodec{ElementType: elem}})
+	return m
+}
+
+var formats = []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode}
+
+// ============================================================================
+// DomainSQL Tests
+// ============================================================================
+
+func TestDomainSQL(t *testing.T) {
+	want := `CREATE DOMAIN "pin" AS varchar(3) CHECK (VALUE ~ '^[-+]?[A-Za-z]\^?$')`
+	if got := DomainSQL("pin"); got != want {
+		t.Errorf("DomainSQL(pin) = %q, want %q", got, want)
+	}
+}
+
+// ============================================================================
+// Encode Tests
+// ============================================================================
+
+func TestEncode(t *testing.T) {
+	m := newMap()
+	id := pin.MustParse("+k^")
+
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{id, "+k^"},
+		{&id, "+k^"},
+		{pin.NullIdentifier{Identifier: id, Valid: true}, "+k^"},
+		{"-P", "-P"},
+	}
+
+	for _, format := range formats {
+		for _, tt := range tests {
+			got, err := m.Encode(testOID, format, tt.value, nil)
+			if err != nil {
+				t.Errorf("Encode(%v, format %d) error = %v", tt.value, format, err)
+				continue
+			}
+			if string(got) != tt.want {
+				t.Errorf("Encode(%v, format %d) = %q, want %q", tt.value, format, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestEncodeNull(t *testing.T) {
+	m := newMap()
+
+	for _, value := range []any{pin.NullIdentifier{}, (*pin.Identifier)(nil)} {
+		got, err := m.Encode(testOID, pgtype.BinaryFormatCode, value, nil)
+		if err != nil || got != nil {
+			t.Errorf("Encode(%#v) = %q, %v, want NULL", value, got, err)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	m := newMap()
+
+	if _, err := m.Encode(testOID, pgtype.BinaryFormatCode, pin.Identifier{}, nil); !errors.Is(err, pin.ErrInvalidIdentifier) {
+		t.Errorf("Encode(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+	if _, err := m.Encode(testOID, pgtype.BinaryFormatCode, "K+", nil); !errors.Is(err, pin.ErrInvalidTerminalMarker) {
+		t.Errorf("Encode(K+) error = %v, want ErrInvalidTerminalMarker", err)
+	}
+}
+
+// ============================================================================
+// Scan Tests
+// ============================================================================
+
+func TestScan(t *testing.T) {
+	m := newMap()
+
+	for _, format := range formats {
+		var id pin.Identifier
+		if err := m.Scan(testOID, format, []byte("-r^"), &id); err != nil {
+			t.Errorf("Scan(-r^, format %d) error = %v", format, err)
+		} else if id.String() != "-r^" {
+			t.Errorf("Scan(-r^, format %d) = %s", format, id)
+		}
+
+		var n pin.NullIdentifier
+		if err := m.Scan(testOID, format, []byte("K"), &n); err != nil || !n.Valid || n.Identifier.String() != "K" {
+			t.Errorf("Scan(K, format %d) into NullIdentifier = %+v, %v", format, n, err)
+		}
+
+		var s string
+		if err := m.Scan(testOID, format, []byte("k"), &s); err != nil || s != "k" {
+			t.Errorf("Scan(k, format %d) into string = %q, %v", format, s, err)
+		}
+	}
+}
+
+func TestScanNull(t *testing.T) {
+	m := newMap()
+
+	var id pin.Identifier
+	if err := m.Scan(testOID, pgtype.BinaryFormatCode, nil, &id); !errors.Is(err, pin.ErrNullValue) {
+		t.Errorf("Scan(NULL) into Identifier error = %v, want ErrNullValue", err)
+	}
+
+	n := pin.NullIdentifier{Identifier: pin.MustParse("K"), Valid: true}
+	if err := m.Scan(testOID, pgtype.BinaryFormatCode, nil, &n); err != nil || n.Valid {
+		t.Errorf("Scan(NULL) into NullIdentifier = %+v, %v, want invalid", n, err)
+	}
+
+	var p *pin.Identifier
+	if err := m.Scan(testOID, pgtype.BinaryFormatCode, nil, &p); err != nil || p != nil {
+		t.Errorf("Scan(NULL) into *Identifier = %v, %v, want nil", p, err)
+	}
+}
+
+func TestScanInvalid(t *testing.T) {
+	m := newMap()
+
+	var id pin.Identifier
+	if err := m.Scan(testOID, pgtype.TextFormatCode, []byte("KKKK"), &id); !errors.Is(err, pin.ErrInputTooLong) {
+		t.Errorf("Scan(KKKK) error = %v, want ErrInputTooLong", err)
+	}
+}
+
+// ============================================================================
+// Decode Tests
+// ============================================================================
+
+func TestDecodeValue(t *testing.T) {
+	c := Codec{}
+
+	v, err := c.DecodeValue(nil, testOID, pgtype.BinaryFormatCode, []byte("+P"))
+	if err != nil || v != pin.MustParse("+P") {
+		t.Errorf("DecodeValue(+P) = %v, %v, want +P", v, err)
+	}
+
+	v, err = c.DecodeValue(nil, testOID, pgtype.BinaryFormatCode, nil)
+	if err != nil || v != nil {
+		t.Errorf("DecodeValue(NULL) = %v, %v, want nil", v, err)
+	}
+
+	v, err = c.DecodeDatabaseSQLValue(nil, testOID, pgtype.TextFormatCode, []byte("+P"))
+	if err != nil || v != "+P" {
+		t.Errorf("DecodeDatabaseSQLValue(+P) = %v, %v, want \"+P\"", v, err)
+	}
+}
+
+// ============================================================================
+// Array Tests
+// ============================================================================
+
+func TestArrayRoundTrip(t *testing.T) {
+	m := newMap()
+	in := []pin.Identifier{pin.MustParse("K"), pin.MustParse("+p^"), pin.MustParse("-r")}
+
+	for _, format := range formats {
+		buf, err := m.Encode(testArrayOID, format, in, nil)
+		if err != nil {
+			t.Fatalf("Encode(array, format %d) error = %v", format, err)
+		}
+
+		var out []pin.Identifier
+		if err := m.Scan(testArrayOID, format, buf, &out); err != nil {
+			t.Fatalf("Scan(array, format %d) error = %v", format, err)
+		}
+		if len(out) != len(in) {
+			t.Fatalf("Scan(array, format %d) = %v, want %v", format, out, in)
+		}
+		for i := range in {
+			if out[i] != in[i] {
+				t.Errorf("Scan(array, format %d)[%d] = %s, want %s", format, i, out[i], in[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package pin
+
+// Profile describes the subset of piece name abbreviations used by a game,
+// and optionally its promotion rules and board dimensions.
+//
+// PIN itself accepts any letter A-Z; a Profile narrows that alphabet so
+// tooling can reject identifiers that are syntactically valid but foreign
+// to a given game. A nil *Profile imposes no restriction.
+//
+// Profiles are immutable once built; WithPromotion, WithPiece, and
+// WithBoardSize return a modified copy. Each copy holds the full promotion,
+// name, and glyph tables, about 6 KB, so profiles are best built once, as
+// package-level variables, rather than in hot paths.
+type Profile struct {
+	name       string
+	abbrs      uint32              // bit i set when 'A'+i is allowed
+	promotions [26 * 3][]pieceKind // targets, by source kind
+	demotable  [26 * 3]bool        // kinds reached by a state-changing promotion
+	names      [26 * 3]string      // piece names, by kind
+	glyphs     [2][26 * 3]string   // piece glyphs, by side and kind
+	rows, cols int                 // board dimensions, zero if unspecified
+}
+
+// allAbbrs is the abbreviation mask allowing every letter from A to Z.
+const allAbbrs = 1<<26 - 1
+
+// clone returns a copy of the Profile for the With builders. A nil Profile
+// is copied as an unnamed profile allowing every letter, so that builders
+// keep its absence of restriction.
+func (p *Profile) clone() *Profile {
+	if p == nil {
+		return &Profile{abbrs: allAbbrs}
+	}
+	cp := *p
+	return &cp
+}
+
+// pieceKind is the side-independent part of an Identifier relevant to promotion.
+type pieceKind struct {
+	abbr  rune
+	state State
+}
+
+// slot returns the position of the kind in the promotion tables.
+func (k pieceKind) slot() int {
+	return int(k.abbr-'A')*3 + int(k.state)
+}
+
+// kindOf returns the kind of a valid Identifier.
+func kindOf(id Identifier) pieceKind {
+	return pieceKind{abbr: id.Abbr(), state: id.State()}
+}
+
+// Built-in profiles.
+var (
+	// ChessProfile is the profile of Western chess: pawns promote to a queen,
+	// rook, bishop, or knight.
+	ChessProfile = NewProfile("chess", "KQRBNP").
+			WithBoardSize(8, 8).
+			WithPromotion("P", "Q", "R", "B", "N").
+			WithPiece("K", "King", "♔", "♚").
+			WithPiece("Q", "Queen", "♕", "♛").
+			WithPiece("R", "Rook", "♖", "♜").
+			WithPiece("B", "Bishop", "♗", "♝").
+			WithPiece("N", "Knight", "♘", "♞").
+			WithPiece("P", "Pawn", "♙", "♟")
+
+	// ShogiProfile is the profile of shogi: rooks, bishops, silvers, knights,
+	// lances, and pawns promote to their Enhanced form.
+	ShogiProfile = NewProfile("shogi", "KRBGSNLP").
+			WithBoardSize(9, 9).
+			WithPromotion("R", "+R").
+			WithPromotion("B", "+B").
+			WithPromotion("S", "+S").
+			WithPromotion("N", "+N").
+			WithPromotion("L", "+L").
+			WithPromotion("P", "+P").
+			WithPiece("K", "King", "玉", "玉").
+			WithPiece("R", "Rook", "飛", "飛").
+			WithPiece("+R", "Dragon King", "龍", "龍").
+			WithPiece("B", "Bishop", "角", "角").
+			WithPiece("+B", "Dragon Horse", "馬", "馬").
+			WithPiece("G", "Gold General", "金", "金").
+			WithPiece("S", "Silver General", "銀", "銀").
+			WithPiece("+S", "Promoted Silver", "全", "全").
+			WithPiece("N", "Knight", "桂", "桂").
+			WithPiece("+N", "Promoted Knight", "圭", "圭").
+			WithPiece("L", "Lance", "香", "香").
+			WithPiece("+L", "Promoted Lance", "杏", "杏").
+			WithPiece("P", "Pawn", "歩", "歩").
+			WithPiece("+P", "Tokin", "と", "と")
+
+	// XiangqiProfile is the profile of xiangqi, Chinese chess, in the letters
+	// of FEEN: general, advisor, elephant, horse, chariot, cannon, and
+	// soldier. No piece promotes; the first side is red.
+	XiangqiProfile = NewProfile("xiangqi", "GAEHRCS").
+			WithBoardSize(10, 9).
+			WithPiece("G", "General", "帥", "將").
+			WithPiece("A", "Advisor", "仕", "士").
+			WithPiece("E", "Elephant", "相", "象").
+			WithPiece("H", "Horse", "傌", "馬").
+			WithPiece("R", "Chariot", "俥", "車").
+			WithPiece("C", "Cannon", "炮", "砲").
+			WithPiece("S", "Soldier", "兵", "卒")
+)
+
+// NewProfile creates a Profile allowing the abbreviations listed in abbrs.
+//
+// Letters may be given in either case; they are normalized to uppercase.
+// Duplicate letters are ignored.
+//
+// Panics if abbrs contains a character that is not a letter A-Z.
+func NewProfile(name string, abbrs string) *Profile {
+	p := &Profile{name: name}
+
+	for i := 0; i < len(abbrs); i++ {
+		abbr := rune(abbrs[i])
+
+		// Normalize to uppercase
+		if abbr >= 'a' && abbr <= 'z' {
+			abbr = abbr - 'a' + 'A'
+		}
+
+		if !isValidAbbr(abbr) {
+			panic(ErrInvalidAbbr)
+		}
+
+		p.abbrs |= 1 << (abbr - 'A')
+	}
+
+	return p
+}
+
+// Name returns the name of the Profile, or "" for a nil Profile.
+func (p *Profile) Name() string {
+	if p == nil {
+		return ""
+	}
+	return p.name
+}
+
+// AllowsAbbr reports whether the abbreviation is part of the Profile.
+// The abbreviation is matched case-insensitively.
+func (p *Profile) AllowsAbbr(abbr rune) bool {
+	if p == nil {
+		return true
+	}
+
+	// Normalize to uppercase
+	if abbr >= 'a' && abbr <= 'z' {
+		abbr = abbr - 'a' + 'A'
+	}
+
+	if !isValidAbbr(abbr) {
+		return false
+	}
+
+	return p.abbrs&(1<<(abbr-'A')) != 0
+}
+
+// Allows reports whether the Identifier's abbreviation is part of the Profile.
+func (p *Profile) Allows(id Identifier) bool {
+	return p.AllowsAbbr(id.Abbr())
+}
+
+// Abbrs returns the allowed abbreviations in alphabetical order. A nil
+// Profile allows every letter from A to Z.
+func (p *Profile) Abbrs() string {
+	if p == nil {
+		return "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	}
+
+	buf := make([]byte, 0, 26)
+	for i := 0; i < 26; i++ {
+		if p.abbrs&(1<<i) != 0 {
+			buf = append(buf, byte('A'+i))
+		}
+	}
+	return string(buf)
+}
+
+// Identifiers returns the set of identifiers whose abbreviation is part of
+// the Profile, for both sides and every state and terminal status. A nil
+// Profile returns all 312 identifiers.
+func (p *Profile) Identifiers() Set {
+	if p == nil {
+		return fullSet
+	}
+
+	var s Set
+	for i := 0; i < identifierCount; i++ {
+		if id := fromIndex(i); p.Allows(id) {
+			s.Add(id)
+		}
+	}
+	return s
+}
+
+// Check returns nil if id is allowed by the Profile, or ErrAbbrNotInProfile.
+func (p *Profile) Check(id Identifier) error {
+	if !p.Allows(id) {
+		return ErrAbbrNotInProfile
+	}
+	return nil
+}
+
+// ============================================================================
+// Promotion Rules
+// ============================================================================
+
+// WithPromotion returns a copy of the Profile declaring that pieces matching
+// from may promote to each of targets.
+//
+// from and targets are PIN strings; only their abbreviation and state are
+// significant. A promoted piece keeps the side and terminal status of the
+// promoting piece.
+//
+// Example:
+//
+//	NewProfile("shogi", "KRBGSNLP").WithPromotion("P", "+P")
+//	NewProfile("chess", "KQRBNP").WithPromotion("P", "Q", "R", "B", "N")
+//
+// On a nil Profile, the With builders start from an unnamed profile allowing
+// every letter.
+//
+// Panics if a PIN string is invalid or its abbreviation is not allowed by
+// the Profile.
+func (p *Profile) WithPromotion(from string, targets ...string) *Profile {
+	src := p.mustKind(from)
+
+	cp := p.clone()
+	rules := append([]pieceKind(nil), cp.promotions[src.slot()]...)
+	for _, t := range targets {
+		dst := p.mustKind(t)
+		rules = append(rules, dst)
+
+		// A promotion recorded in the state can be reverted from the notation
+		if dst.state != src.state {
+			cp.demotable[dst.slot()] = true
+		}
+	}
+	cp.promotions[src.slot()] = rules
+
+	return cp
+}
+
+// mustKind parses s and returns its kind, panicking with the parsing error
+// or ErrAbbrNotInProfile.
+func (p *Profile) mustKind(s string) pieceKind {
+	id, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	if !p.Allows(id) {
+		panic(ErrAbbrNotInProfile)
+	}
+	return kindOf(id)
+}
+
+// IsPromotable reports whether id may promote under the Profile.
+// It is always false for a nil Profile.
+func (p *Profile) IsPromotable(id Identifier) bool {
+	if p == nil || !id.isValid() {
+		return false
+	}
+	return len(p.promotions[kindOf(id).slot()]) > 0
+}
+
+// PromotionTargets returns the Identifiers id may promote to under the
+// Profile, in declaration order, or nil if it cannot promote.
+func (p *Profile) PromotionTargets(id Identifier) []Identifier {
+	if !p.IsPromotable(id) {
+		return nil
+	}
+
+	rules := p.promotions[kindOf(id).slot()]
+	out := make([]Identifier, len(rules))
+	for i, k := range rules {
+		out[i] = makeIdentifier(k.abbr, id.Side(), k.state, id.IsTerminal())
+	}
+	return out
+}
+
+// IsDemotable reports whether id is a promoted piece that can be demoted
+// under the Profile.
+//
+// Only promotions that change the state (such as shogi "P" to "+P") can be
+// reverted: when a promotion only changes the abbreviation (such as chess
+// "P" to "Q"), a promoted piece is indistinguishable from an original one.
+// It is always false for a nil Profile.
+func (p *Profile) IsDemotable(id Identifier) bool {
+	if p == nil || !id.isValid() {
+		return false
+	}
+	return p.demotable[kindOf(id).slot()]
+}
+
+// ============================================================================
+// Piece Names
+// ============================================================================
+
+// WithPiece returns a copy of the Profile giving a name and glyphs to the
+// pieces matching kind.
+//
+// kind is a PIN string; only its abbreviation and state are significant.
+// firstGlyph and secondGlyph are the glyphs of the First and Second player's
+// pieces, and may be equal or empty.
+//
+// Panics if kind is invalid or its abbreviation is not allowed by the Profile.
+func (p *Profile) WithPiece(kind, name, firstGlyph, secondGlyph string) *Profile {
+	k := p.mustKind(kind)
+
+	cp := p.clone()
+	cp.names[k.slot()] = name
+	cp.glyphs[First][k.slot()] = firstGlyph
+	cp.glyphs[Second][k.slot()] = secondGlyph
+
+	return cp
+}
+
+// PieceName returns the name of the piece under the Profile, such as "King",
+// or "" if it has none. It is always "" for a nil Profile.
+func (p *Profile) PieceName(id Identifier) string {
+	if p == nil || !id.isValid() {
+		return ""
+	}
+	return p.names[kindOf(id).slot()]
+}
+
+// Glyph returns the glyph of the piece under the Profile, such as "♔", or
+// "" if it has none. It is always "" for a nil Profile.
+func (p *Profile) Glyph(id Identifier) string {
+	if p == nil || !id.isValid() {
+		return ""
+	}
+	return p.glyphs[id.Side()][kindOf(id).slot()]
+}
+
+// ============================================================================
+// Board Dimensions
+// ============================================================================
+
+// WithBoardSize returns a copy of the Profile declaring the dimensions of
+// the game's board, as for NewBoard.
+//
+// Panics with ErrInvalidSquare if rows or cols is not positive, or if the
+// board has more than 4096 squares.
+func (p *Profile) WithBoardSize(rows, cols int) *Profile {
+	if rows <= 0 || cols <= 0 || rows > maxPlacementSquares/cols {
+		panic(ErrInvalidSquare)
+	}
+
+	cp := p.clone()
+	cp.rows, cp.cols = rows, cols
+	return cp
+}
+
+// BoardSize returns the dimensions of the game's board, or zeros if the
+// Profile does not declare them. It is always zeros for a nil Profile.
+func (p *Profile) BoardSize() (rows, cols int) {
+	if p == nil {
+		return 0, 0
+	}
+	return p.rows, p.cols
+}
+
+// NewBoard returns an empty board of the game's dimensions.
+//
+// Panics with ErrInvalidSquare if the Profile does not declare them.
+func (p *Profile) NewBoard() *Board {
+	return NewBoard(p.BoardSize())
+}
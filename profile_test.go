@@ -0,0 +1,382 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+// ============================================================================
+// Constructor Tests
+// ============================================================================
+
+func TestNewProfile(t *testing.T) {
+	p := NewProfile("chess", "KQRBNP")
+
+	if p.Name() != "chess" {
+		t.Errorf("Name() = %q, want \"chess\"", p.Name())
+	}
+	if p.Abbrs() != "BKNPQR" {
+		t.Errorf("Abbrs() = %q, want \"BKNPQR\"", p.Abbrs())
+	}
+}
+
+func TestNewProfileNormalizesLowercase(t *testing.T) {
+	p := NewProfile("mixed", "kQk")
+
+	if p.Abbrs() != "KQ" {
+		t.Errorf("Abbrs() = %q, want \"KQ\"", p.Abbrs())
+	}
+}
+
+func TestNewProfilePanicsOnInvalidAbbr(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for invalid abbr")
+		}
+	}()
+
+	NewProfile("invalid", "K1")
+}
+
+// ============================================================================
+// Query Tests
+// ============================================================================
+
+func TestProfileAllows(t *testing.T) {
+	p := NewProfile("chess", "KQRBNP")
+
+	tests := []struct {
+		id   Identifier
+		want bool
+	}{
+		{MustParse("K"), true},
+		{MustParse("+p^"), true},
+		{MustParse("S"), false},
+		{MustParse("g"), false},
+	}
+
+	for _, tt := range tests {
+		got := p.Allows(tt.id)
+		if got != tt.want {
+			t.Errorf("Allows(%s) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestProfileAllowsAbbr(t *testing.T) {
+	p := NewProfile("chess", "KQRBNP")
+
+	if !p.AllowsAbbr('k') {
+		t.Error("AllowsAbbr('k') = false, want true")
+	}
+	if p.AllowsAbbr('1') {
+		t.Error("AllowsAbbr('1') = true, want false")
+	}
+}
+
+func TestNilProfileAllowsEverything(t *testing.T) {
+	var p *Profile
+
+	for r := 'A'; r <= 'Z'; r++ {
+		if !p.AllowsAbbr(r) {
+			t.Errorf("nil Profile AllowsAbbr(%q) = false, want true", r)
+		}
+	}
+	if err := p.Check(MustParse("z")); err != nil {
+		t.Errorf("nil Profile Check() = %v, want nil", err)
+	}
+	if got := p.Name(); got != "" {
+		t.Errorf("nil Profile Name() = %q, want empty", got)
+	}
+	if got := p.Abbrs(); got != "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+		t.Errorf("nil Profile Abbrs() = %q, want A to Z", got)
+	}
+}
+
+func TestNilProfileBuilders(t *testing.T) {
+	var p *Profile
+
+	sized := p.WithBoardSize(9, 9)
+	if rows, cols := sized.BoardSize(); rows != 9 || cols != 9 {
+		t.Errorf("nil Profile WithBoardSize(9, 9).BoardSize() = %d, %d", rows, cols)
+	}
+	if sized.Name() != "" || sized.Abbrs() != p.Abbrs() {
+		t.Errorf("nil Profile WithBoardSize() = %q %q, want unnamed with every letter", sized.Name(), sized.Abbrs())
+	}
+
+	if got := p.WithPromotion("P", "+P").PromotionTargets(MustParse("p")); len(got) != 1 || got[0] != MustParse("+p") {
+		t.Errorf("nil Profile WithPromotion(P, +P) targets = %v, want [+p]", got)
+	}
+	if got := p.WithPiece("Z", "Zebra", "Z", "z").PieceName(MustParse("z")); got != "Zebra" {
+		t.Errorf("nil Profile WithPiece(Z) name = %q, want Zebra", got)
+	}
+	if p != nil {
+		t.Error("builders modified the nil Profile")
+	}
+}
+
+func TestProfileIdentifiers(t *testing.T) {
+	s := ChessProfile.Identifiers()
+
+	// 6 abbreviations × 2 sides × 3 states × 2 terminal statuses
+	if s.Len() != 72 {
+		t.Errorf("Identifiers().Len() = %d, want 72", s.Len())
+	}
+	s.Range(func(id Identifier) bool {
+		if !ChessProfile.Allows(id) {
+			t.Errorf("Identifiers() contains %v", id)
+		}
+		return true
+	})
+
+	var p *Profile
+	if got := p.Identifiers().Len(); got != identifierCount {
+		t.Errorf("nil Profile Identifiers().Len() = %d, want %d", got, identifierCount)
+	}
+}
+
+func TestProfileCheck(t *testing.T) {
+	p := NewProfile("chess", "KQRBNP")
+
+	if err := p.Check(MustParse("Q")); err != nil {
+		t.Errorf("Check(Q) = %v, want nil", err)
+	}
+	if err := p.Check(MustParse("S")); !errors.Is(err, ErrAbbrNotInProfile) {
+		t.Errorf("Check(S) = %v, want ErrAbbrNotInProfile", err)
+	}
+}
+
+// ============================================================================
+// Promotion Rules Tests
+// ============================================================================
+
+func TestProfileIsPromotable(t *testing.T) {
+	tests := []struct {
+		profile *Profile
+		pin     string
+		want    bool
+	}{
+		{ChessProfile, "P", true},
+		{ChessProfile, "p^", true},
+		{ChessProfile, "Q", false},
+		{ChessProfile, "K^", false},
+		{ShogiProfile, "p", true},
+		{ShogiProfile, "+P", false},
+		{ShogiProfile, "G", false},
+		{ShogiProfile, "K^", false},
+		{nil, "P", false},
+	}
+
+	for _, tt := range tests {
+		got := tt.profile.IsPromotable(MustParse(tt.pin))
+		if got != tt.want {
+			t.Errorf("%s.IsPromotable(%s) = %v, want %v", tt.profile.Name(), tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestProfilePromotionTargets(t *testing.T) {
+	tests := []struct {
+		profile *Profile
+		pin     string
+		want    []string
+	}{
+		{ChessProfile, "P", []string{"Q", "R", "B", "N"}},
+		{ChessProfile, "p", []string{"q", "r", "b", "n"}},
+		{ShogiProfile, "s", []string{"+s"}},
+		{ShogiProfile, "L", []string{"+L"}},
+		{ShogiProfile, "G", nil},
+		{NewProfile("test", "PQ").WithPromotion("P^", "+Q"), "p^", []string{"+q^"}},
+	}
+
+	for _, tt := range tests {
+		got := tt.profile.PromotionTargets(MustParse(tt.pin))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s.PromotionTargets(%s) = %v, want %v", tt.profile.Name(), tt.pin, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].String() != tt.want[i] {
+				t.Errorf("%s.PromotionTargets(%s)[%d] = %s, want %s", tt.profile.Name(), tt.pin, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestProfileIsDemotable(t *testing.T) {
+	tests := []struct {
+		profile *Profile
+		pin     string
+		want    bool
+	}{
+		{ShogiProfile, "+P", true},
+		{ShogiProfile, "+r", true},
+		{ShogiProfile, "P", false},
+		{ShogiProfile, "+G", false},
+		{ChessProfile, "Q", false},
+		{ChessProfile, "P", false},
+		{nil, "+P", false},
+	}
+
+	for _, tt := range tests {
+		got := tt.profile.IsDemotable(MustParse(tt.pin))
+		if got != tt.want {
+			t.Errorf("%s.IsDemotable(%s) = %v, want %v", tt.profile.Name(), tt.pin, got, tt.want)
+		}
+	}
+}
+
+func TestProfileWithPromotionReturnsCopy(t *testing.T) {
+	base := NewProfile("test", "PQR")
+	withQ := base.WithPromotion("P", "Q")
+	withR := withQ.WithPromotion("P", "R")
+
+	if base.IsPromotable(MustParse("P")) {
+		t.Error("base profile was modified by WithPromotion")
+	}
+	if got := withQ.PromotionTargets(MustParse("P")); len(got) != 1 {
+		t.Errorf("withQ.PromotionTargets(P) = %v, want [Q]", got)
+	}
+	if got := withR.PromotionTargets(MustParse("P")); len(got) != 2 {
+		t.Errorf("withR.PromotionTargets(P) = %v, want [Q R]", got)
+	}
+	if withR.Name() != "test" || withR.Abbrs() != "PQR" {
+		t.Errorf("WithPromotion() = %s %s, want test PQR", withR.Name(), withR.Abbrs())
+	}
+}
+
+func TestProfileWithPromotionPanics(t *testing.T) {
+	tests := []struct {
+		from    string
+		targets []string
+		want    error
+	}{
+		{"X", []string{"Q"}, ErrAbbrNotInProfile},
+		{"P", []string{"X"}, ErrAbbrNotInProfile},
+		{"P", []string{""}, ErrEmptyInput},
+		{"P+", []string{"Q"}, ErrInvalidTerminalMarker},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				r := recover()
+				if err, ok := r.(error); !ok || !errors.Is(err, tt.want) {
+					t.Errorf("WithPromotion(%q, %q) panic = %v, want %v", tt.from, tt.targets, r, tt.want)
+				}
+			}()
+			NewProfile("test", "PQ").WithPromotion(tt.from, tt.targets...)
+		}()
+	}
+}
+
+// ============================================================================
+// Piece Names Tests
+// ============================================================================
+
+func TestProfilePieceNameAndGlyph(t *testing.T) {
+	tests := []struct {
+		profile   *Profile
+		pin       string
+		wantName  string
+		wantGlyph string
+	}{
+		{ChessProfile, "K^", "King", "♔"},
+		{ChessProfile, "k^", "King", "♚"},
+		{ChessProfile, "p", "Pawn", "♟"},
+		{ChessProfile, "+P", "", ""},
+		{ShogiProfile, "+p", "Tokin", "と"},
+		{ShogiProfile, "G", "Gold General", "金"},
+		{ShogiProfile, "+G", "", ""},
+		{nil, "K", "", ""},
+	}
+
+	for _, tt := range tests {
+		id := MustParse(tt.pin)
+		if got := tt.profile.PieceName(id); got != tt.wantName {
+			t.Errorf("%s.PieceName(%s) = %q, want %q", tt.profile.Name(), tt.pin, got, tt.wantName)
+		}
+		if got := tt.profile.Glyph(id); got != tt.wantGlyph {
+			t.Errorf("%s.Glyph(%s) = %q, want %q", tt.profile.Name(), tt.pin, got, tt.wantGlyph)
+		}
+	}
+}
+
+func TestProfileWithPieceReturnsCopy(t *testing.T) {
+	base := NewProfile("test", "K")
+	named := base.WithPiece("K", "King", "K", "k")
+
+	if got := base.PieceName(MustParse("K")); got != "" {
+		t.Errorf("base profile PieceName(K) = %q, want \"\"", got)
+	}
+	if got := named.PieceName(MustParse("K")); got != "King" {
+		t.Errorf("PieceName(K) = %q, want King", got)
+	}
+}
+
+func TestProfileWithPiecePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrAbbrNotInProfile {
+			t.Errorf("WithPiece(Q) panic = %v, want ErrAbbrNotInProfile", r)
+		}
+	}()
+	NewProfile("test", "K").WithPiece("Q", "Queen", "", "")
+}
+
+func TestProfileBoardSize(t *testing.T) {
+	tests := []struct {
+		profile    *Profile
+		rows, cols int
+	}{
+		{ChessProfile, 8, 8},
+		{ShogiProfile, 9, 9},
+		{XiangqiProfile, 10, 9},
+		{NewProfile("test", "K"), 0, 0},
+		{nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if rows, cols := tt.profile.BoardSize(); rows != tt.rows || cols != tt.cols {
+			t.Errorf("%s.BoardSize() = %d, %d, want %d, %d", tt.profile.Name(), rows, cols, tt.rows, tt.cols)
+		}
+	}
+
+	b := XiangqiProfile.NewBoard()
+	if b.Rows() != 10 || b.Cols() != 9 {
+		t.Errorf("NewBoard() = %dx%d, want 10x9", b.Rows(), b.Cols())
+	}
+}
+
+func TestProfileWithBoardSizeReturnsCopy(t *testing.T) {
+	base := NewProfile("test", "K")
+	sized := base.WithBoardSize(5, 5)
+
+	if rows, _ := base.BoardSize(); rows != 0 {
+		t.Errorf("base profile BoardSize() rows = %d, want 0", rows)
+	}
+	if rows, cols := sized.BoardSize(); rows != 5 || cols != 5 {
+		t.Errorf("BoardSize() = %d, %d, want 5, 5", rows, cols)
+	}
+}
+
+func TestProfileBoardSizePanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"WithBoardSize zero", func() { NewProfile("test", "K").WithBoardSize(0, 8) }},
+		{"WithBoardSize too large", func() { NewProfile("test", "K").WithBoardSize(65, 64) }},
+		{"NewBoard unsized", func() { NewProfile("test", "K").NewBoard() }},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrInvalidSquare {
+					t.Errorf("%s: panic = %v, want ErrInvalidSquare", tt.name, r)
+				}
+			}()
+			tt.fn()
+		}()
+	}
+}
@@ -0,0 +1,155 @@
+package pin
+
+import "strings"
+
+// QualifiedIdentifier is a PIN qualified by a style prefix, in the form of
+// the General Actor Notation: "C:K" is the king of the first player in the
+// chess style, "S:+p" a promoted pawn of the second player in the shogi
+// style. Qualifying pieces by style lets positions mixing the pieces of
+// several games, such as cross-game variants, be represented.
+//
+// The style is an ASCII letter followed by letters or digits. Its meaning is
+// outside the scope of PIN: it is kept as written and compared exactly. SNN
+// style names are valid styles; Style.Qualify and SNN convert between them.
+//
+// QualifiedIdentifier is an immutable value type, comparable with ==.
+type QualifiedIdentifier struct {
+	style string
+	id    Identifier
+}
+
+// styleSeparator separates the style from the PIN in a QualifiedIdentifier.
+const styleSeparator = ':'
+
+// NewQualifiedIdentifier creates a QualifiedIdentifier from a style and an
+// Identifier.
+//
+// Panics with ErrInvalidStyle if the style is not valid, or with
+// ErrInvalidIdentifier if id is not valid.
+func NewQualifiedIdentifier(style string, id Identifier) QualifiedIdentifier {
+	if styleError(style) >= 0 {
+		panic(ErrInvalidStyle)
+	}
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+	return QualifiedIdentifier{style: style, id: id}
+}
+
+// ParseQualified converts a qualified PIN string, such as "C:K" or "S:+p",
+// into a QualifiedIdentifier.
+//
+// Returns a *SyntaxError if the string is not valid, with offsets counted
+// from the start of s, wrapping one of:
+//   - ErrMissingStyle: no style separator ':'
+//   - ErrInvalidStyle: empty style or invalid byte in the style
+//   - the parsing errors of Parse, for the part after the separator
+func ParseQualified(s string) (QualifiedIdentifier, error) {
+	sep := strings.IndexByte(s, styleSeparator)
+	if sep < 0 {
+		return QualifiedIdentifier{}, newSyntaxError(s, len(s), ErrMissingStyle)
+	}
+
+	style := s[:sep]
+	if i := styleError(style); i >= 0 {
+		return QualifiedIdentifier{}, newSyntaxError(s, i, ErrInvalidStyle)
+	}
+
+	id, err := Parse(s[sep+1:])
+	if err != nil {
+		// Locate the error in s rather than in its PIN part
+		se := err.(*SyntaxError)
+		return QualifiedIdentifier{}, newSyntaxError(s, sep+1+se.Offset, se.Err)
+	}
+
+	return QualifiedIdentifier{style: style, id: id}, nil
+}
+
+// MustParseQualified is like ParseQualified but panics on error.
+// Use for constants or trusted input.
+func MustParseQualified(s string) QualifiedIdentifier {
+	q, err := ParseQualified(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// styleError returns the offset of the first invalid byte of style, its
+// length if it is empty, or -1 if it is valid.
+func styleError(style string) int {
+	if style == "" {
+		return 0
+	}
+	for i := 0; i < len(style); i++ {
+		if !isStyleByte(style[i], i == 0) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isStyleByte reports whether b may appear in a style: a letter, or a digit
+// unless it comes first.
+func isStyleByte(b byte, first bool) bool {
+	switch {
+	case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z':
+		return true
+	case b >= '0' && b <= '9':
+		return !first
+	default:
+		return false
+	}
+}
+
+// Style returns the style qualifying the PIN, as written.
+func (q QualifiedIdentifier) Style() string {
+	return q.style
+}
+
+// Identifier returns the PIN embedded in the QualifiedIdentifier.
+func (q QualifiedIdentifier) Identifier() Identifier {
+	return q.id
+}
+
+// IsZero reports whether q is the zero value, which is not a valid
+// QualifiedIdentifier.
+func (q QualifiedIdentifier) IsZero() bool {
+	return q == QualifiedIdentifier{}
+}
+
+// String returns the qualified PIN string representation, such as "C:K".
+func (q QualifiedIdentifier) String() string {
+	return string(q.AppendTo(make([]byte, 0, len(q.style)+1+MaxStringLength)))
+}
+
+// AppendTo appends the qualified PIN string representation to dst and
+// returns the result.
+func (q QualifiedIdentifier) AppendTo(dst []byte) []byte {
+	dst = append(dst, q.style...)
+	dst = append(dst, styleSeparator)
+	return q.id.AppendTo(dst)
+}
+
+// MarshalText returns the qualified PIN string representation,
+// implementing encoding.TextMarshaler.
+//
+// Returns ErrInvalidIdentifier for the zero value.
+func (q QualifiedIdentifier) MarshalText() ([]byte, error) {
+	if q.IsZero() {
+		return nil, ErrInvalidIdentifier
+	}
+	return q.AppendTo(nil), nil
+}
+
+// UnmarshalText parses a qualified PIN string, implementing
+// encoding.TextUnmarshaler.
+func (q *QualifiedIdentifier) UnmarshalText(text []byte) error {
+	parsed, err := ParseQualified(string(text))
+	if err != nil {
+		return err
+	}
+
+	*q = parsed
+	return nil
+}
@@ -0,0 +1,147 @@
+package pin
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseQualified(t *testing.T) {
+	tests := []struct {
+		input string
+		style string
+		pin   string
+	}{
+		{"C:K", "C", "K"},
+		{"S:+p", "S", "+p"},
+		{"c:-r^", "c", "-r^"},
+		{"CHESS:Q", "CHESS", "Q"},
+		{"Shogi9:+B^", "Shogi9", "+B^"},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQualified(tt.input)
+		if err != nil {
+			t.Errorf("ParseQualified(%q) error = %v", tt.input, err)
+			continue
+		}
+		if q.Style() != tt.style || q.Identifier() != MustParse(tt.pin) {
+			t.Errorf("ParseQualified(%q) = %q, %v, want %q, %s", tt.input, q.Style(), q.Identifier(), tt.style, tt.pin)
+		}
+		if q.String() != tt.input {
+			t.Errorf("ParseQualified(%q).String() = %q", tt.input, q.String())
+		}
+	}
+}
+
+func TestParseQualifiedErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		err    error
+	}{
+		{"", 0, ErrMissingStyle},
+		{"K", 1, ErrMissingStyle},
+		{":K", 0, ErrInvalidStyle},
+		{"9:K", 0, ErrInvalidStyle},
+		{"C!:K", 1, ErrInvalidStyle},
+		{"C:", 2, ErrEmptyInput},
+		{"C:K+", 3, ErrInvalidTerminalMarker},
+		{"CH:*k", 3, ErrInvalidStateModifier},
+		{"C:+K^^", 5, ErrInputTooLong},
+		{"C:K:K", 3, ErrInvalidTerminalMarker},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseQualified(tt.input)
+
+		var se *SyntaxError
+		if !errors.As(err, &se) || !errors.Is(err, tt.err) {
+			t.Errorf("ParseQualified(%q) error = %v, want *SyntaxError wrapping %v", tt.input, err, tt.err)
+			continue
+		}
+		if se.Input != tt.input || se.Offset != tt.offset {
+			t.Errorf("ParseQualified(%q) error at %q offset %d, want offset %d", tt.input, se.Input, se.Offset, tt.offset)
+		}
+	}
+}
+
+func TestNewQualifiedIdentifier(t *testing.T) {
+	q := NewQualifiedIdentifier("S", MustParse("+p"))
+	if q != MustParseQualified("S:+p") {
+		t.Errorf("NewQualifiedIdentifier() = %v, want S:+p", q)
+	}
+
+	tests := []struct {
+		style string
+		id    Identifier
+		want  error
+	}{
+		{"", MustParse("K"), ErrInvalidStyle},
+		{"C:", MustParse("K"), ErrInvalidStyle},
+		{"C", Identifier{}, ErrInvalidIdentifier},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("NewQualifiedIdentifier(%q, %v) panic = %v, want %v", tt.style, tt.id, r, tt.want)
+				}
+			}()
+			NewQualifiedIdentifier(tt.style, tt.id)
+		}()
+	}
+}
+
+func TestQualifiedIdentifierZero(t *testing.T) {
+	var q QualifiedIdentifier
+	if !q.IsZero() || MustParseQualified("C:K").IsZero() {
+		t.Error("IsZero() reports wrongly")
+	}
+	if _, err := q.MarshalText(); err != ErrInvalidIdentifier {
+		t.Errorf("MarshalText() on zero value error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestQualifiedIdentifierJSON(t *testing.T) {
+	pieces := map[QualifiedIdentifier]int{
+		MustParseQualified("C:K"):  1,
+		MustParseQualified("S:+p"): 2,
+	}
+
+	data, err := json.Marshal(pieces)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"C:K":1,"S:+p":2}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var got map[QualifiedIdentifier]int
+	if err := json.Unmarshal(data, &got); err != nil || len(got) != 2 || got[MustParseQualified("S:+p")] != 2 {
+		t.Errorf("Unmarshal() = %v, %v", got, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"K":1}`), &got); !errors.Is(err, ErrMissingStyle) {
+		t.Errorf("Unmarshal() unqualified key error = %v, want ErrMissingStyle", err)
+	}
+}
+
+func TestQualifiedNotationRoundTrip(t *testing.T) {
+	for _, s := range []string{"C:K", "s:+p^", "XIANGQI:-G"} {
+		got, err := roundTrip(Parser[QualifiedIdentifier](ParseQualified), s)
+		if err != nil || got != s {
+			t.Errorf("roundTrip(%q) = %q, %v", s, got, err)
+		}
+	}
+}
+
+func TestMustParseQualifiedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseQualified(\"K\") did not panic")
+		}
+	}()
+	MustParseQualified("K")
+}
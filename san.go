@@ -0,0 +1,84 @@
+package pin
+
+// FromSANPiece returns the identifier of side for the piece letter of a
+// SAN (Standard Algebraic Notation) move: "N" with Second is "n".
+//
+// SAN writes piece letters in uppercase for both sides and leaves pawns
+// implied, so both "" and "P" name a pawn. Kings are terminal pieces, as
+// in FEEN, so "K" with First is "K^".
+//
+// Returns a *SyntaxError wrapping ErrAbbrNotInProfile for an uppercase
+// letter outside ChessProfile, or ErrInvalidSAN for any other string.
+//
+// Panics with ErrInvalidSide if side is invalid.
+func FromSANPiece(letter string, side Side) (Identifier, error) {
+	if !isValidSide(side) {
+		panic(ErrInvalidSide)
+	}
+
+	switch {
+	case letter == "":
+		return makeIdentifier('P', side, Normal, false), nil
+	case letter[0] < 'A' || letter[0] > 'Z':
+		return Identifier{}, newSyntaxError(letter, 0, ErrInvalidSAN)
+	case len(letter) > 1:
+		return Identifier{}, newSyntaxError(letter, 1, ErrInvalidSAN)
+	case !ChessProfile.AllowsAbbr(rune(letter[0])):
+		return Identifier{}, newSyntaxError(letter, 0, ErrAbbrNotInProfile)
+	}
+
+	abbr := rune(letter[0])
+	return makeIdentifier(abbr, side, Normal, abbr == 'K'), nil
+}
+
+// SANMovePiece returns the identifier of side for the piece moved by a SAN
+// move, such as "Nf3", "exd5", or "O-O": the piece of its leading letter,
+// a pawn when it starts with a file, and the king when it castles.
+//
+// Only the start of the move is read; the rest is left to the caller.
+//
+// Returns a *SyntaxError wrapping the errors of FromSANPiece, or
+// ErrInvalidSAN if the move starts with neither a piece letter, a file,
+// nor castling.
+//
+// Panics with ErrInvalidSide if side is invalid.
+func SANMovePiece(move string, side Side) (Identifier, error) {
+	if !isValidSide(side) {
+		panic(ErrInvalidSide)
+	}
+
+	switch {
+	case len(move) >= 3 && (move[:3] == "O-O" || move[:3] == "0-0"):
+		return FromSANPiece("K", side)
+	case move != "" && move[0] >= 'a' && move[0] <= 'z':
+		return FromSANPiece("", side)
+	case move != "" && move[0] >= 'A' && move[0] <= 'Z':
+		id, err := FromSANPiece(move[:1], side)
+		if err != nil {
+			// Locate the error in the move rather than in its letter
+			return Identifier{}, newSyntaxError(move, 0, err.(*SyntaxError).Err)
+		}
+		return id, nil
+	default:
+		return Identifier{}, newSyntaxError(move, 0, ErrInvalidSAN)
+	}
+}
+
+// ToSANPiece returns the SAN piece letter of id, uppercase whatever its
+// side, and "" for a pawn, whose letter SAN leaves implied. States and
+// terminal markers are dropped.
+//
+// Returns ErrInvalidIdentifier if id is not valid, or ErrAbbrNotInProfile
+// if it is outside ChessProfile.
+func ToSANPiece(id Identifier) (string, error) {
+	switch {
+	case !id.isValid():
+		return "", ErrInvalidIdentifier
+	case !ChessProfile.Allows(id):
+		return "", ErrAbbrNotInProfile
+	case id.Abbr() == 'P':
+		return "", nil
+	default:
+		return string(id.Abbr()), nil
+	}
+}
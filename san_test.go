@@ -0,0 +1,137 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFromSANPiece(t *testing.T) {
+	tests := []struct {
+		letter string
+		side   Side
+		want   string
+	}{
+		{"N", First, "N"},
+		{"N", Second, "n"},
+		{"K", First, "K^"},
+		{"K", Second, "k^"},
+		{"Q", Second, "q"},
+		{"", First, "P"},
+		{"", Second, "p"},
+		{"P", Second, "p"},
+	}
+
+	for _, tt := range tests {
+		got, err := FromSANPiece(tt.letter, tt.side)
+		if err != nil || got != MustParse(tt.want) {
+			t.Errorf("FromSANPiece(%q, %v) = %v, %v, want %s", tt.letter, tt.side, got, err, tt.want)
+		}
+	}
+}
+
+func TestFromSANPieceErrors(t *testing.T) {
+	tests := []struct {
+		letter string
+		offset int
+		err    error
+	}{
+		{"n", 0, ErrInvalidSAN},
+		{"NN", 1, ErrInvalidSAN},
+		{"+", 0, ErrInvalidSAN},
+		{"S", 0, ErrAbbrNotInProfile},
+	}
+
+	for _, tt := range tests {
+		_, err := FromSANPiece(tt.letter, First)
+
+		var se *SyntaxError
+		if !errors.As(err, &se) || !errors.Is(err, tt.err) || se.Offset != tt.offset {
+			t.Errorf("FromSANPiece(%q) error = %v, want %v at offset %d", tt.letter, err, tt.err, tt.offset)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != ErrInvalidSide {
+			t.Errorf("FromSANPiece(N, 2) panic = %v, want ErrInvalidSide", r)
+		}
+	}()
+	FromSANPiece("N", Side(2))
+}
+
+func TestSANMovePiece(t *testing.T) {
+	tests := []struct {
+		move string
+		side Side
+		want string
+	}{
+		{"e4", First, "P"},
+		{"exd5", Second, "p"},
+		{"e8=Q+", First, "P"},
+		{"Nf3", First, "N"},
+		{"Nbd7", Second, "n"},
+		{"Qxh7#", First, "Q"},
+		{"O-O", First, "K^"},
+		{"O-O-O", Second, "k^"},
+		{"0-0", Second, "k^"},
+	}
+
+	for _, tt := range tests {
+		got, err := SANMovePiece(tt.move, tt.side)
+		if err != nil || got != MustParse(tt.want) {
+			t.Errorf("SANMovePiece(%q, %v) = %v, %v, want %s", tt.move, tt.side, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		move string
+		err  error
+	}{
+		{"", ErrInvalidSAN},
+		{"1-0", ErrInvalidSAN},
+		{"Sf3", ErrAbbrNotInProfile},
+	} {
+		var se *SyntaxError
+		if _, err := SANMovePiece(tt.move, First); !errors.As(err, &se) || !errors.Is(err, tt.err) || se.Input != tt.move {
+			t.Errorf("SANMovePiece(%q) error = %v, want %v", tt.move, err, tt.err)
+		}
+	}
+}
+
+func TestToSANPiece(t *testing.T) {
+	tests := []struct {
+		pin  string
+		want string
+	}{
+		{"N", "N"},
+		{"n", "N"},
+		{"k^", "K"},
+		{"+R", "R"},
+		{"P", ""},
+		{"-p", ""},
+	}
+
+	for _, tt := range tests {
+		got, err := ToSANPiece(MustParse(tt.pin))
+		if err != nil || got != tt.want {
+			t.Errorf("ToSANPiece(%s) = %q, %v, want %q", tt.pin, got, err, tt.want)
+		}
+	}
+
+	if _, err := ToSANPiece(MustParse("S")); err != ErrAbbrNotInProfile {
+		t.Errorf("ToSANPiece(S) error = %v, want ErrAbbrNotInProfile", err)
+	}
+	if _, err := ToSANPiece(Identifier{}); err != ErrInvalidIdentifier {
+		t.Errorf("ToSANPiece(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestSANPieceRoundTrip(t *testing.T) {
+	for _, letter := range []string{"K", "Q", "R", "B", "N", ""} {
+		for _, side := range []Side{First, Second} {
+			id, _ := FromSANPiece(letter, side)
+			if got, err := ToSANPiece(id); err != nil || got != letter {
+				t.Errorf("ToSANPiece(FromSANPiece(%q, %v)) = %q, %v", letter, side, got, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package pin
+
+import (
+	"encoding/hex"
+	"math/bits"
+)
+
+// setWords is the number of 64-bit words needed to hold identifierCount bits.
+const setWords = (identifierCount + 63) / 64
+
+// Set is a set of Identifiers.
+//
+// Set is a fixed-size value type with one bit per valid identifier, so
+// copies are independent and iteration always follows the canonical order:
+// by side, then abbreviation, then state, then terminal status. This makes
+// serialization and hashing of sets reproducible across runs and platforms.
+//
+// The zero value is an empty set ready to use.
+type Set struct {
+	bits [setWords]uint64
+}
+
+// NewSet returns a Set containing the given Identifiers.
+//
+// Panics if any Identifier is not valid (e.g., the zero value).
+func NewSet(ids ...Identifier) Set {
+	var s Set
+	for _, id := range ids {
+		s.Add(id)
+	}
+	return s
+}
+
+// Add inserts id into the set.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (s *Set) Add(id Identifier) {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+
+	i := id.index()
+	s.bits[i/64] |= 1 << (i % 64)
+}
+
+// Remove deletes id from the set, if present. Invalid identifiers are
+// never present, so removing one has no effect.
+func (s *Set) Remove(id Identifier) {
+	if !id.isValid() {
+		return
+	}
+
+	i := id.index()
+	s.bits[i/64] &^= 1 << (i % 64)
+}
+
+// Contains reports whether id is in the set.
+func (s Set) Contains(id Identifier) bool {
+	if !id.isValid() {
+		return false
+	}
+
+	i := id.index()
+	return s.bits[i/64]&(1<<(i%64)) != 0
+}
+
+// Len returns the number of identifiers in the set.
+func (s Set) Len() int {
+	n := 0
+	for _, word := range s.bits {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
+
+// Union returns the identifiers in s, t, or both.
+func (s Set) Union(t Set) Set {
+	for i := range s.bits {
+		s.bits[i] |= t.bits[i]
+	}
+	return s
+}
+
+// Intersect returns the identifiers in both s and t.
+func (s Set) Intersect(t Set) Set {
+	for i := range s.bits {
+		s.bits[i] &= t.bits[i]
+	}
+	return s
+}
+
+// Difference returns the identifiers in s but not in t.
+func (s Set) Difference(t Set) Set {
+	for i := range s.bits {
+		s.bits[i] &^= t.bits[i]
+	}
+	return s
+}
+
+// Complement returns the valid identifiers not in s, within the universe
+// of all 312 identifiers. To complement within a game, subtract from the
+// identifiers of its profile instead:
+//
+//	ShogiProfile.Identifiers().Difference(s)
+func (s Set) Complement() Set {
+	return fullSet.Difference(s)
+}
+
+// fullSet holds every valid identifier.
+var fullSet = func() (s Set) {
+	for i := 0; i < identifierCount; i++ {
+		s.bits[i/64] |= 1 << (i % 64)
+	}
+	return s
+}()
+
+// Range calls fn for each Identifier in the set, in canonical order.
+// If fn returns false, Range stops the iteration.
+func (s Set) Range(fn func(id Identifier) bool) {
+	for w, word := range s.bits {
+		for word != 0 {
+			b := bits.TrailingZeros64(word)
+			if !fn(fromIndex(w*64 + b)) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// SortedSlice returns the elements of the set in canonical order.
+// The result is a new slice; it is empty (but non-nil) for an empty set.
+func (s Set) SortedSlice() []Identifier {
+	out := make([]Identifier, 0, s.Len())
+	s.Range(func(id Identifier) bool {
+		out = append(out, id)
+		return true
+	})
+	return out
+}
+
+// ============================================================================
+// Hex Encoding
+// ============================================================================
+
+// setBytes is the number of bytes holding the 312 bits of a Set.
+const setBytes = identifierCount / 8
+
+// SetHexLength is the length of the hex form of a Set.
+const SetHexLength = 2 * setBytes
+
+// MarshalHex returns the set as a fixed-length string of SetHexLength
+// lowercase hex digits: the 312-bit mask in big-endian order, where bit i
+// stands for the identifier of compact code i.
+//
+//	NewSet(MustParse("A")).MarshalHex()   // 77 zeros, then "1"
+//	NewSet(MustParse("-z^")).MarshalHex() // "8", then 77 zeros
+//
+// Equal sets have equal strings, so sets can be stored in a single database
+// column and compared as strings. The format is stable across versions.
+func (s Set) MarshalHex() string {
+	var buf [setBytes]byte
+	for i := range buf {
+		buf[setBytes-1-i] = byte(s.bits[i/8] >> (i % 8 * 8))
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// UnmarshalHex decodes the form written by MarshalHex into the set,
+// replacing its contents. Uppercase hex digits are accepted.
+//
+// Returns ErrInvalidSetHex, leaving the set unchanged, if h is not
+// SetHexLength hex digits.
+func (s *Set) UnmarshalHex(h string) error {
+	var buf [setBytes]byte
+	if len(h) != SetHexLength {
+		return ErrInvalidSetHex
+	}
+	if _, err := hex.Decode(buf[:], []byte(h)); err != nil {
+		return ErrInvalidSetHex
+	}
+
+	var out Set
+	for i := range buf {
+		out.bits[i/8] |= uint64(buf[setBytes-1-i]) << (i % 8 * 8)
+	}
+	*s = out
+	return nil
+}
@@ -0,0 +1,331 @@
+package pin
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Membership Tests
+// ============================================================================
+
+func TestSetZeroValueIsEmpty(t *testing.T) {
+	var s Set
+
+	if s.Contains(MustParse("K")) {
+		t.Error("zero Set Contains(K) = true, want false")
+	}
+	if got := s.SortedSlice(); got == nil || len(got) != 0 {
+		t.Errorf("zero Set SortedSlice() = %v, want empty non-nil slice", got)
+	}
+}
+
+func TestSetAddContains(t *testing.T) {
+	var s Set
+	s.Add(MustParse("+K^"))
+
+	if !s.Contains(MustParse("+K^")) {
+		t.Error("Contains(+K^) = false, want true")
+	}
+	for _, other := range []string{"K", "+K", "K^", "+k^", "-K^"} {
+		if s.Contains(MustParse(other)) {
+			t.Errorf("Contains(%q) = true, want false", other)
+		}
+	}
+}
+
+func TestSetContainsZeroIdentifier(t *testing.T) {
+	s := NewSet(MustParse("A"))
+
+	if s.Contains(Identifier{}) {
+		t.Error("Contains(Identifier{}) = true, want false")
+	}
+}
+
+func TestSetAddPanicsOnZeroIdentifier(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for zero Identifier")
+		}
+	}()
+
+	var s Set
+	s.Add(Identifier{})
+}
+
+func TestSetIsValueType(t *testing.T) {
+	s1 := NewSet(MustParse("K"))
+	s2 := s1
+	s2.Add(MustParse("Q"))
+
+	if s1.Contains(MustParse("Q")) {
+		t.Error("adding to a copy affected the original")
+	}
+}
+
+func TestSetRemove(t *testing.T) {
+	s := NewSet(MustParse("K"), MustParse("k"))
+	s.Remove(MustParse("K"))
+	s.Remove(MustParse("K"))
+	s.Remove(MustParse("Q"))
+	s.Remove(Identifier{})
+
+	if s.Contains(MustParse("K")) {
+		t.Error("Contains(K) = true after Remove(K)")
+	}
+	if !s.Contains(MustParse("k")) {
+		t.Error("Contains(k) = false, want true")
+	}
+}
+
+func TestSetLen(t *testing.T) {
+	var s Set
+	if s.Len() != 0 {
+		t.Errorf("zero Set Len() = %d, want 0", s.Len())
+	}
+
+	for _, id := range All() {
+		s.Add(id)
+	}
+	s.Add(MustParse("K"))
+	if s.Len() != CodeCount {
+		t.Errorf("full Set Len() = %d, want %d", s.Len(), CodeCount)
+	}
+
+	s.Remove(MustParse("-z^"))
+	if s.Len() != CodeCount-1 {
+		t.Errorf("Len() after Remove = %d, want %d", s.Len(), CodeCount-1)
+	}
+}
+
+// ============================================================================
+// Sorted Iteration Tests
+// ============================================================================
+
+func TestSetSortedSlice(t *testing.T) {
+	s := NewSet(
+		MustParse("k"),
+		MustParse("-P"),
+		MustParse("K^"),
+		MustParse("+K"),
+		MustParse("K"),
+		MustParse("a"),
+	)
+
+	want := []string{"K", "K^", "+K", "-P", "a", "k"}
+	got := s.SortedSlice()
+
+	if len(got) != len(want) {
+		t.Fatalf("SortedSlice() length = %d, want %d", len(got), len(want))
+	}
+	for i, id := range got {
+		if id.String() != want[i] {
+			t.Errorf("SortedSlice()[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+}
+
+func TestSetSortedSliceIsIndependentOfInsertionOrder(t *testing.T) {
+	ids := []Identifier{MustParse("r"), MustParse("B"), MustParse("+s^"), MustParse("-B")}
+
+	s1 := NewSet(ids...)
+	s2 := NewSet(ids[3], ids[1], ids[2], ids[0])
+
+	if s1 != s2 {
+		t.Error("sets built in different orders should be equal")
+	}
+
+	got1, got2 := s1.SortedSlice(), s2.SortedSlice()
+	for i := range got1 {
+		if got1[i] != got2[i] {
+			t.Errorf("SortedSlice()[%d] differs: %s vs %s", i, got1[i], got2[i])
+		}
+	}
+}
+
+func TestSetRangeFullUniverse(t *testing.T) {
+	var s Set
+	for i := 0; i < identifierCount; i++ {
+		s.Add(fromIndex(i))
+	}
+
+	n := 0
+	s.Range(func(id Identifier) bool {
+		if id.index() != n {
+			t.Errorf("Range() element %d has index %d", n, id.index())
+		}
+		n++
+		return true
+	})
+
+	if n != identifierCount {
+		t.Errorf("Range() visited %d identifiers, want %d", n, identifierCount)
+	}
+}
+
+func TestSetRangeStopsEarly(t *testing.T) {
+	s := NewSet(MustParse("A"), MustParse("B"), MustParse("C"))
+
+	var visited []Identifier
+	s.Range(func(id Identifier) bool {
+		visited = append(visited, id)
+		return len(visited) < 2
+	})
+
+	if len(visited) != 2 {
+		t.Errorf("Range() visited %d identifiers, want 2", len(visited))
+	}
+}
+
+// ============================================================================
+// Set Algebra Tests
+// ============================================================================
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet(MustParse("K"), MustParse("Q"), MustParse("r"))
+	b := NewSet(MustParse("Q"), MustParse("r"), MustParse("+P"))
+
+	tests := []struct {
+		name string
+		got  Set
+		want Set
+	}{
+		{"Union", a.Union(b), NewSet(MustParse("K"), MustParse("Q"), MustParse("r"), MustParse("+P"))},
+		{"Intersect", a.Intersect(b), NewSet(MustParse("Q"), MustParse("r"))},
+		{"Difference", a.Difference(b), NewSet(MustParse("K"))},
+		{"Difference reversed", b.Difference(a), NewSet(MustParse("+P"))},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got.SortedSlice(), tt.want.SortedSlice())
+		}
+	}
+
+	// Operands are left untouched
+	if a.Len() != 3 || b.Len() != 3 {
+		t.Errorf("operands modified: a has %d, b has %d elements", a.Len(), b.Len())
+	}
+}
+
+func TestSetComplement(t *testing.T) {
+	var empty Set
+	full := empty.Complement()
+	if full.Len() != identifierCount {
+		t.Errorf("Complement(empty).Len() = %d, want %d", full.Len(), identifierCount)
+	}
+	if got := full.Complement(); got != empty {
+		t.Errorf("Complement(full) = %v, want empty", got.SortedSlice())
+	}
+
+	s := NewSet(MustParse("K"), MustParse("-z^"))
+	c := s.Complement()
+	if c.Len() != identifierCount-2 || c.Contains(MustParse("K")) || !c.Contains(MustParse("Q")) {
+		t.Errorf("Complement() = %d elements, want %d without K", c.Len(), identifierCount-2)
+	}
+	if c.Union(s) != full || c.Intersect(s) != empty {
+		t.Error("Complement() is not the complement of s within the universe")
+	}
+}
+
+func TestSetAlgebraWithinProfile(t *testing.T) {
+	// Shogi pieces owned by Second that are neither promoted nor kings
+	var second, promoted, kings Set
+	for _, id := range All() {
+		if id.Side() == Second {
+			second.Add(id)
+		}
+		if id.IsEnhanced() {
+			promoted.Add(id)
+		}
+		if id.Abbr() == 'K' {
+			kings.Add(id)
+		}
+	}
+
+	got := ShogiProfile.Identifiers().Intersect(second).Difference(promoted.Union(kings))
+
+	// 7 abbreviations × 2 remaining states × 2 terminal statuses
+	if got.Len() != 28 {
+		t.Errorf("Len() = %d, want 28", got.Len())
+	}
+	for _, s := range []string{"p", "-g^"} {
+		if !got.Contains(MustParse(s)) {
+			t.Errorf("result lacks %s", s)
+		}
+	}
+	for _, s := range []string{"+p", "P", "k", "q"} {
+		if got.Contains(MustParse(s)) {
+			t.Errorf("result contains %s", s)
+		}
+	}
+}
+
+// ============================================================================
+// Hex Encoding Tests
+// ============================================================================
+
+func TestSetMarshalHex(t *testing.T) {
+	zeros := strings.Repeat("0", SetHexLength-1)
+
+	tests := []struct {
+		set  Set
+		want string
+	}{
+		{Set{}, zeros + "0"},
+		{NewSet(MustParse("A")), zeros + "1"},
+		{NewSet(MustParse("A^"), MustParse("+A")), zeros + "6"},
+		{NewSet(MustParse("-z^")), "8" + zeros},
+		{Set{}.Complement(), strings.Repeat("f", SetHexLength)},
+	}
+
+	for _, tt := range tests {
+		got := tt.set.MarshalHex()
+		if got != tt.want {
+			t.Errorf("MarshalHex(%v) = %q, want %q", tt.set.SortedSlice(), got, tt.want)
+		}
+		if len(got) != SetHexLength {
+			t.Errorf("len(MarshalHex()) = %d, want %d", len(got), SetHexLength)
+		}
+	}
+}
+
+func TestSetHexRoundTrip(t *testing.T) {
+	var s Set
+	for i, id := range All() {
+		if i%3 == 0 || i%7 == 0 {
+			s.Add(id)
+		}
+	}
+
+	var got Set
+	if err := got.UnmarshalHex(s.MarshalHex()); err != nil || got != s {
+		t.Errorf("UnmarshalHex(MarshalHex()) = %v, %v, want %v", got.SortedSlice(), err, s.SortedSlice())
+	}
+
+	// Uppercase digits decode as well
+	if err := got.UnmarshalHex(strings.ToUpper(s.MarshalHex())); err != nil || got != s {
+		t.Errorf("UnmarshalHex(uppercase) = %v, want %v", err, s.SortedSlice())
+	}
+}
+
+func TestSetUnmarshalHexErrors(t *testing.T) {
+	valid := NewSet(MustParse("K")).MarshalHex()
+
+	tests := []string{
+		"",
+		valid[1:],
+		valid + "0",
+		"g" + valid[1:],
+	}
+
+	for _, h := range tests {
+		s := NewSet(MustParse("Q"))
+		if err := s.UnmarshalHex(h); err != ErrInvalidSetHex {
+			t.Errorf("UnmarshalHex(%q) error = %v, want ErrInvalidSetHex", h, err)
+		}
+		if s != NewSet(MustParse("Q")) {
+			t.Errorf("UnmarshalHex(%q) modified the set on error", h)
+		}
+	}
+}
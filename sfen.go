@@ -0,0 +1,204 @@
+package pin
+
+import (
+	"strconv"
+	"strings"
+)
+
+// sfenHandOrder lists the pieces in hand in the order SFEN writes them,
+// from the most valuable.
+const sfenHandOrder = "RBGSNLP"
+
+// shogiStyle is the SNN style name of the sides of an SFEN position.
+const shogiStyle = "SHOGI"
+
+// FromSFEN converts a shogi SFEN string, such as
+//
+//	lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1
+//
+// into a GameState and its move number, so that positions from shogi
+// engines and GUIs round-trip through this package.
+//
+// Pieces are letters of ShogiProfile, uppercase for the first side (sente)
+// and lowercase for the second (gote); promoted pieces, such as "+P",
+// become Enhanced identifiers, and kings are terminal pieces, as in FEEN.
+// The hand field, "-" or pieces prefixed by their count such as "2Pp",
+// fills the hand of the side of each piece. The styles of the state are
+// "SHOGI" and "shogi", the side to move being "b" (sente) or "w" (gote).
+//
+// Invalid input returns a *DecodeError locating the offending part of s,
+// wrapping one of:
+//   - ErrInvalidSFEN: s does not have four fields, or the move number is
+//     not a positive number
+//   - ErrAbbrNotInProfile: a letter outside ShogiProfile
+//   - ErrInvalidCount: a count that is zero or has a leading zero
+//   - ErrMustContainOneLetter: a count in hand not followed by a piece
+//   - ErrInvalidPlacement: any other byte in the placement, an empty rank,
+//     or ranks of different lengths
+//   - ErrInvalidHands: a king, a promoted piece, or any other byte in hand
+//   - ErrInvalidTurn: a side to move other than "b" or "w"
+func FromSFEN(s string) (*GameState, int, error) {
+	fields := strings.Split(s, " ")
+	if len(fields) != 4 {
+		return nil, 0, segmentError(s, 0, len(s), ErrInvalidSFEN)
+	}
+	turnStart := len(fields[0]) + 1
+	handsStart := turnStart + len(fields[1]) + 1
+	moveStart := handsStart + len(fields[2]) + 1
+
+	g := new(GameState)
+
+	var err error
+	if g.Placement, err = parseProfilePlacement(fields[0], ShogiProfile, 'K'); err != nil {
+		return nil, 0, err
+	}
+
+	switch fields[1] {
+	case "b":
+		g.Active = NewStyle(shogiStyle, First)
+	case "w":
+		g.Active = NewStyle(shogiStyle, Second)
+	default:
+		return nil, 0, segmentError(s, turnStart, handsStart-1, ErrInvalidTurn)
+	}
+	g.Inactive = g.Active.Flip()
+
+	if err := g.parseSFENHands(s, handsStart, moveStart-1); err != nil {
+		return nil, 0, err
+	}
+
+	move, err := strconv.Atoi(fields[3])
+	if err != nil || move < 1 || fields[3][0] == '+' {
+		return nil, 0, segmentError(s, moveStart, len(s), ErrInvalidSFEN)
+	}
+
+	return g, move, nil
+}
+
+// parseSFENHands parses the SFEN hand field s[start:end] into g.
+func (g *GameState) parseSFENHands(s string, start, end int) error {
+	field := s[start:end]
+	if field == emptyHand {
+		return nil
+	}
+
+	for i := 0; i < len(field); i++ {
+		if c := field[i]; c < '0' || c > '9' {
+			id := letterIdentifier(c)
+			if id.IsZero() || id.Abbr() == 'K' {
+				return segmentError(s, start+i, start+i+1, ErrInvalidHands)
+			}
+			if !ShogiProfile.Allows(id) {
+				return segmentError(s, start+i, start+i+1, ErrAbbrNotInProfile)
+			}
+		}
+	}
+
+	b, err := ParseBag(field)
+	if err != nil {
+		// Locate the error in s rather than in the field
+		de := err.(*DecodeError)
+		offset := start + int(de.Offset)
+		return segmentError(s, offset, offset+len(de.Token), de.Err)
+	}
+	if b.Len() == 0 {
+		return segmentError(s, start, end, ErrInvalidHands)
+	}
+
+	b.Range(func(id Identifier, count int) bool {
+		g.Hands[id.Side()].add(id, count)
+		return true
+	})
+	return nil
+}
+
+// ToSFEN converts a GameState and its move number into a shogi SFEN
+// string, the reverse of FromSFEN.
+//
+// The side to move is that of the active style; the style names are not
+// checked. Kings lose their terminal marker, and hands are written in the
+// SFEN order, the first side's pieces before the second's.
+//
+// Returns ErrAbbrNotInProfile if a piece is outside ShogiProfile,
+// ErrInvalidState if a piece is Diminished or a promoted form ShogiProfile
+// does not have, ErrInvalidHands if a hand holds a king or a promoted
+// piece, ErrInvalidPlacement if the state has no ranks or an empty rank, or
+// ErrInvalidSFEN if the move number is not positive.
+func ToSFEN(g *GameState, move int) (string, error) {
+	if move < 1 {
+		return "", ErrInvalidSFEN
+	}
+	if len(g.Placement) == 0 {
+		return "", ErrInvalidPlacement
+	}
+
+	var dst []byte
+	for r, rank := range g.Placement {
+		if len(rank) == 0 {
+			return "", ErrInvalidPlacement
+		}
+		if r > 0 {
+			dst = append(dst, '/')
+		}
+
+		empty := 0
+		for _, id := range rank {
+			if id.IsZero() {
+				empty++
+				continue
+			}
+			if !ShogiProfile.Allows(id) {
+				return "", ErrAbbrNotInProfile
+			}
+			if empty > 0 {
+				dst = strconv.AppendInt(dst, int64(empty), 10)
+				empty = 0
+			}
+
+			switch {
+			case id.IsNormal():
+			case id.IsEnhanced() && ShogiProfile.IsDemotable(id):
+				dst = append(dst, '+')
+			default:
+				return "", ErrInvalidState
+			}
+			dst = append(dst, id.LetterByte())
+		}
+		if empty > 0 {
+			dst = strconv.AppendInt(dst, int64(empty), 10)
+		}
+	}
+
+	if g.Turn() == First {
+		dst = append(dst, " b "...)
+	} else {
+		dst = append(dst, " w "...)
+	}
+
+	n := len(dst)
+	for side := First; side <= Second; side++ {
+		hand := &g.Hands[side]
+
+		written := 0
+		for i := 0; i < len(sfenHandOrder); i++ {
+			id := makeIdentifier(rune(sfenHandOrder[i]), side, Normal, false)
+			count := hand.Count(id)
+			if count > 1 {
+				dst = strconv.AppendInt(dst, int64(count), 10)
+			}
+			if count > 0 {
+				dst = append(dst, id.LetterByte())
+			}
+			written += count
+		}
+		if written != hand.Total() {
+			return "", ErrInvalidHands
+		}
+	}
+	if len(dst) == n {
+		dst = append(dst, emptyHand...)
+	}
+
+	dst = append(dst, ' ')
+	return string(strconv.AppendInt(dst, int64(move), 10)), nil
+}
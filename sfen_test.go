@@ -0,0 +1,132 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+const startSFEN = "lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1"
+
+func TestFromSFEN(t *testing.T) {
+	g, move, err := FromSFEN(startSFEN)
+	if err != nil {
+		t.Fatalf("FromSFEN() error = %v", err)
+	}
+	if move != 1 {
+		t.Errorf("FromSFEN() move = %d, want 1", move)
+	}
+	if got := g.String(); got != shogiFEEN {
+		t.Errorf("FromSFEN() = %q, want %q", got, shogiFEEN)
+	}
+}
+
+func TestFromSFENPromotedAndHands(t *testing.T) {
+	g, move, err := FromSFEN("8l/1l+R2P3/p2pBG1pp/kps1p4/Nn1P2G2/P1P1P2PP/1PS6/1KSG3+r1/LN2+p3L w Sbgn3p 124")
+	if err != nil {
+		t.Fatalf("FromSFEN() error = %v", err)
+	}
+	if move != 124 || g.Turn() != Second || g.Active != MustParseStyle("shogi") {
+		t.Errorf("FromSFEN() turn = %v, %v, move = %d, want shogi, Second, 124", g.Active, g.Turn(), move)
+	}
+	if got := g.Placement[1][2]; got != MustParse("+R") {
+		t.Errorf("Placement[1][2] = %v, want +R", got)
+	}
+	if got := g.Placement[3][0]; got != MustParse("k^") {
+		t.Errorf("Placement[3][0] = %v, want k^", got)
+	}
+	if got := g.Hands[First].String(); got != "S" {
+		t.Errorf("Hands[First] = %q, want S", got)
+	}
+	if got := g.Hands[Second].String(); got != "bgn3p" {
+		t.Errorf("Hands[Second] = %q, want bgn3p", got)
+	}
+}
+
+func TestSFENRoundTrip(t *testing.T) {
+	tests := []string{
+		startSFEN,
+		"8l/1l+R2P3/p2pBG1pp/kps1p4/Nn1P2G2/P1P1P2PP/1PS6/1KSG3+r1/LN2+p3L w Sbgn3p 124",
+		"4k4/9/9/9/9/9/9/9/4K4 b 2R2B4G4S4N4L9P9p 1",
+		"4k4/9/9/9/9/9/9/9/4K4 w r 42",
+	}
+
+	for _, sfen := range tests {
+		g, move, err := FromSFEN(sfen)
+		if err != nil {
+			t.Errorf("FromSFEN(%q) error = %v", sfen, err)
+			continue
+		}
+		if got, err := ToSFEN(g, move); err != nil || got != sfen {
+			t.Errorf("ToSFEN(FromSFEN(%q)) = %q, %v", sfen, got, err)
+		}
+	}
+
+	// FEEN and SFEN describe the same position
+	g := MustParseFEEN(shogiFEEN)
+	if got, err := ToSFEN(g, 1); err != nil || got != startSFEN {
+		t.Errorf("ToSFEN(shogiFEEN) = %q, %v, want %q", got, err, startSFEN)
+	}
+}
+
+func TestFromSFENErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int64
+		token  string
+		err    error
+	}{
+		{"9/9 b -", 0, "9/9 b -", ErrInvalidSFEN},
+		{"9/9 b - 0", 8, "0", ErrInvalidSFEN},
+		{"9/9 b - +1", 8, "+1", ErrInvalidSFEN},
+		{"9/9 x - 1", 4, "x", ErrInvalidTurn},
+		{"9/8 b - 1", 2, "8", ErrInvalidPlacement},
+		{"9/+K8 b - 1", 2, "+K", ErrInvalidPlacement},
+		{"9/+G8 b - 1", 2, "+G", ErrInvalidPlacement},
+		{"9/Q8 b - 1", 2, "Q", ErrAbbrNotInProfile},
+		{"9/9 b K 1", 6, "K", ErrInvalidHands},
+		{"9/9 b +P 1", 6, "+", ErrInvalidHands},
+		{"9/9 b 2Q 1", 7, "Q", ErrAbbrNotInProfile},
+		{"9/9 b 02P 1", 6, "02", ErrInvalidCount},
+		{"9/9 b P2 1", 7, "2", ErrMustContainOneLetter},
+		{"9/9 b  1", 6, "", ErrInvalidHands},
+	}
+
+	for _, tt := range tests {
+		_, _, err := FromSFEN(tt.input)
+
+		var de *DecodeError
+		if !errors.As(err, &de) || !errors.Is(err, tt.err) {
+			t.Errorf("FromSFEN(%q) error = %v, want %v", tt.input, err, tt.err)
+			continue
+		}
+		if de.Offset != tt.offset || de.Token != tt.token {
+			t.Errorf("FromSFEN(%q) error at %d %q, want %d %q", tt.input, de.Offset, de.Token, tt.offset, tt.token)
+		}
+	}
+}
+
+func TestToSFENErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		feen string
+		move int
+		err  error
+	}{
+		{"move zero", shogiFEEN, 0, ErrInvalidSFEN},
+		{"chess piece", "4k^4/9/9/9/9/9/9/9/3QK^4 / SHOGI/shogi", 1, ErrAbbrNotInProfile},
+		{"diminished", "4k^4/9/9/9/9/9/9/9/3-PK^4 / SHOGI/shogi", 1, ErrInvalidState},
+		{"promoted gold", "4k^4/9/9/9/9/9/9/9/3+GK^4 / SHOGI/shogi", 1, ErrInvalidState},
+		{"promoted in hand", "4k^4/9/9/9/9/9/9/9/4K^4 +P/ SHOGI/shogi", 1, ErrInvalidHands},
+		{"wrong side in hand", "4k^4/9/9/9/9/9/9/9/4K^4 p/ SHOGI/shogi", 1, ErrInvalidHands},
+	}
+
+	for _, tt := range tests {
+		if _, err := ToSFEN(MustParseFEEN(tt.feen), tt.move); err != tt.err {
+			t.Errorf("%s: ToSFEN() error = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	if _, err := ToSFEN(new(GameState), 1); err != ErrInvalidPlacement {
+		t.Errorf("ToSFEN(empty) error = %v, want ErrInvalidPlacement", err)
+	}
+}
@@ -0,0 +1,30 @@
+package pin
+
+import (
+	"slices"
+	"sort"
+)
+
+// IdentifierSlice attaches the methods of sort.Interface to []Identifier,
+// sorting in canonical order as defined by Compare, like sort.IntSlice.
+type IdentifierSlice []Identifier
+
+// Len returns the length of the slice.
+func (x IdentifierSlice) Len() int { return len(x) }
+
+// Less reports whether x[i] sorts before x[j] in canonical order.
+func (x IdentifierSlice) Less(i, j int) bool { return x[i].Less(x[j]) }
+
+// Swap swaps x[i] and x[j].
+func (x IdentifierSlice) Swap(i, j int) { x[i], x[j] = x[j], x[i] }
+
+// Sort sorts the slice in canonical order.
+func (x IdentifierSlice) Sort() { sort.Sort(x) }
+
+// SortCanonical sorts ids in place in canonical order: by side, then
+// abbreviation, then state, then terminal status. The result is the same
+// across programs and versions, for display, diffing, and hashing of piece
+// collections.
+func SortCanonical(ids []Identifier) {
+	slices.SortFunc(ids, Compare)
+}
@@ -0,0 +1,62 @@
+package pin
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+var _ sort.Interface = IdentifierSlice(nil)
+
+// shuffled returns All() in a fixed scrambled order.
+func shuffled() []Identifier {
+	ids := All()
+	for i := range ids {
+		j := (i * 197) % len(ids)
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+	return ids
+}
+
+func TestSortCanonical(t *testing.T) {
+	ids := shuffled()
+	SortCanonical(ids)
+
+	if !slices.Equal(ids, All()) {
+		t.Errorf("SortCanonical() = %v, want canonical order", ids)
+	}
+}
+
+func TestSortCanonicalExample(t *testing.T) {
+	ids := []Identifier{MustParse("p"), MustParse("-K"), MustParse("K^"), MustParse("b"), MustParse("K")}
+	SortCanonical(ids)
+
+	var got []string
+	for _, id := range ids {
+		got = append(got, id.String())
+	}
+	if want := []string{"K", "K^", "-K", "b", "p"}; !slices.Equal(got, want) {
+		t.Errorf("SortCanonical() = %v, want %v", got, want)
+	}
+}
+
+func TestIdentifierSlice(t *testing.T) {
+	ids := shuffled()
+	IdentifierSlice(ids).Sort()
+
+	if !slices.Equal(ids, All()) {
+		t.Errorf("IdentifierSlice.Sort() = %v, want canonical order", ids)
+	}
+	if !sort.IsSorted(IdentifierSlice(ids)) {
+		t.Error("sort.IsSorted() = false after Sort")
+	}
+
+	x := IdentifierSlice{MustParse("k"), MustParse("K")}
+	if x.Len() != 2 || !x.Less(1, 0) || x.Less(0, 1) {
+		t.Errorf("Len, Less = %d, %v, %v", x.Len(), x.Less(1, 0), x.Less(0, 1))
+	}
+	x.Swap(0, 1)
+	if x[0] != MustParse("K") || x[1] != MustParse("k") {
+		t.Errorf("Swap() = %v", x)
+	}
+}
@@ -0,0 +1,138 @@
+package pin
+
+import "math"
+
+// maxSpriteIndex is the largest flat index of a sprite cell, as cells are
+// stored as int32 values offset by one.
+const maxSpriteIndex = math.MaxInt32 - 1
+
+// SpriteSheet maps Identifiers to cells of a sprite sheet laid out as a grid
+// with a fixed number of columns, read left to right, top to bottom.
+//
+// Lookups that find no cell for a terminal Identifier fall back to its
+// non-terminal form, since sprite sets rarely draw terminal pieces
+// differently.
+//
+// The zero value is not usable; use NewSpriteSheet or one of the built-in
+// layouts.
+type SpriteSheet struct {
+	columns int
+	cells   [identifierCount]int32 // flat index + 1; 0 means unmapped
+}
+
+// NewSpriteSheet creates an empty SpriteSheet with the given number of columns.
+//
+// Panics with ErrInvalidSpriteColumns if columns is not positive.
+func NewSpriteSheet(columns int) *SpriteSheet {
+	if columns <= 0 {
+		panic(ErrInvalidSpriteColumns)
+	}
+	return &SpriteSheet{columns: columns}
+}
+
+// Columns returns the number of columns of the sheet.
+func (s *SpriteSheet) Columns() int {
+	return s.columns
+}
+
+// Set maps id to the cell at the given flat index (row*Columns()+column).
+//
+// Panics with ErrInvalidIdentifier if id is not valid, or with
+// ErrInvalidSpriteIndex if index is negative or above math.MaxInt32 - 1.
+func (s *SpriteSheet) Set(id Identifier, index int) {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+	if index < 0 || index > maxSpriteIndex {
+		panic(ErrInvalidSpriteIndex)
+	}
+	s.cells[id.index()] = int32(index) + 1
+}
+
+// SetCell maps id to the cell at the given row and column.
+//
+// Panics as Set does, or with ErrInvalidSpriteIndex if column is out of
+// range.
+func (s *SpriteSheet) SetCell(id Identifier, row, column int) {
+	if column < 0 || column >= s.columns {
+		panic(ErrInvalidSpriteIndex)
+	}
+	// Check the row before multiplying, which could overflow
+	if row < 0 || row > (maxSpriteIndex-column)/s.columns {
+		panic(ErrInvalidSpriteIndex)
+	}
+	s.Set(id, row*s.columns+column)
+}
+
+// Index returns the flat index of the cell for id, and whether one is mapped.
+func (s *SpriteSheet) Index(id Identifier) (int, bool) {
+	if !id.isValid() {
+		return 0, false
+	}
+	if c := s.cells[id.index()]; c != 0 {
+		return int(c - 1), true
+	}
+	if c := s.cells[id.NonTerminal().index()]; c != 0 {
+		return int(c - 1), true
+	}
+	return 0, false
+}
+
+// Cell returns the row and column of the cell for id, and whether one is mapped.
+func (s *SpriteSheet) Cell(id Identifier) (row, column int, ok bool) {
+	i, ok := s.Index(id)
+	if !ok {
+		return 0, 0, false
+	}
+	return i / s.columns, i % s.columns, true
+}
+
+// ============================================================================
+// Built-in Layouts
+// ============================================================================
+
+// ChessSpriteSheet returns the layout of the Wikimedia Commons chess sprite
+// (Chess_Pieces_Sprite.svg): two rows of six pieces, first player on top,
+// in the order king, queen, bishop, knight, rook, pawn.
+//
+//	K Q B N R P
+//	k q b n r p
+//
+// Each call returns a new SpriteSheet that can be customized freely.
+func ChessSpriteSheet() *SpriteSheet {
+	return newGridSpriteSheet(6, []string{
+		"K", "Q", "B", "N", "R", "P",
+		"k", "q", "b", "n", "r", "p",
+	})
+}
+
+// ShogiSpriteSheet returns an eight-column layout for shogi sets: for each
+// player, a row of unpromoted pieces followed by a row of promoted pieces
+// aligned under their unpromoted form (king and gold have no promoted form).
+//
+//	K  R  B  G  S  N  L  P
+//	.  +R +B .  +S +N +L +P
+//	k  r  b  g  s  n  l  p
+//	.  +r +b .  +s +n +l +p
+//
+// Each call returns a new SpriteSheet that can be customized freely.
+func ShogiSpriteSheet() *SpriteSheet {
+	return newGridSpriteSheet(8, []string{
+		"K", "R", "B", "G", "S", "N", "L", "P",
+		"", "+R", "+B", "", "+S", "+N", "+L", "+P",
+		"k", "r", "b", "g", "s", "n", "l", "p",
+		"", "+r", "+b", "", "+s", "+n", "+l", "+p",
+	})
+}
+
+// newGridSpriteSheet builds a SpriteSheet from PIN strings listed in cell
+// order; empty strings leave the cell unmapped.
+func newGridSpriteSheet(columns int, grid []string) *SpriteSheet {
+	s := NewSpriteSheet(columns)
+	for i, token := range grid {
+		if token != "" {
+			s.Set(MustParse(token), i)
+		}
+	}
+	return s
+}
@@ -0,0 +1,178 @@
+package pin
+
+import (
+	"math"
+	"testing"
+)
+
+// ============================================================================
+// Custom Layout Tests
+// ============================================================================
+
+func TestSpriteSheetSetAndIndex(t *testing.T) {
+	s := NewSpriteSheet(4)
+	s.Set(MustParse("K"), 5)
+
+	i, ok := s.Index(MustParse("K"))
+	if !ok || i != 5 {
+		t.Errorf("Index(K) = %d, %v, want 5, true", i, ok)
+	}
+
+	row, col, ok := s.Cell(MustParse("K"))
+	if !ok || row != 1 || col != 1 {
+		t.Errorf("Cell(K) = %d, %d, %v, want 1, 1, true", row, col, ok)
+	}
+}
+
+func TestSpriteSheetSetCell(t *testing.T) {
+	s := NewSpriteSheet(4)
+	s.SetCell(MustParse("q"), 2, 3)
+
+	if i, ok := s.Index(MustParse("q")); !ok || i != 11 {
+		t.Errorf("Index(q) = %d, %v, want 11, true", i, ok)
+	}
+}
+
+func TestSpriteSheetIndexZeroIsMapped(t *testing.T) {
+	s := NewSpriteSheet(1)
+	s.Set(MustParse("A"), 0)
+
+	if i, ok := s.Index(MustParse("A")); !ok || i != 0 {
+		t.Errorf("Index(A) = %d, %v, want 0, true", i, ok)
+	}
+}
+
+func TestSpriteSheetUnmapped(t *testing.T) {
+	s := NewSpriteSheet(4)
+
+	if _, ok := s.Index(MustParse("K")); ok {
+		t.Error("Index(K) on empty sheet ok = true, want false")
+	}
+	if _, _, ok := s.Cell(MustParse("K")); ok {
+		t.Error("Cell(K) on empty sheet ok = true, want false")
+	}
+	if _, ok := s.Index(Identifier{}); ok {
+		t.Error("Index(Identifier{}) ok = true, want false")
+	}
+}
+
+func TestSpriteSheetTerminalFallback(t *testing.T) {
+	s := NewSpriteSheet(2)
+	s.Set(MustParse("K"), 0)
+
+	if i, ok := s.Index(MustParse("K^")); !ok || i != 0 {
+		t.Errorf("Index(K^) = %d, %v, want fallback to 0, true", i, ok)
+	}
+
+	// An explicit terminal sprite wins over the fallback
+	s.Set(MustParse("K^"), 1)
+	if i, _ := s.Index(MustParse("K^")); i != 1 {
+		t.Errorf("Index(K^) = %d, want 1", i)
+	}
+}
+
+func TestSpriteSheetPanics(t *testing.T) {
+	k := MustParse("K")
+	tests := []struct {
+		name string
+		fn   func()
+		want error
+	}{
+		{"NewSpriteSheet(0)", func() { NewSpriteSheet(0) }, ErrInvalidSpriteColumns},
+		{"Set zero id", func() { NewSpriteSheet(4).Set(Identifier{}, 0) }, ErrInvalidIdentifier},
+		{"Set negative", func() { NewSpriteSheet(4).Set(k, -1) }, ErrInvalidSpriteIndex},
+		{"Set beyond int32", func() { NewSpriteSheet(4).Set(k, math.MaxInt32) }, ErrInvalidSpriteIndex},
+		{"SetCell column", func() { NewSpriteSheet(4).SetCell(k, 0, 4) }, ErrInvalidSpriteIndex},
+		{"SetCell negative row", func() { NewSpriteSheet(4).SetCell(k, -1, 0) }, ErrInvalidSpriteIndex},
+		{"SetCell overflowing row", func() { NewSpriteSheet(4).SetCell(k, math.MaxInt/2, 0) }, ErrInvalidSpriteIndex},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s: panic = %v, want %v", tt.name, r, tt.want)
+				}
+			}()
+			tt.fn()
+		}()
+	}
+}
+
+func TestSpriteSheetLargestIndex(t *testing.T) {
+	s := NewSpriteSheet(1)
+	s.Set(MustParse("K"), math.MaxInt32-1)
+	if i, ok := s.Index(MustParse("K")); !ok || i != math.MaxInt32-1 {
+		t.Errorf("Index(K) = %d, %v, want %d, true", i, ok, math.MaxInt32-1)
+	}
+}
+
+// ============================================================================
+// Built-in Layout Tests
+// ============================================================================
+
+func TestChessSpriteSheet(t *testing.T) {
+	s := ChessSpriteSheet()
+
+	tests := []struct {
+		pin      string
+		row, col int
+	}{
+		{"K", 0, 0},
+		{"Q", 0, 1},
+		{"P", 0, 5},
+		{"k", 1, 0},
+		{"n", 1, 3},
+		{"p", 1, 5},
+		{"K^", 0, 0},
+	}
+
+	for _, tt := range tests {
+		row, col, ok := s.Cell(MustParse(tt.pin))
+		if !ok || row != tt.row || col != tt.col {
+			t.Errorf("Cell(%q) = %d, %d, %v, want %d, %d, true", tt.pin, row, col, ok, tt.row, tt.col)
+		}
+	}
+
+	if _, ok := s.Index(MustParse("+P")); ok {
+		t.Error("Index(+P) ok = true, want false")
+	}
+}
+
+func TestShogiSpriteSheet(t *testing.T) {
+	s := ShogiSpriteSheet()
+
+	tests := []struct {
+		pin      string
+		row, col int
+	}{
+		{"K", 0, 0},
+		{"P", 0, 7},
+		{"+R", 1, 1},
+		{"+P", 1, 7},
+		{"g", 2, 3},
+		{"+s", 3, 4},
+	}
+
+	for _, tt := range tests {
+		row, col, ok := s.Cell(MustParse(tt.pin))
+		if !ok || row != tt.row || col != tt.col {
+			t.Errorf("Cell(%q) = %d, %d, %v, want %d, %d, true", tt.pin, row, col, ok, tt.row, tt.col)
+		}
+	}
+
+	for _, pin := range []string{"+K", "+G", "Q"} {
+		if _, ok := s.Index(MustParse(pin)); ok {
+			t.Errorf("Index(%q) ok = true, want false", pin)
+		}
+	}
+}
+
+func TestBuiltinSpriteSheetsAreIndependent(t *testing.T) {
+	s := ChessSpriteSheet()
+	s.Set(MustParse("K"), 11)
+
+	if i, _ := ChessSpriteSheet().Index(MustParse("K")); i != 0 {
+		t.Errorf("customizing a built-in sheet affected later calls: Index(K) = %d", i)
+	}
+}
@@ -0,0 +1,226 @@
+package pin
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// SQLPattern is a regular expression matching valid PIN strings, usable in
+// SQL check constraints (Postgres "~", MySQL "REGEXP", SQLite with a regexp
+// extension).
+const SQLPattern = `^[-+]?[A-Za-z]\^?$`
+
+// ============================================================================
+// Identifier
+// ============================================================================
+
+// Value implements driver.Valuer. The Identifier is stored as its PIN string.
+//
+// Returns ErrInvalidIdentifier for the zero value.
+func (id Identifier) Value() (driver.Value, error) {
+	if !id.isValid() {
+		return nil, ErrInvalidIdentifier
+	}
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts string and []byte sources holding a
+// PIN string.
+//
+// Returns ErrNullValue for NULL; use a pointer field for nullable columns.
+func (id *Identifier) Scan(src any) error {
+	var (
+		parsed Identifier
+		err    error
+	)
+
+	switch src := src.(type) {
+	case nil:
+		return ErrNullValue
+	case string:
+		parsed, err = Parse(src)
+	case []byte:
+		// Parse the bytes directly, without a string conversion
+		return id.UnmarshalText(src)
+	default:
+		return ErrUnsupportedScanType
+	}
+
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// GormDataType returns the column type used by GORM migrations.
+// PIN strings are at most MaxStringLength bytes long.
+func (Identifier) GormDataType() string {
+	return "varchar(3)"
+}
+
+// ============================================================================
+// NullIdentifier
+// ============================================================================
+
+// NullIdentifier is an Identifier that may be NULL, for nullable columns.
+// It follows the conventions of sql.NullString.
+type NullIdentifier struct {
+	Identifier Identifier
+	Valid      bool // Valid is true if Identifier is not NULL
+}
+
+// Scan implements sql.Scanner. NULL sets Valid to false.
+func (n *NullIdentifier) Scan(src any) error {
+	if src == nil {
+		n.Identifier, n.Valid = Identifier{}, false
+		return nil
+	}
+
+	if err := n.Identifier.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer. An invalid NullIdentifier is stored as NULL.
+func (n NullIdentifier) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Identifier.Value()
+}
+
+// ============================================================================
+// TextArray
+// ============================================================================
+
+// TextArray is a slice of Identifiers mapping onto a Postgres text[] column.
+//
+// TextArray implements sql.Scanner and driver.Valuer using the Postgres array
+// literal format ("{K,+r,-p^}"), so it works with database/sql drivers such as
+// lib/pq as well as with pgx, without wrapping in pq.Array.
+//
+// A nil TextArray is stored as NULL; an empty one as "{}".
+type TextArray []Identifier
+
+// Value implements driver.Valuer.
+func (a TextArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	// PIN characters never need quoting in an array literal
+	buf := make([]byte, 0, 2+len(a)*(MaxStringLength+1))
+	buf = append(buf, '{')
+	for i, id := range a {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = id.AppendTo(buf)
+	}
+	buf = append(buf, '}')
+
+	return string(buf), nil
+}
+
+// Scan implements sql.Scanner. It accepts string, []byte, and nil sources.
+//
+// Invalid elements are reported as *IndexError wrapping the parsing sentinel.
+func (a *TextArray) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		return a.scanLiteral(src)
+	case []byte:
+		return a.scanLiteral(string(src))
+	default:
+		return ErrUnsupportedScanType
+	}
+}
+
+// scanLiteral parses a one-dimensional Postgres array literal.
+func (a *TextArray) scanLiteral(s string) error {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return ErrInvalidArrayLiteral
+	}
+	s = s[1 : len(s)-1]
+
+	out := TextArray{}
+	if s == "" {
+		*a = out
+		return nil
+	}
+
+	for i := 0; ; i++ {
+		elem, rest, err := nextArrayElement(s)
+		if err != nil {
+			return err
+		}
+
+		id, err := Parse(elem)
+		if err != nil {
+			return &IndexError{Index: i, Err: err}
+		}
+		out = append(out, id)
+
+		if rest == "" {
+			break
+		}
+		s = rest[1:] // skip ','
+	}
+
+	*a = out
+	return nil
+}
+
+// nextArrayElement splits the first element off an array literal body.
+// The returned rest is either empty or starts with the ',' delimiter.
+func nextArrayElement(s string) (elem, rest string, err error) {
+	if s == "" {
+		return "", "", ErrInvalidArrayLiteral
+	}
+
+	// Unquoted element
+	if s[0] != '"' {
+		end := strings.IndexByte(s, ',')
+		if end < 0 {
+			end = len(s)
+		}
+		elem, rest = s[:end], s[end:]
+
+		if elem == "" || strings.ContainsAny(elem, "{}\"") {
+			return "", "", ErrInvalidArrayLiteral // nested or malformed
+		}
+		if strings.EqualFold(elem, "NULL") {
+			return "", "", ErrNullArrayElement
+		}
+		return elem, rest, nil
+	}
+
+	// Quoted element, with backslash escapes
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", "", ErrInvalidArrayLiteral
+			}
+			b.WriteByte(s[i])
+		case '"':
+			rest = s[i+1:]
+			if rest != "" && rest[0] != ',' {
+				return "", "", ErrInvalidArrayLiteral
+			}
+			return b.String(), rest, nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", ErrInvalidArrayLiteral
+}
@@ -0,0 +1,294 @@
+package pin
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+// Compile-time interface checks.
+var (
+	_ sql.Scanner   = (*Identifier)(nil)
+	_ driver.Valuer = Identifier{}
+	_ sql.Scanner   = (*NullIdentifier)(nil)
+	_ driver.Valuer = NullIdentifier{}
+	_ sql.Scanner   = (*TextArray)(nil)
+	_ driver.Valuer = TextArray(nil)
+)
+
+// ============================================================================
+// SQLPattern Tests
+// ============================================================================
+
+func TestSQLPattern(t *testing.T) {
+	re := regexp.MustCompile(SQLPattern)
+
+	for i := 0; i < identifierCount; i++ {
+		s := fromIndex(i).String()
+		if !re.MatchString(s) {
+			t.Errorf("SQLPattern does not match %q", s)
+		}
+	}
+	for _, s := range []string{"", "KK", "*K", "K!", "+K^^", "1"} {
+		if re.MatchString(s) {
+			t.Errorf("SQLPattern matches invalid %q", s)
+		}
+	}
+}
+
+// ============================================================================
+// Identifier Value/Scan Tests
+// ============================================================================
+
+func TestIdentifierValue(t *testing.T) {
+	got, err := MustParse("+k^").Value()
+	if err != nil || got != "+k^" {
+		t.Errorf("Value() = %v, %v, want \"+k^\", nil", got, err)
+	}
+}
+
+func TestIdentifierValueZero(t *testing.T) {
+	if _, err := (Identifier{}).Value(); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Value() error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestIdentifierScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want string
+	}{
+		{"K", "K"},
+		{"+r^", "+r^"},
+		{[]byte("-p"), "-p"},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		if err := id.Scan(tt.src); err != nil {
+			t.Errorf("Scan(%v) error = %v", tt.src, err)
+			continue
+		}
+		if id.String() != tt.want {
+			t.Errorf("Scan(%v) = %q, want %q", tt.src, id.String(), tt.want)
+		}
+	}
+}
+
+func TestIdentifierScanErrors(t *testing.T) {
+	tests := []struct {
+		src  any
+		want error
+	}{
+		{nil, ErrNullValue},
+		{42, ErrUnsupportedScanType},
+		{"", ErrEmptyInput},
+		{[]byte("*K"), ErrInvalidStateModifier},
+		{[]byte("KKKK"), ErrInputTooLong},
+	}
+
+	for _, tt := range tests {
+		id := MustParse("K")
+		err := id.Scan(tt.src)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Scan(%v) error = %v, want %v", tt.src, err, tt.want)
+		}
+		if id.String() != "K" {
+			t.Errorf("Scan(%v) modified receiver on error: %q", tt.src, id.String())
+		}
+	}
+}
+
+func TestIdentifierGormDataType(t *testing.T) {
+	if got := (Identifier{}).GormDataType(); got != "varchar(3)" {
+		t.Errorf("GormDataType() = %q, want \"varchar(3)\"", got)
+	}
+}
+
+// ============================================================================
+// NullIdentifier Tests
+// ============================================================================
+
+func TestNullIdentifierScan(t *testing.T) {
+	var n NullIdentifier
+	if err := n.Scan([]byte("+k^")); err != nil {
+		t.Fatalf("Scan(+k^) error = %v", err)
+	}
+	if !n.Valid || n.Identifier.String() != "+k^" {
+		t.Errorf("Scan(+k^) = %+v, want valid +k^", n)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error = %v", err)
+	}
+	if n.Valid || !n.Identifier.IsZero() {
+		t.Errorf("Scan(nil) = %+v, want invalid zero value", n)
+	}
+}
+
+func TestNullIdentifierScanErrors(t *testing.T) {
+	var n NullIdentifier
+	if err := n.Scan("K+"); !errors.Is(err, ErrInvalidTerminalMarker) {
+		t.Errorf("Scan(K+) error = %v, want ErrInvalidTerminalMarker", err)
+	}
+	if n.Valid {
+		t.Error("Scan(K+) set Valid on error")
+	}
+	if err := n.Scan(3.5); !errors.Is(err, ErrUnsupportedScanType) {
+		t.Errorf("Scan(3.5) error = %v, want ErrUnsupportedScanType", err)
+	}
+}
+
+func TestNullIdentifierValue(t *testing.T) {
+	v, err := NullIdentifier{Identifier: MustParse("-p"), Valid: true}.Value()
+	if err != nil || v != "-p" {
+		t.Errorf("Value(valid -p) = %v, %v, want -p", v, err)
+	}
+
+	v, err = NullIdentifier{}.Value()
+	if err != nil || v != nil {
+		t.Errorf("Value(invalid) = %v, %v, want nil", v, err)
+	}
+
+	_, err = NullIdentifier{Valid: true}.Value()
+	if !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Value(valid zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+// ============================================================================
+// TextArray Value Tests
+// ============================================================================
+
+func TestTextArrayValue(t *testing.T) {
+	tests := []struct {
+		a    TextArray
+		want driver.Value
+	}{
+		{nil, nil},
+		{TextArray{}, "{}"},
+		{TextArray{MustParse("K")}, "{K}"},
+		{TextArray{MustParse("K"), MustParse("+r"), MustParse("-p^")}, "{K,+r,-p^}"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.a.Value()
+		if err != nil {
+			t.Errorf("Value() error = %v", err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Value() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+// ============================================================================
+// TextArray Scan Tests
+// ============================================================================
+
+func TestTextArrayScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want []string
+	}{
+		{"{}", []string{}},
+		{"{K}", []string{"K"}},
+		{"{K,+r,-p^}", []string{"K", "+r", "-p^"}},
+		{[]byte("{K,k}"), []string{"K", "k"}},
+		{`{"+K^",r}`, []string{"+K^", "r"}},
+		{`{"\K"}`, []string{"K"}},
+	}
+
+	for _, tt := range tests {
+		var a TextArray
+		if err := a.Scan(tt.src); err != nil {
+			t.Errorf("Scan(%v) error = %v", tt.src, err)
+			continue
+		}
+		if a == nil {
+			t.Errorf("Scan(%v) = nil, want non-nil", tt.src)
+			continue
+		}
+		if len(a) != len(tt.want) {
+			t.Errorf("Scan(%v) length = %d, want %d", tt.src, len(a), len(tt.want))
+			continue
+		}
+		for i, id := range a {
+			if id.String() != tt.want[i] {
+				t.Errorf("Scan(%v)[%d] = %q, want %q", tt.src, i, id.String(), tt.want[i])
+			}
+		}
+	}
+}
+
+func TestTextArrayScanNull(t *testing.T) {
+	a := TextArray{MustParse("K")}
+
+	if err := a.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error = %v", err)
+	}
+	if a != nil {
+		t.Errorf("Scan(nil) = %v, want nil", a)
+	}
+}
+
+func TestTextArrayScanInvalidLiteral(t *testing.T) {
+	inputs := []string{"", "K", "{K", "K}", "{K,}", "{,K}", "{{K}}", `{"K}`, `{"K"x}`, `{K"}`}
+
+	for _, input := range inputs {
+		var a TextArray
+		if err := a.Scan(input); !errors.Is(err, ErrInvalidArrayLiteral) {
+			t.Errorf("Scan(%q) error = %v, want ErrInvalidArrayLiteral", input, err)
+		}
+	}
+}
+
+func TestTextArrayScanNullElement(t *testing.T) {
+	var a TextArray
+	if err := a.Scan("{K,NULL}"); !errors.Is(err, ErrNullArrayElement) {
+		t.Errorf("Scan({K,NULL}) error = %v, want ErrNullArrayElement", err)
+	}
+}
+
+func TestTextArrayScanInvalidElement(t *testing.T) {
+	var a TextArray
+	err := a.Scan("{K,Q,*R}")
+
+	var ie *IndexError
+	if !errors.As(err, &ie) || ie.Index != 2 {
+		t.Fatalf("Scan() error = %v, want *IndexError at index 2", err)
+	}
+	if !errors.Is(err, ErrInvalidStateModifier) {
+		t.Errorf("Scan() error = %v, want ErrInvalidStateModifier", err)
+	}
+}
+
+func TestTextArrayScanUnsupportedType(t *testing.T) {
+	var a TextArray
+	if err := a.Scan(42); !errors.Is(err, ErrUnsupportedScanType) {
+		t.Errorf("Scan(42) error = %v, want ErrUnsupportedScanType", err)
+	}
+}
+
+func TestTextArrayRoundTrip(t *testing.T) {
+	in := TextArray{MustParse("+K^"), MustParse("k"), MustParse("-P")}
+
+	v, err := in.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	var out TextArray
+	if err := out.Scan(v); err != nil {
+		t.Fatalf("Scan(%v) error = %v", v, err)
+	}
+
+	for i := range in {
+		if in[i] != out[i] {
+			t.Errorf("round trip [%d] = %s, want %s", i, out[i], in[i])
+		}
+	}
+}
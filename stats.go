@@ -0,0 +1,129 @@
+package pin
+
+import (
+	"errors"
+	"io"
+	"slices"
+)
+
+// Stats collects frequency statistics over a corpus of identifiers, such
+// as a large PIN stream or the pieces of a FEEN database: token
+// frequencies, state distribution, and side balance.
+//
+// The zero value is an empty collector ready to use.
+type Stats struct {
+	bag     Bag
+	invalid int
+}
+
+// Frequency is the number of occurrences of an identifier.
+type Frequency struct {
+	Identifier Identifier
+	Count      int
+}
+
+// Add records one occurrence of id.
+//
+// Panics if id is not valid (e.g., the zero value).
+func (s *Stats) Add(id Identifier) {
+	s.bag.Add(id)
+}
+
+// DecodeFrom records every identifier read from d until the end of its
+// input. Invalid tokens, including those skipped by d in recovery mode,
+// are counted by Invalid and skipped; any other error, such as a read
+// error or a limit of the Decoder, stops the collection and is returned.
+func (s *Stats) DecodeFrom(d *Decoder) error {
+	skipped := len(d.Skipped())
+	defer func() {
+		s.invalid += len(d.Skipped()) - skipped
+	}()
+
+	for {
+		id, err := d.Decode()
+		if err == io.EOF {
+			return nil
+		}
+
+		var de *DecodeError
+		if errors.As(err, &de) {
+			s.invalid++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		s.bag.Add(id)
+	}
+}
+
+// ReadFrom records every identifier read from r, separated by whitespace
+// or commas as for a Decoder, implementing io.ReaderFrom. It returns the
+// number of bytes read, and errors as DecodeFrom.
+func (s *Stats) ReadFrom(r io.Reader) (int64, error) {
+	d := NewDecoder(r)
+	defer d.Release()
+
+	err := s.DecodeFrom(d)
+	return d.Offset(), err
+}
+
+// Total returns the number of valid identifiers recorded.
+func (s *Stats) Total() int {
+	return s.bag.Total()
+}
+
+// Invalid returns the number of invalid tokens skipped.
+func (s *Stats) Invalid() int {
+	return s.invalid
+}
+
+// Distinct returns the number of distinct identifiers recorded.
+func (s *Stats) Distinct() int {
+	return s.bag.Len()
+}
+
+// Count returns the number of occurrences of id.
+func (s *Stats) Count(id Identifier) int {
+	return s.bag.Count(id)
+}
+
+// Top returns the n most frequent identifiers, by decreasing count, ties
+// broken in canonical order. A negative n returns all of them.
+func (s *Stats) Top(n int) []Frequency {
+	out := byCount(&s.bag)
+	if n >= 0 && n < len(out) {
+		out = out[:n]
+	}
+	return out
+}
+
+// byCount returns the identifiers of b and their counts, by decreasing
+// count, ties in canonical order.
+func byCount(b *Bag) []Frequency {
+	out := make([]Frequency, 0, b.Len())
+	b.Range(func(id Identifier, count int) bool {
+		out = append(out, Frequency{Identifier: id, Count: count})
+		return true
+	})
+
+	// Stable, so ties keep the canonical order of Range
+	slices.SortStableFunc(out, func(a, b Frequency) int {
+		return b.Count - a.Count
+	})
+	return out
+}
+
+// Counter returns the tally of the recorded identifiers by abbreviation,
+// side, and state, for the state distribution of the corpus.
+func (s *Stats) Counter() Counter {
+	return s.bag.Counter()
+}
+
+// SideBalance returns the number of first-player identifiers minus the
+// number of second-player identifiers.
+func (s *Stats) SideBalance() int {
+	c := s.Counter()
+	return c.CountBySide(First) - c.CountBySide(Second)
+}
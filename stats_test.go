@@ -0,0 +1,114 @@
+package pin
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var _ io.ReaderFrom = (*Stats)(nil)
+
+func TestStatsZeroValue(t *testing.T) {
+	var s Stats
+
+	if s.Total() != 0 || s.Invalid() != 0 || s.Distinct() != 0 || len(s.Top(-1)) != 0 || s.SideBalance() != 0 {
+		t.Error("zero Stats is not empty")
+	}
+}
+
+func TestStatsReadFrom(t *testing.T) {
+	input := "K k P P +P p x P^ ++ p, P"
+
+	var s Stats
+	n, err := s.ReadFrom(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if n != int64(len(input)) {
+		t.Errorf("ReadFrom() = %d bytes, want %d", n, len(input))
+	}
+
+	if s.Total() != 10 || s.Invalid() != 1 || s.Distinct() != 7 {
+		t.Errorf("Total, Invalid, Distinct = %d, %d, %d, want 10, 1, 7", s.Total(), s.Invalid(), s.Distinct())
+	}
+	if s.Count(MustParse("P")) != 3 || s.Count(MustParse("p")) != 2 || s.Count(MustParse("Q")) != 0 {
+		t.Errorf("Count(P), Count(p), Count(Q) = %d, %d, %d, want 3, 2, 0",
+			s.Count(MustParse("P")), s.Count(MustParse("p")), s.Count(MustParse("Q")))
+	}
+
+	// 6 first-player identifiers against 4
+	if s.SideBalance() != 2 {
+		t.Errorf("SideBalance() = %d, want 2", s.SideBalance())
+	}
+	if got := s.Counter().CountByState(First, Enhanced); got != 1 {
+		t.Errorf("Counter().CountByState(First, Enhanced) = %d, want 1", got)
+	}
+}
+
+func TestStatsTop(t *testing.T) {
+	var s Stats
+	for _, p := range []string{"p", "K", "p", "P", "p", "P", "k"} {
+		s.Add(MustParse(p))
+	}
+
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, ""},
+		{1, "p3"},
+		{3, "p3 P2 K1"},
+		{-1, "p3 P2 K1 k1"},
+		{10, "p3 P2 K1 k1"},
+	}
+
+	for _, tt := range tests {
+		var parts []string
+		for _, f := range s.Top(tt.n) {
+			parts = append(parts, f.Identifier.String()+strconv.Itoa(f.Count))
+		}
+		if got := strings.Join(parts, " "); got != tt.want {
+			t.Errorf("Top(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestStatsDecodeFromStopsOnLimit(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K Q R B"))
+	d.SetMaxTokens(2)
+
+	var s Stats
+	if err := s.DecodeFrom(d); !errors.Is(err, ErrTooManyTokens) {
+		t.Errorf("DecodeFrom() error = %v, want ErrTooManyTokens", err)
+	}
+	if s.Total() != 2 {
+		t.Errorf("Total() = %d, want 2", s.Total())
+	}
+}
+
+func TestStatsReadFromReadError(t *testing.T) {
+	var s Stats
+	readErr := errors.New("read failed")
+	if _, err := s.ReadFrom(io.MultiReader(strings.NewReader("K "), iotest.ErrReader(readErr))); err != readErr {
+		t.Errorf("ReadFrom() error = %v, want %v", err, readErr)
+	}
+	if s.Total() != 1 {
+		t.Errorf("Total() = %d, want 1", s.Total())
+	}
+}
+
+func TestStatsDecodeFromRecoveryMode(t *testing.T) {
+	d := NewDecoder(strings.NewReader("K ++ q 1 P"))
+	d.SetSkipInvalid(true)
+
+	var s Stats
+	if err := s.DecodeFrom(d); err != nil {
+		t.Fatalf("DecodeFrom() error = %v", err)
+	}
+	if s.Total() != 3 || s.Invalid() != 2 {
+		t.Errorf("Total, Invalid = %d, %d, want 3, 2", s.Total(), s.Invalid())
+	}
+}
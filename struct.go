@@ -0,0 +1,213 @@
+package pin
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError reports an invalid struct field found by ValidateStruct.
+type FieldError struct {
+	// Path is the location of the field, e.g. "Moves[2].Piece".
+	Path string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the error message, including the field path.
+func (e *FieldError) Error() string {
+	return "pin: field " + e.Path + ": " + strings.TrimPrefix(e.Err.Error(), "pin: ")
+}
+
+// Unwrap returns the underlying error, so errors.Is works with the sentinels.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors is the list of invalid fields returned by ValidateStruct.
+type FieldErrors []*FieldError
+
+// Error returns the messages of all field errors, separated by "; ".
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the field errors, so errors.Is and errors.As inspect each of them.
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fe := range e {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// ValidateStruct validates every field of the struct v tagged with `pin`.
+//
+// Tagged fields must be of type string, Identifier, or a slice or array of
+// those. The tag holds comma-separated options:
+//   - required: an empty string or zero Identifier is an error (ErrRequired);
+//     otherwise empty values are skipped
+//   - profile=<name>: the value must be allowed by the Profile with that
+//     name among profiles (ErrUnknownProfile if none matches)
+//
+// Untagged struct fields (and pointers to structs) are walked recursively, so
+// nested DTOs are validated too. Unexported fields are ignored.
+//
+// Example:
+//
+//	type Drop struct {
+//		Piece string `pin:"required,profile=shogi"`
+//	}
+//
+//	err := ValidateStruct(drop, shogi)
+//
+// Returns nil if all fields are valid, FieldErrors listing every invalid field
+// otherwise, or ErrNotStruct if v is not a struct or a pointer to a struct.
+func ValidateStruct(v any, profiles ...*Profile) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	w := structWalker{profiles: profiles}
+	w.walkStruct(rv, "")
+
+	if len(w.errs) > 0 {
+		return w.errs
+	}
+	return nil
+}
+
+// structWalker accumulates field errors while walking a struct.
+type structWalker struct {
+	profiles []*Profile
+	errs     FieldErrors
+}
+
+var identifierType = reflect.TypeOf(Identifier{})
+
+// walkStruct visits the exported fields of the struct rv.
+func (w *structWalker) walkStruct(rv reflect.Value, prefix string) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		path := sf.Name
+		if prefix != "" {
+			path = prefix + "." + sf.Name
+		}
+
+		tag, tagged := sf.Tag.Lookup("pin")
+		if !tagged {
+			w.walkNested(rv.Field(i), path)
+			continue
+		}
+
+		required, profile, err := w.parseTag(tag)
+		if err != nil {
+			w.fail(path, err)
+			continue
+		}
+		w.checkValue(rv.Field(i), path, required, profile)
+	}
+}
+
+// walkNested descends into untagged struct and pointer-to-struct fields.
+func (w *structWalker) walkNested(fv reflect.Value, path string) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return
+		}
+		fv = fv.Elem()
+	}
+	if fv.Kind() == reflect.Struct && fv.Type() != identifierType {
+		w.walkStruct(fv, path)
+	}
+}
+
+// parseTag interprets the options of a `pin` struct tag.
+func (w *structWalker) parseTag(tag string) (required bool, profile *Profile, err error) {
+	for _, opt := range strings.Split(tag, ",") {
+		switch {
+		case opt == "":
+		case opt == "required":
+			required = true
+		case strings.HasPrefix(opt, "profile="):
+			name := strings.TrimPrefix(opt, "profile=")
+			profile = w.lookupProfile(name)
+			if profile == nil {
+				return false, nil, ErrUnknownProfile
+			}
+		default:
+			return false, nil, ErrInvalidTag
+		}
+	}
+	return required, profile, nil
+}
+
+// lookupProfile returns the profile with the given name, or nil.
+func (w *structWalker) lookupProfile(name string) *Profile {
+	for _, p := range w.profiles {
+		if p != nil && p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// checkValue validates a tagged field value.
+func (w *structWalker) checkValue(fv reflect.Value, path string, required bool, profile *Profile) {
+	switch {
+	case fv.Type() == identifierType:
+		id := fv.Interface().(Identifier)
+		if id == (Identifier{}) {
+			if required {
+				w.fail(path, ErrRequired)
+			}
+			return
+		}
+		if err := profile.Check(id); err != nil {
+			w.fail(path, err)
+		}
+
+	case fv.Kind() == reflect.String:
+		s := fv.String()
+		if s == "" {
+			if required {
+				w.fail(path, ErrRequired)
+			}
+			return
+		}
+		id, err := Parse(s)
+		if err == nil {
+			err = profile.Check(id)
+		}
+		if err != nil {
+			w.fail(path, err)
+		}
+
+	case fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array:
+		for i := 0; i < fv.Len(); i++ {
+			w.checkValue(fv.Index(i), path+"["+strconv.Itoa(i)+"]", required, profile)
+		}
+
+	default:
+		w.fail(path, ErrUnsupportedFieldType)
+	}
+}
+
+// fail records a field error.
+func (w *structWalker) fail(path string, err error) {
+	w.errs = append(w.errs, &FieldError{Path: path, Err: err})
+}
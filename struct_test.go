@@ -0,0 +1,189 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+type dropRequest struct {
+	Piece    string        `pin:"required"`
+	Captured Identifier    `pin:""`
+	Hand     []string      `pin:"profile=shogi"`
+	Note     string        // untagged, ignored
+	Target   *dropTarget   // walked recursively
+	Promoted [2]Identifier `pin:"profile=shogi"`
+	hidden   string        `pin:"required"` // unexported, ignored
+}
+
+type dropTarget struct {
+	Occupant string `pin:"required"`
+}
+
+// ============================================================================
+// Valid Structs
+// ============================================================================
+
+func TestValidateStructValid(t *testing.T) {
+	shogi := NewProfile("shogi", "KRBGSNLP")
+
+	req := dropRequest{
+		Piece:    "+p",
+		Captured: MustParse("s"),
+		Hand:     []string{"P", "p", "G"},
+		Note:     "not a PIN",
+		Target:   &dropTarget{Occupant: "K^"},
+		Promoted: [2]Identifier{MustParse("+R"), MustParse("+b")},
+		hidden:   "",
+	}
+
+	if err := ValidateStruct(req, shogi); err != nil {
+		t.Errorf("ValidateStruct() = %v, want nil", err)
+	}
+	if err := ValidateStruct(&req, shogi); err != nil {
+		t.Errorf("ValidateStruct(&req) = %v, want nil", err)
+	}
+}
+
+func TestValidateStructSkipsOptionalEmptyValues(t *testing.T) {
+	type dto struct {
+		Piece  string     `pin:""`
+		Pieces Identifier `pin:""`
+	}
+
+	if err := ValidateStruct(dto{}); err != nil {
+		t.Errorf("ValidateStruct() = %v, want nil", err)
+	}
+}
+
+// ============================================================================
+// Invalid Structs
+// ============================================================================
+
+func TestValidateStructReportsAllFields(t *testing.T) {
+	shogi := NewProfile("shogi", "KRBGSNLP")
+
+	req := dropRequest{
+		Piece:    "",
+		Hand:     []string{"P", "Q", "*G"},
+		Target:   &dropTarget{Occupant: "KK"},
+		Promoted: [2]Identifier{MustParse("+R"), MustParse("+X")},
+	}
+
+	err := ValidateStruct(req, shogi)
+
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("ValidateStruct() = %v, want FieldErrors", err)
+	}
+
+	tests := []struct {
+		path string
+		err  error
+	}{
+		{"Piece", ErrRequired},
+		{"Hand[1]", ErrAbbrNotInProfile},
+		{"Hand[2]", ErrInvalidStateModifier},
+		{"Target.Occupant", ErrInvalidTerminalMarker},
+		{"Promoted[1]", ErrAbbrNotInProfile},
+	}
+
+	if len(fieldErrs) != len(tests) {
+		t.Fatalf("len(FieldErrors) = %d, want %d: %v", len(fieldErrs), len(tests), err)
+	}
+	for i, tt := range tests {
+		if fieldErrs[i].Path != tt.path {
+			t.Errorf("FieldErrors[%d].Path = %q, want %q", i, fieldErrs[i].Path, tt.path)
+		}
+		if !errors.Is(fieldErrs[i], tt.err) {
+			t.Errorf("FieldErrors[%d] = %v, want %v", i, fieldErrs[i], tt.err)
+		}
+	}
+}
+
+func TestValidateStructRequiredIdentifier(t *testing.T) {
+	type dto struct {
+		Piece Identifier `pin:"required"`
+	}
+
+	err := ValidateStruct(dto{})
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("ValidateStruct() = %v, want ErrRequired", err)
+	}
+}
+
+func TestValidateStructUnknownProfile(t *testing.T) {
+	type dto struct {
+		Piece string `pin:"profile=xiangqi"`
+	}
+
+	err := ValidateStruct(dto{Piece: "K"}, NewProfile("chess", "KQRBNP"))
+	if !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("ValidateStruct() = %v, want ErrUnknownProfile", err)
+	}
+}
+
+func TestValidateStructInvalidTag(t *testing.T) {
+	type dto struct {
+		Piece string `pin:"mandatory"`
+	}
+
+	err := ValidateStruct(dto{Piece: "K"})
+	if !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("ValidateStruct() = %v, want ErrInvalidTag", err)
+	}
+}
+
+func TestValidateStructUnsupportedFieldType(t *testing.T) {
+	type dto struct {
+		Count int `pin:"required"`
+	}
+
+	err := ValidateStruct(dto{Count: 1})
+	if !errors.Is(err, ErrUnsupportedFieldType) {
+		t.Errorf("ValidateStruct() = %v, want ErrUnsupportedFieldType", err)
+	}
+}
+
+func TestValidateStructNotStruct(t *testing.T) {
+	var nilPtr *dropRequest
+	inputs := []any{nil, "K", 42, []string{"K"}, nilPtr}
+
+	for _, input := range inputs {
+		if err := ValidateStruct(input); !errors.Is(err, ErrNotStruct) {
+			t.Errorf("ValidateStruct(%#v) = %v, want ErrNotStruct", input, err)
+		}
+	}
+}
+
+func TestValidateStructNilNestedPointer(t *testing.T) {
+	req := dropRequest{Piece: "K"}
+
+	if err := ValidateStruct(req, NewProfile("shogi", "KRBGSNLP")); err != nil {
+		t.Errorf("ValidateStruct() with nil Target = %v, want nil", err)
+	}
+}
+
+// ============================================================================
+// Error Message Tests
+// ============================================================================
+
+func TestFieldErrorMessage(t *testing.T) {
+	err := &FieldError{Path: "Target.Occupant", Err: ErrInvalidTerminalMarker}
+
+	want := "pin: field Target.Occupant: invalid terminal marker"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFieldErrorsMessage(t *testing.T) {
+	errs := FieldErrors{
+		{Path: "A", Err: ErrRequired},
+		{Path: "B", Err: ErrEmptyInput},
+	}
+
+	want := "pin: field A: required value is missing; pin: field B: empty input"
+	if errs.Error() != want {
+		t.Errorf("Error() = %q, want %q", errs.Error(), want)
+	}
+}
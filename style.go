@@ -0,0 +1,190 @@
+package pin
+
+// Style is a style name in the Style Name Notation (SNN), such as "CHESS"
+// or "shogi", naming the rules a player's pieces follow.
+//
+// A style name is an ASCII letter followed by letters or digits, all
+// letters sharing one case. As in PIN, the case encodes the side: an
+// uppercase name is the style of the first player, a lowercase name that
+// of the second. SNN and PIN compose: a Style qualifies an Identifier with
+// Qualify, giving the "CHESS:K" form of a QualifiedIdentifier.
+//
+// See https://sashite.dev/specs/snn/1.0.0/ for the specification.
+//
+// Style is an immutable value type, comparable with ==. Its zero value is
+// not valid.
+type Style struct {
+	name string
+}
+
+// NewStyle creates the Style named name for the side, converting the
+// letters of name to the case of the side.
+//
+// Panics with ErrInvalidStyle if name is not a valid style name in either
+// case, or with ErrInvalidSide if the side is invalid.
+func NewStyle(name string, side Side) Style {
+	if !isValidSide(side) {
+		panic(ErrInvalidSide)
+	}
+	if styleError(name) >= 0 {
+		panic(ErrInvalidStyle)
+	}
+	return Style{name: styleCase(name, side)}
+}
+
+// ParseStyle converts an SNN string, such as "CHESS" or "shogi", into a
+// Style.
+//
+// Returns a *SyntaxError wrapping ErrInvalidStyle if s is empty, contains a
+// byte other than a letter or a digit, starts with a digit, or mixes letter
+// cases.
+func ParseStyle(s string) (Style, error) {
+	if i := snnError(s); i >= 0 {
+		return Style{}, newSyntaxError(s, i, ErrInvalidStyle)
+	}
+	return Style{name: s}, nil
+}
+
+// MustParseStyle is like ParseStyle but panics on error.
+// Use for constants or trusted input.
+func MustParseStyle(s string) Style {
+	st, err := ParseStyle(s)
+	if err != nil {
+		panic(err)
+	}
+	return st
+}
+
+// snnError returns the offset of the first byte of name breaking the SNN
+// syntax, or -1 if it is valid.
+func snnError(name string) int {
+	if i := styleError(name); i >= 0 {
+		return i
+	}
+
+	// All letters share the case of the first one
+	upper := isUpperASCII(name[0])
+	for i := 1; i < len(name); i++ {
+		if b := name[i]; b >= 'A' && isUpperASCII(b) != upper {
+			return i
+		}
+	}
+	return -1
+}
+
+// styleCase returns name with its letters converted to the case of side.
+func styleCase(name string, side Side) string {
+	b := []byte(name)
+	for i, c := range b {
+		switch {
+		case side == First && c >= 'a' && c <= 'z':
+			b[i] = c - 'a' + 'A'
+		case side == Second && c >= 'A' && c <= 'Z':
+			b[i] = c - 'A' + 'a'
+		}
+	}
+	return string(b)
+}
+
+// isUpperASCII reports whether b is an uppercase ASCII letter.
+func isUpperASCII(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+// Name returns the style name, as written.
+func (st Style) Name() string {
+	return st.name
+}
+
+// Side returns the side encoded by the case of the style name.
+func (st Style) Side() Side {
+	if st.name != "" && !isUpperASCII(st.name[0]) {
+		return Second
+	}
+	return First
+}
+
+// IsZero reports whether st is the zero value, which is not a valid Style.
+func (st Style) IsZero() bool {
+	return st.name == ""
+}
+
+// WithSide returns the same style for the given side.
+//
+// Panics with ErrInvalidSide if the side is invalid.
+func (st Style) WithSide(side Side) Style {
+	if !isValidSide(side) {
+		panic(ErrInvalidSide)
+	}
+	if st.IsZero() || st.Side() == side {
+		return st
+	}
+	return Style{name: styleCase(st.name, side)}
+}
+
+// Flip returns the same style for the opposite side.
+func (st Style) Flip() Style {
+	if st.Side() == First {
+		return st.WithSide(Second)
+	}
+	return st.WithSide(First)
+}
+
+// SameStyle reports whether st and other name the same style, whatever
+// their sides.
+func (st Style) SameStyle(other Style) bool {
+	return st.WithSide(First) == other.WithSide(First)
+}
+
+// Qualify returns id qualified by the style.
+//
+// Panics with ErrInvalidStyle for the zero Style, or with
+// ErrInvalidIdentifier if id is not valid.
+func (st Style) Qualify(id Identifier) QualifiedIdentifier {
+	return NewQualifiedIdentifier(st.name, id)
+}
+
+// Matches reports whether the style and id belong to the same side, as
+// when a player's pieces are qualified by that player's style.
+func (st Style) Matches(id Identifier) bool {
+	return !st.IsZero() && st.Side() == id.Side()
+}
+
+// String returns the style name.
+func (st Style) String() string {
+	return st.name
+}
+
+// AppendTo appends the style name to dst and returns the result.
+func (st Style) AppendTo(dst []byte) []byte {
+	return append(dst, st.name...)
+}
+
+// MarshalText returns the style name, implementing encoding.TextMarshaler.
+//
+// Returns ErrInvalidStyle for the zero value.
+func (st Style) MarshalText() ([]byte, error) {
+	if st.IsZero() {
+		return nil, ErrInvalidStyle
+	}
+	return st.AppendTo(nil), nil
+}
+
+// UnmarshalText parses an SNN string, implementing encoding.TextUnmarshaler.
+func (st *Style) UnmarshalText(text []byte) error {
+	parsed, err := ParseStyle(string(text))
+	if err != nil {
+		return err
+	}
+
+	*st = parsed
+	return nil
+}
+
+// SNN returns the style of q as a Style.
+//
+// Returns a *SyntaxError wrapping ErrInvalidStyle if the style of q mixes
+// letter cases, which QualifiedIdentifier allows but SNN does not.
+func (q QualifiedIdentifier) SNN() (Style, error) {
+	return ParseStyle(q.style)
+}
@@ -0,0 +1,167 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		input string
+		side  Side
+	}{
+		{"C", First},
+		{"CHESS", First},
+		{"shogi", Second},
+		{"CHESS960", First},
+		{"x9", Second},
+	}
+
+	for _, tt := range tests {
+		st, err := ParseStyle(tt.input)
+		if err != nil {
+			t.Errorf("ParseStyle(%q) error = %v", tt.input, err)
+			continue
+		}
+		if st.Name() != tt.input || st.String() != tt.input || st.Side() != tt.side {
+			t.Errorf("ParseStyle(%q) = %q, %v, want %q, %v", tt.input, st.Name(), st.Side(), tt.input, tt.side)
+		}
+	}
+}
+
+func TestParseStyleErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{"", 0},
+		{"9X", 0},
+		{"Chess", 1},
+		{"cHESS", 1},
+		{"CHESS-960", 5},
+		{"ÉCHECS", 0},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseStyle(tt.input)
+
+		var se *SyntaxError
+		if !errors.As(err, &se) || !errors.Is(err, ErrInvalidStyle) {
+			t.Errorf("ParseStyle(%q) error = %v, want *SyntaxError wrapping ErrInvalidStyle", tt.input, err)
+			continue
+		}
+		if se.Offset != tt.offset {
+			t.Errorf("ParseStyle(%q) error at offset %d, want %d", tt.input, se.Offset, tt.offset)
+		}
+	}
+}
+
+func TestNewStyle(t *testing.T) {
+	tests := []struct {
+		name string
+		side Side
+		want string
+	}{
+		{"chess", First, "CHESS"},
+		{"Shogi", Second, "shogi"},
+		{"Chess960", First, "CHESS960"},
+		{"x", Second, "x"},
+	}
+
+	for _, tt := range tests {
+		if got := NewStyle(tt.name, tt.side); got.Name() != tt.want || got.Side() != tt.side {
+			t.Errorf("NewStyle(%q, %v) = %q, want %q", tt.name, tt.side, got.Name(), tt.want)
+		}
+	}
+}
+
+func TestNewStylePanics(t *testing.T) {
+	tests := []struct {
+		name string
+		side Side
+		want error
+	}{
+		{"", First, ErrInvalidStyle},
+		{"9X", First, ErrInvalidStyle},
+		{"CHESS", Side(2), ErrInvalidSide},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("NewStyle(%q, %v) panic = %v, want %v", tt.name, tt.side, r, tt.want)
+				}
+			}()
+			NewStyle(tt.name, tt.side)
+		}()
+	}
+}
+
+func TestStyleSides(t *testing.T) {
+	chess := MustParseStyle("CHESS")
+
+	if got := chess.Flip(); got.Name() != "chess" || got.Side() != Second {
+		t.Errorf("Flip() = %q, want \"chess\"", got.Name())
+	}
+	if got := chess.Flip().Flip(); got != chess {
+		t.Errorf("Flip().Flip() = %q, want %q", got.Name(), chess.Name())
+	}
+	if got := chess.WithSide(First); got != chess {
+		t.Errorf("WithSide(First) = %q, want %q", got.Name(), chess.Name())
+	}
+	if !chess.SameStyle(MustParseStyle("chess")) || chess.SameStyle(MustParseStyle("SHOGI")) {
+		t.Error("SameStyle() reports wrongly")
+	}
+}
+
+func TestStyleZero(t *testing.T) {
+	var st Style
+	if !st.IsZero() || MustParseStyle("C").IsZero() {
+		t.Error("IsZero() reports wrongly")
+	}
+	if _, err := st.MarshalText(); err != ErrInvalidStyle {
+		t.Errorf("MarshalText() on zero value error = %v, want ErrInvalidStyle", err)
+	}
+	if st.Matches(MustParse("K")) {
+		t.Error("zero Style Matches(K) = true, want false")
+	}
+}
+
+func TestStyleQualify(t *testing.T) {
+	shogi := MustParseStyle("S")
+
+	q := shogi.Qualify(MustParse("+P"))
+	if q != MustParseQualified("S:+P") {
+		t.Errorf("Qualify(+P) = %v, want S:+P", q)
+	}
+
+	got, err := q.SNN()
+	if err != nil || got != shogi {
+		t.Errorf("SNN() = %v, %v, want %v", got, err, shogi)
+	}
+
+	if !shogi.Matches(MustParse("+P")) || shogi.Matches(MustParse("+p")) {
+		t.Error("Matches() reports wrongly")
+	}
+}
+
+func TestQualifiedSNNMixedCase(t *testing.T) {
+	q := MustParseQualified("Shogi:K")
+	if _, err := q.SNN(); !errors.Is(err, ErrInvalidStyle) {
+		t.Errorf("SNN() error = %v, want ErrInvalidStyle", err)
+	}
+}
+
+func TestStyleText(t *testing.T) {
+	var st Style
+	if err := st.UnmarshalText([]byte("xiangqi")); err != nil || st.Name() != "xiangqi" {
+		t.Errorf("UnmarshalText() = %q, %v", st.Name(), err)
+	}
+	if err := st.UnmarshalText([]byte("Xiangqi")); !errors.Is(err, ErrInvalidStyle) || st.Name() != "xiangqi" {
+		t.Errorf("UnmarshalText(invalid) = %q, %v; want unchanged and ErrInvalidStyle", st.Name(), err)
+	}
+	if text, err := st.MarshalText(); err != nil || string(text) != "xiangqi" {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+}
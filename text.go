@@ -0,0 +1,50 @@
+package pin
+
+import "io"
+
+// MarshalText returns the PIN string representation, implementing
+// encoding.TextMarshaler. It makes identifiers usable as JSON map keys and
+// with other text-based encoders.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero value).
+func (id Identifier) MarshalText() ([]byte, error) {
+	return id.AppendText(make([]byte, 0, MaxStringLength))
+}
+
+// AppendText appends the PIN string representation to b, implementing
+// encoding.TextAppender.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero value).
+func (id Identifier) AppendText(b []byte) ([]byte, error) {
+	if !id.isValid() {
+		return b, ErrInvalidIdentifier
+	}
+	return id.AppendTo(b), nil
+}
+
+// WriteTo writes the PIN string representation to w, implementing
+// io.WriterTo, so identifiers compose with io pipelines directly. Writers
+// implementing io.StringWriter receive the precomputed string without
+// allocation.
+//
+// Returns ErrInvalidIdentifier for an invalid Identifier (e.g., the zero
+// value), writing nothing.
+func (id Identifier) WriteTo(w io.Writer) (int64, error) {
+	if !id.isValid() {
+		return 0, ErrInvalidIdentifier
+	}
+	n, err := io.WriteString(w, identifierStrings[id.index()])
+	return int64(n), err
+}
+
+// UnmarshalText parses a PIN string, implementing encoding.TextUnmarshaler.
+// Invalid input returns a *SyntaxError wrapping the parsing sentinels.
+func (id *Identifier) UnmarshalText(text []byte) error {
+	parsed, err := ParseBytes(text)
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
@@ -0,0 +1,133 @@
+package pin
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = Identifier{}
+	_ encoding.TextUnmarshaler = (*Identifier)(nil)
+	_ io.WriterTo              = Identifier{}
+)
+
+func TestMarshalText(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		id := fromIndex(i)
+
+		got, err := id.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText(%s) error = %v", id, err)
+			continue
+		}
+		if string(got) != id.String() {
+			t.Errorf("MarshalText(%s) = %q", id, got)
+		}
+
+		var back Identifier
+		if err := back.UnmarshalText(got); err != nil || back != id {
+			t.Errorf("UnmarshalText(%q) = %s, %v, want %s", got, back, err, id)
+		}
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	var b strings.Builder
+	for _, id := range All() {
+		b.Reset()
+		n, err := id.WriteTo(&b)
+		if err != nil || b.String() != id.String() || n != int64(b.Len()) {
+			t.Errorf("WriteTo(%s) = %d, %v, wrote %q", id, n, err, b.String())
+		}
+	}
+}
+
+func TestWriteToInvalid(t *testing.T) {
+	var b strings.Builder
+	if n, err := (Identifier{}).WriteTo(&b); n != 0 || err != ErrInvalidIdentifier || b.Len() != 0 {
+		t.Errorf("WriteTo(zero) = %d, %v, wrote %q, want 0, ErrInvalidIdentifier", n, err, b.String())
+	}
+}
+
+func TestWriteToMultiWriter(t *testing.T) {
+	var a, b strings.Builder
+	if _, err := MustParse("+K^").WriteTo(io.MultiWriter(&a, &b)); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if a.String() != "+K^" || b.String() != "+K^" {
+		t.Errorf("WriteTo(MultiWriter) wrote %q and %q, want \"+K^\"", a.String(), b.String())
+	}
+}
+
+func TestWriteToError(t *testing.T) {
+	if _, err := MustParse("K").WriteTo(errWriter{}); err == nil {
+		t.Error("WriteTo() to a failing writer returned nil error")
+	}
+}
+
+func TestMarshalTextInvalid(t *testing.T) {
+	if _, err := (Identifier{}).MarshalText(); err != ErrInvalidIdentifier {
+		t.Errorf("MarshalText(zero) error = %v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	got, err := MustParse("+k^").AppendText([]byte("pieces: "))
+	if err != nil {
+		t.Fatalf("AppendText() error = %v", err)
+	}
+	if string(got) != "pieces: +k^" {
+		t.Errorf("AppendText() = %q, want %q", got, "pieces: +k^")
+	}
+
+	got, err = (Identifier{}).AppendText([]byte("x"))
+	if err != ErrInvalidIdentifier || string(got) != "x" {
+		t.Errorf("AppendText(zero) = %q, %v, want \"x\", ErrInvalidIdentifier", got, err)
+	}
+}
+
+func TestUnmarshalTextErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", ErrEmptyInput},
+		{"KKKK", ErrInputTooLong},
+		{"K+", ErrInvalidTerminalMarker},
+		{"1", ErrMustContainOneLetter},
+	}
+
+	for _, tt := range tests {
+		var id Identifier
+		if err := id.UnmarshalText([]byte(tt.input)); !errors.Is(err, tt.want) {
+			t.Errorf("UnmarshalText(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestTextJSONMapKeys(t *testing.T) {
+	counts := map[Identifier]int{
+		MustParse("P"):  8,
+		MustParse("+p"): 1,
+	}
+
+	data, err := json.Marshal(counts)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"+p":1,"P":8}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var back map[Identifier]int
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(back) != 2 || back[MustParse("P")] != 8 || back[MustParse("+p")] != 1 {
+		t.Errorf("Unmarshal() = %v, want %v", back, counts)
+	}
+}
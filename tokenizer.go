@@ -0,0 +1,121 @@
+package pin
+
+import (
+	"io"
+	"strings"
+)
+
+// Token is a PIN found in text by a Tokenizer.
+type Token struct {
+	// ID is the parsed identifier.
+	ID Identifier
+	// Offset is the byte offset of the token in the text.
+	Offset int
+}
+
+// Tokenizer extracts the PIN tokens embedded in text, such as FEEN rows,
+// move lists, or logs.
+//
+// Tokens are matched greedily and need no delimiters: "+rnb^" yields +r, n,
+// and b^. Bytes that cannot start a PIN token are handed to the skip policy,
+// which by default skips them all.
+//
+// The Tokenizer does not understand move or coordinate syntax, so by
+// default the move "e2-e4" yields e and -e. In move lists and prose, call
+// SetWholeWords to accept only tokens standing alone.
+type Tokenizer struct {
+	text  string
+	pos   int
+	skip  func(b byte) bool
+	whole bool
+
+	// line is the current line, counted from 1; lineStart is the offset
+	// at which it starts.
+	line      int
+	lineStart int
+}
+
+// NewTokenizer returns a Tokenizer over text that skips every byte outside
+// PIN tokens.
+func NewTokenizer(text string) *Tokenizer {
+	return &Tokenizer{text: text, skip: SkipAny, line: 1}
+}
+
+// SetSkip sets the skip policy: skip reports whether a byte outside PIN
+// tokens may be skipped. A nil skip restores the default, SkipAny.
+func (t *Tokenizer) SetSkip(skip func(b byte) bool) {
+	if skip == nil {
+		skip = SkipAny
+	}
+	t.skip = skip
+}
+
+// SetWholeWords sets whether tokens must stand alone. When whole is true, a
+// token is accepted only if it is neither preceded nor followed by a letter,
+// a digit, or one of "+-^", so that in "1. e2-e4 K^" only K^ is a token.
+// The bytes of rejected matches are handed to the skip policy.
+func (t *Tokenizer) SetWholeWords(whole bool) {
+	t.whole = whole
+}
+
+// isWordByte reports whether b may be part of a word around a PIN token.
+func isWordByte(b byte) bool {
+	return b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9' ||
+		b == enhancedPrefix || b == diminishedPrefix || b == terminalSuffix
+}
+
+// standsAlone reports whether the n bytes at start of the text form a word.
+func (t *Tokenizer) standsAlone(start, n int) bool {
+	end := start + n
+	return (start == 0 || !isWordByte(t.text[start-1])) &&
+		(end == len(t.text) || !isWordByte(t.text[end]))
+}
+
+// SkipAny is the skip policy accepting every byte.
+func SkipAny(byte) bool {
+	return true
+}
+
+// SkipOnly returns a skip policy accepting only the bytes of chars, such as
+// "/0123456789" for FEEN rows.
+func SkipOnly(chars string) func(b byte) bool {
+	return func(b byte) bool {
+		return strings.IndexByte(chars, b) >= 0
+	}
+}
+
+// Next returns the next token in the text.
+//
+// It returns io.EOF when no tokens remain. A byte rejected by the skip
+// policy is reported as a *DecodeError wrapping ErrUnexpectedByte; the
+// Tokenizer moves past it, so tokenizing may continue.
+func (t *Tokenizer) Next() (Token, error) {
+	for t.pos < len(t.text) {
+		start := t.pos
+
+		id, n, err := parsePrefix(t.text[start:])
+		if err == nil && (!t.whole || t.standsAlone(start, n)) {
+			t.pos += n
+			return Token{ID: id, Offset: start}, nil
+		}
+
+		line, column := t.line, start-t.lineStart+1
+		t.pos++
+		b := t.text[start]
+		if b == '\n' {
+			t.line++
+			t.lineStart = t.pos
+		}
+		if !t.skip(b) {
+			return Token{}, &DecodeError{
+				Offset: int64(start),
+				Line:   line,
+				Column: column,
+				Token:  t.text[start : start+1],
+				Err:    ErrUnexpectedByte,
+			}
+		}
+	}
+
+	return Token{}, io.EOF
+}
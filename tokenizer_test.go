@@ -0,0 +1,159 @@
+package pin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// tokenizeAll collects the tokens as "PIN@offset" until io.EOF, stopping at
+// the first error.
+func tokenizeAll(tz *Tokenizer) ([]string, error) {
+	var out []string
+	for {
+		tok, err := tz.Next()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, tok.ID.String()+"@"+strconv.Itoa(tok.Offset))
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"123/", nil},
+		{"K", []string{"K@0"}},
+		{"+rnb^", []string{"+r@0", "n@2", "b^@3"}},
+		{"+r1k^/8/-P7", []string{"+r@0", "k^@3", "-P@8"}},
+		{"1. e4 e5", []string{"e@3", "e@6"}},
+		{"++K ^ --", []string{"+K@1"}},
+		{"é K", []string{"K@3"}},
+	}
+
+	for _, tt := range tests {
+		got, err := tokenizeAll(NewTokenizer(tt.input))
+		if err != nil {
+			t.Errorf("Next(%q) error = %v", tt.input, err)
+			continue
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("tokens of %q = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTokenizerWholeWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"1. e2-e4 e7-e5 2. Nf3 Nc6", nil},
+		{"1. e2-e4 K^", []string{"K^@9"}},
+		{"K^, +r (-p). Q", []string{"K^@0", "+r@4", "-p@8", "Q@13"}},
+		{"++K Kx xK +rnb^", nil},
+	}
+
+	for _, tt := range tests {
+		tz := NewTokenizer(tt.input)
+		tz.SetWholeWords(true)
+
+		got, err := tokenizeAll(tz)
+		if err != nil {
+			t.Errorf("Next(%q) error = %v", tt.input, err)
+			continue
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("whole-word tokens of %q = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	// Without whole words, moves are split into identifiers
+	got, _ := tokenizeAll(NewTokenizer("e2-e4"))
+	if strings.Join(got, " ") != "e@0 -e@2" {
+		t.Errorf("tokens of e2-e4 = %v, want [e@0 -e@2]", got)
+	}
+}
+
+func TestTokenizerWholeWordsSkipPolicy(t *testing.T) {
+	tz := NewTokenizer("K Kx")
+	tz.SetWholeWords(true)
+	tz.SetSkip(SkipOnly(" "))
+
+	tok, err := tz.Next()
+	if err != nil || tok.ID != MustParse("K") {
+		t.Fatalf("Next() = %v, %v, want K", tok, err)
+	}
+
+	// The bytes of a rejected match go to the skip policy
+	var de *DecodeError
+	if _, err := tz.Next(); !errors.As(err, &de) || de.Token != "K" || de.Offset != 2 {
+		t.Errorf("Next() error = %v, want ErrUnexpectedByte for K at offset 2", err)
+	}
+}
+
+func TestTokenizerSkipOnly(t *testing.T) {
+	tz := NewTokenizer("+r1k/8*P")
+	tz.SetSkip(SkipOnly("/0123456789"))
+
+	got, err := tokenizeAll(tz)
+	if strings.Join(got, " ") != "+r@0 k@3" {
+		t.Errorf("tokens = %v, want [+r@0 k@3]", got)
+	}
+
+	var de *DecodeError
+	if !errors.As(err, &de) || !errors.Is(err, ErrUnexpectedByte) {
+		t.Fatalf("Next() error = %v, want *DecodeError wrapping ErrUnexpectedByte", err)
+	}
+	if de.Offset != 6 || de.Token != "*" {
+		t.Errorf("DecodeError = %+v, want offset 6 and token \"*\"", de)
+	}
+
+	// Tokenizing continues after the rejected byte
+	if tok, err := tz.Next(); err != nil || tok.ID.String() != "P" || tok.Offset != 7 {
+		t.Errorf("Next() after error = %+v, %v; want P at offset 7", tok, err)
+	}
+}
+
+func TestTokenizerErrorLineColumn(t *testing.T) {
+	tz := NewTokenizer("rnbqk/8\nPPP*P\n#")
+	tz.SetSkip(SkipOnly("/0123456789"))
+
+	var got []string
+	for {
+		_, err := tz.Next()
+		if err == io.EOF {
+			break
+		}
+		var de *DecodeError
+		if errors.As(err, &de) {
+			got = append(got, fmt.Sprintf("%q@%d:%d", de.Token, de.Line, de.Column))
+		}
+	}
+
+	// Newlines are rejected too, at the end of the line they close
+	want := `"\n"@1:8 "*"@2:4 "\n"@2:6 "#"@3:1`
+	if strings.Join(got, " ") != want {
+		t.Errorf("errors = %v, want %s", got, want)
+	}
+}
+
+func TestTokenizerSetSkipNil(t *testing.T) {
+	tz := NewTokenizer("K * q")
+	tz.SetSkip(SkipOnly(""))
+	tz.SetSkip(nil)
+
+	got, err := tokenizeAll(tz)
+	if err != nil || strings.Join(got, " ") != "K@0 q@4" {
+		t.Errorf("tokens = %v, %v; want [K@0 q@4]", got, err)
+	}
+}
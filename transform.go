@@ -0,0 +1,150 @@
+package pin
+
+// Transform is a function rewriting the attributes of an Identifier.
+//
+// Transforms are pure: they take an Identifier by value and return a new one.
+// Any method of Identifier with the signature func() Identifier can be used
+// as a Transform through a method expression, e.g. Transform(Identifier.Flip).
+type Transform func(Identifier) Identifier
+
+// Compose returns a Transform applying each of ts in order, from left to right.
+// Compose with no arguments returns the identity Transform.
+//
+// Example:
+//
+//	capture := Compose(FlipT, NormalizeT)
+//	capture(MustParse("+P")) // "p"
+func Compose(ts ...Transform) Transform {
+	// Copy so that later changes to the caller's slice have no effect
+	ts = append([]Transform(nil), ts...)
+
+	return func(id Identifier) Identifier {
+		for _, t := range ts {
+			id = t(id)
+		}
+		return id
+	}
+}
+
+// Apply returns a new slice holding the result of t for each element of ids.
+// The input slice is left unchanged.
+func (t Transform) Apply(ids []Identifier) []Identifier {
+	out := make([]Identifier, len(ids))
+	for i, id := range ids {
+		out[i] = t(id)
+	}
+	return out
+}
+
+// ============================================================================
+// Built-in Transforms
+// ============================================================================
+
+// IdentityT returns id unchanged.
+func IdentityT(id Identifier) Identifier {
+	return id
+}
+
+// EnhanceT is the Transform form of Identifier.Enhance.
+func EnhanceT(id Identifier) Identifier {
+	return id.Enhance()
+}
+
+// DiminishT is the Transform form of Identifier.Diminish.
+func DiminishT(id Identifier) Identifier {
+	return id.Diminish()
+}
+
+// NormalizeT is the Transform form of Identifier.Normalize.
+func NormalizeT(id Identifier) Identifier {
+	return id.Normalize()
+}
+
+// FlipT is the Transform form of Identifier.Flip.
+func FlipT(id Identifier) Identifier {
+	return id.Flip()
+}
+
+// TerminalT is the Transform form of Identifier.Terminal.
+func TerminalT(id Identifier) Identifier {
+	return id.Terminal()
+}
+
+// NonTerminalT is the Transform form of Identifier.NonTerminal.
+func NonTerminalT(id Identifier) Identifier {
+	return id.NonTerminal()
+}
+
+// CapturedT returns the Identifier a piece takes once captured: it changes
+// side and loses its state modifier, as in shogi where captured pieces go to
+// the capturer's hand unpromoted.
+func CapturedT(id Identifier) Identifier {
+	return id.Flip().Normalize()
+}
+
+// ============================================================================
+// Bulk Transformations
+// ============================================================================
+
+// MapInPlace replaces each element of ids with the result of t, in a single
+// pass and without allocating.
+func MapInPlace(ids []Identifier, t Transform) {
+	for i, id := range ids {
+		ids[i] = t(id)
+	}
+}
+
+// MapIdentifiers returns a new slice holding the result of t for each
+// element of ids, like t.Apply. Plain functions convert to Transform, so
+// closures can be passed directly.
+func MapIdentifiers(ids []Identifier, t Transform) []Identifier {
+	return t.Apply(ids)
+}
+
+// Filter returns a new slice holding the elements of ids for which pred
+// returns true, in order. The input slice is left unchanged.
+//
+// Example:
+//
+//	Filter(ids, Identifier.IsSecondPlayer) // second-player pieces only
+func Filter(ids []Identifier, pred func(Identifier) bool) []Identifier {
+	out := make([]Identifier, 0, len(ids))
+	for _, id := range ids {
+		if pred(id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// Unique returns a new slice holding the first occurrence of each element
+// of ids, in order. Unlike slices.Compact, duplicates need not be adjacent.
+// The input slice is left unchanged.
+//
+// Panics if any Identifier is not valid (e.g., the zero value).
+func Unique(ids []Identifier) []Identifier {
+	var seen Set
+	out := make([]Identifier, 0, len(ids))
+	for _, id := range ids {
+		if !seen.Contains(id) {
+			seen.Add(id)
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// FlipAll switches the side of every element of ids in place.
+// This mirrors a collection of pieces between the two players.
+func FlipAll(ids []Identifier) {
+	for i, id := range ids {
+		ids[i] = id.Flip()
+	}
+}
+
+// NormalizeAll resets the state of every element of ids to Normal in place.
+func NormalizeAll(ids []Identifier) {
+	for i, id := range ids {
+		ids[i] = id.Normalize()
+	}
+}
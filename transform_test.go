@@ -0,0 +1,221 @@
+package pin
+
+import "testing"
+
+// ============================================================================
+// Built-in Transform Tests
+// ============================================================================
+
+func TestBuiltinTransforms(t *testing.T) {
+	tests := []struct {
+		name string
+		t    Transform
+		in   string
+		want string
+	}{
+		{"IdentityT", IdentityT, "+K^", "+K^"},
+		{"EnhanceT", EnhanceT, "K", "+K"},
+		{"DiminishT", DiminishT, "K", "-K"},
+		{"NormalizeT", NormalizeT, "+K", "K"},
+		{"FlipT", FlipT, "K", "k"},
+		{"TerminalT", TerminalT, "K", "K^"},
+		{"NonTerminalT", NonTerminalT, "K^", "K"},
+		{"CapturedT", CapturedT, "+P", "p"},
+		{"CapturedT", CapturedT, "-r", "R"},
+	}
+
+	for _, tt := range tests {
+		got := tt.t(MustParse(tt.in)).String()
+		if got != tt.want {
+			t.Errorf("%s(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTransformFromMethodExpression(t *testing.T) {
+	flip := Transform(Identifier.Flip)
+
+	if got := flip(MustParse("K")).String(); got != "k" {
+		t.Errorf("Transform(Identifier.Flip)(K) = %q, want \"k\"", got)
+	}
+}
+
+// ============================================================================
+// Compose Tests
+// ============================================================================
+
+func TestComposeAppliesLeftToRight(t *testing.T) {
+	// Enhance then Normalize yields a normal piece; the reverse yields an enhanced one
+	if got := Compose(EnhanceT, NormalizeT)(MustParse("K")).String(); got != "K" {
+		t.Errorf("Compose(EnhanceT, NormalizeT)(K) = %q, want \"K\"", got)
+	}
+	if got := Compose(NormalizeT, EnhanceT)(MustParse("K")).String(); got != "+K" {
+		t.Errorf("Compose(NormalizeT, EnhanceT)(K) = %q, want \"+K\"", got)
+	}
+}
+
+func TestComposeEmptyIsIdentity(t *testing.T) {
+	id := MustParse("-q^")
+
+	if got := Compose()(id); got != id {
+		t.Errorf("Compose()(%s) = %s, want %s", id, got, id)
+	}
+}
+
+func TestComposeIsNotAffectedByCallerSlice(t *testing.T) {
+	ts := []Transform{FlipT}
+	flip := Compose(ts...)
+	ts[0] = EnhanceT
+
+	if got := flip(MustParse("K")).String(); got != "k" {
+		t.Errorf("composed transform = %q, want \"k\"", got)
+	}
+}
+
+// ============================================================================
+// Apply Tests
+// ============================================================================
+
+func TestTransformApply(t *testing.T) {
+	ids := []Identifier{MustParse("K"), MustParse("+p"), MustParse("R^")}
+
+	got := Transform(CapturedT).Apply(ids)
+
+	want := []string{"k", "P", "r^"}
+	for i, id := range got {
+		if id.String() != want[i] {
+			t.Errorf("Apply()[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+
+	// Input is unchanged
+	if ids[1].String() != "+p" {
+		t.Errorf("Apply() modified input: ids[1] = %q, want \"+p\"", ids[1].String())
+	}
+}
+
+func TestTransformApplyEmpty(t *testing.T) {
+	got := Transform(FlipT).Apply(nil)
+	if len(got) != 0 {
+		t.Errorf("Apply(nil) length = %d, want 0", len(got))
+	}
+}
+
+// ============================================================================
+// Bulk Transformation Tests
+// ============================================================================
+
+func TestMapInPlace(t *testing.T) {
+	ids := []Identifier{MustParse("K"), MustParse("+p"), MustParse("R^")}
+
+	MapInPlace(ids, Compose(FlipT, TerminalT))
+
+	want := []string{"k^", "+P^", "r^"}
+	for i, id := range ids {
+		if id.String() != want[i] {
+			t.Errorf("ids[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+}
+
+func TestMapIdentifiers(t *testing.T) {
+	ids := []Identifier{MustParse("K"), MustParse("+p")}
+
+	got := MapIdentifiers(ids, func(id Identifier) Identifier { return id.Terminal() })
+
+	want := []string{"K^", "+p^"}
+	for i, id := range got {
+		if id.String() != want[i] {
+			t.Errorf("MapIdentifiers()[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+	if ids[0].String() != "K" {
+		t.Errorf("MapIdentifiers() modified input: ids[0] = %q", ids[0].String())
+	}
+}
+
+func TestFilter(t *testing.T) {
+	ids := []Identifier{MustParse("K"), MustParse("+p"), MustParse("r^"), MustParse("Q")}
+
+	got := Filter(ids, Identifier.IsSecondPlayer)
+
+	want := []string{"+p", "r^"}
+	if len(got) != len(want) {
+		t.Fatalf("Filter() = %v, want %v", got, want)
+	}
+	for i, id := range got {
+		if id.String() != want[i] {
+			t.Errorf("Filter()[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+
+	if got := Filter(ids, func(Identifier) bool { return false }); len(got) != 0 {
+		t.Errorf("Filter(none) = %v, want empty", got)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	ids := []Identifier{MustParse("p"), MustParse("K"), MustParse("p"), MustParse("+p"), MustParse("K"), MustParse("p")}
+
+	got := Unique(ids)
+
+	want := []string{"p", "K", "+p"}
+	if len(got) != len(want) {
+		t.Fatalf("Unique() = %v, want %v", got, want)
+	}
+	for i, id := range got {
+		if id.String() != want[i] {
+			t.Errorf("Unique()[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+	if len(ids) != 6 || ids[2].String() != "p" {
+		t.Errorf("Unique() modified input: %v", ids)
+	}
+}
+
+func TestUniquePanicsOnZeroIdentifier(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrInvalidIdentifier {
+			t.Errorf("Unique([zero]) panic = %v, want ErrInvalidIdentifier", r)
+		}
+	}()
+
+	Unique([]Identifier{{}})
+}
+
+func TestFlipAll(t *testing.T) {
+	ids := []Identifier{MustParse("K"), MustParse("+p"), MustParse("-R^")}
+
+	FlipAll(ids)
+
+	want := []string{"k", "+P", "-r^"}
+	for i, id := range ids {
+		if id.String() != want[i] {
+			t.Errorf("ids[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+}
+
+func TestNormalizeAll(t *testing.T) {
+	ids := []Identifier{MustParse("K"), MustParse("+p"), MustParse("-R^")}
+
+	NormalizeAll(ids)
+
+	want := []string{"K", "p", "R^"}
+	for i, id := range ids {
+		if id.String() != want[i] {
+			t.Errorf("ids[%d] = %q, want %q", i, id.String(), want[i])
+		}
+	}
+}
+
+func TestBulkTransformationsOnEmptySlice(t *testing.T) {
+	// Must not panic
+	MapInPlace(nil, FlipT)
+	FlipAll(nil)
+	NormalizeAll([]Identifier{})
+
+	if len(Filter(nil, Identifier.IsNormal)) != 0 || len(Unique(nil)) != 0 || len(MapIdentifiers(nil, FlipT)) != 0 {
+		t.Error("helpers on nil returned a non-empty slice")
+	}
+}
@@ -0,0 +1,261 @@
+package pin
+
+import "strings"
+
+// Separators of the string form of a Transition.
+const (
+	cellSeparator       = '@'
+	transitionSeparator = '>'
+)
+
+// Transition describes how a piece changes the board: the identifier it
+// has before and after, and the cells it moves from and to.
+//
+// Three kinds of transitions are described:
+//   - a move, such as "P@e2>P@e4", keeps the identifier;
+//   - a promotion, such as "P@e7>+P@e8", changes it, the side excepted;
+//   - a drop, such as "P@e5", puts a piece from hand on an empty cell, and
+//     has no source cell.
+//
+// A move or promotion ending on an occupied cell captures the piece there;
+// Apply returns it as it goes to the hand of the capturer: flipped to the
+// other side and normalized, "+p" becoming "P".
+//
+// Transition is an immutable value type, comparable with ==.
+type Transition struct {
+	from, to      Cell
+	before, after Identifier
+	drop          bool
+}
+
+// NewTransition creates the Transition of a piece moving from one cell to
+// another, before becoming after, as in a promotion.
+//
+// Panics with ErrInvalidIdentifier if an identifier is not valid, with
+// ErrInvalidCell if a cell is out of range, or with ErrInvalidTransition if
+// the identifiers have different sides or the cells are the same.
+func NewTransition(from, to Cell, before, after Identifier) Transition {
+	t := Transition{from: from, to: to, before: before, after: after}
+	if err := t.validate(); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// NewMove creates the Transition of id moving from one cell to another.
+// It panics as NewTransition does.
+func NewMove(from, to Cell, id Identifier) Transition {
+	return NewTransition(from, to, id, id)
+}
+
+// NewDrop creates the Transition of id dropped from hand on a cell.
+//
+// Panics with ErrInvalidIdentifier if id is not valid, or with
+// ErrInvalidCell if the cell is out of range.
+func NewDrop(to Cell, id Identifier) Transition {
+	t := Transition{to: to, before: id, after: id, drop: true}
+	if err := t.validate(); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// validate returns the sentinel error describing why t is not valid, or
+// nil.
+func (t Transition) validate() error {
+	switch {
+	case !t.before.isValid() || !t.after.isValid():
+		return ErrInvalidIdentifier
+	case !t.to.isValid() || (!t.drop && !t.from.isValid()):
+		return ErrInvalidCell
+	case !t.before.SameSide(t.after) || (!t.drop && t.from == t.to):
+		return ErrInvalidTransition
+	default:
+		return nil
+	}
+}
+
+// ParseTransition converts the string form of a Transition into a
+// Transition: "P@e2>P@e4" for a move, "P@e7>+P@e8" for a promotion, and
+// "P@e5" for a drop, each part being a PIN and a CELL coordinate separated
+// by '@'.
+//
+// Returns a *SyntaxError if the string is not valid, with offsets counted
+// from the start of s, wrapping one of:
+//   - ErrInvalidTransition: a part without '@', identifiers of different
+//     sides, or a move to its own source cell
+//   - the parsing errors of Parse and ParseCell, for the parts
+func ParseTransition(s string) (Transition, error) {
+	sep := strings.IndexByte(s, transitionSeparator)
+	if sep < 0 {
+		id, to, err := parseTransitionPart(s, 0, len(s))
+		if err != nil {
+			return Transition{}, err
+		}
+		return Transition{to: to, before: id, after: id, drop: true}, nil
+	}
+
+	before, from, err := parseTransitionPart(s, 0, sep)
+	if err != nil {
+		return Transition{}, err
+	}
+	after, to, err := parseTransitionPart(s, sep+1, len(s))
+	if err != nil {
+		return Transition{}, err
+	}
+
+	t := Transition{from: from, to: to, before: before, after: after}
+	if t.validate() != nil {
+		return Transition{}, newSyntaxError(s, sep+1, ErrInvalidTransition)
+	}
+	return t, nil
+}
+
+// MustParseTransition is like ParseTransition but panics on error.
+// Use for constants or trusted input.
+func MustParseTransition(s string) Transition {
+	t, err := ParseTransition(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// parseTransitionPart parses the identifier and cell of the part
+// s[start:end] of a transition.
+func parseTransitionPart(s string, start, end int) (Identifier, Cell, error) {
+	at := strings.IndexByte(s[start:end], cellSeparator)
+	if at < 0 {
+		return Identifier{}, Cell{}, newSyntaxError(s, end, ErrInvalidTransition)
+	}
+	at += start
+
+	id, err := Parse(s[start:at])
+	if err != nil {
+		// Locate the error in s rather than in the part
+		se := err.(*SyntaxError)
+		return Identifier{}, Cell{}, newSyntaxError(s, start+se.Offset, se.Err)
+	}
+	c, err := ParseCell(s[at+1 : end])
+	if err != nil {
+		se := err.(*SyntaxError)
+		return Identifier{}, Cell{}, newSyntaxError(s, at+1+se.Offset, se.Err)
+	}
+
+	return id, c, nil
+}
+
+// From returns the source cell of the transition, and false for a drop.
+func (t Transition) From() (Cell, bool) {
+	return t.from, !t.drop
+}
+
+// To returns the destination cell of the transition.
+func (t Transition) To() Cell {
+	return t.to
+}
+
+// Before returns the identifier of the piece before the transition.
+func (t Transition) Before() Identifier {
+	return t.before
+}
+
+// After returns the identifier of the piece after the transition.
+func (t Transition) After() Identifier {
+	return t.after
+}
+
+// IsDrop reports whether the transition drops a piece from hand.
+func (t Transition) IsDrop() bool {
+	return t.drop
+}
+
+// IsPromotion reports whether the piece changes identifier as it moves, as
+// when "P" becomes "+P".
+func (t Transition) IsPromotion() bool {
+	return t.before != t.after
+}
+
+// IsZero reports whether t is the zero value, which is not a valid
+// Transition.
+func (t Transition) IsZero() bool {
+	return t == Transition{}
+}
+
+// Apply performs the transition on the board, and returns the captured
+// piece as it goes to the hand of the capturer, flipped and normalized, and
+// whether a piece was captured. Dropped pieces are not taken from any hand;
+// callers holding hands update them.
+//
+// Returns ErrInvalidTransition for the zero value, ErrInvalidSquare if a
+// cell is off the board, or ErrTransitionMismatch if the source cell does
+// not hold the identifier before the transition, the destination of a drop
+// is occupied, or the destination holds a piece of the mover's side. The
+// board is unchanged on error.
+func (t Transition) Apply(b *Board) (captured Identifier, ok bool, err error) {
+	if t.IsZero() {
+		return Identifier{}, false, ErrInvalidTransition
+	}
+	if !b.ContainsCell(t.to) || (!t.drop && !b.ContainsCell(t.from)) {
+		return Identifier{}, false, ErrInvalidSquare
+	}
+
+	target, occupied := b.GetCell(t.to)
+	if occupied && (t.drop || target.SameSide(t.before)) {
+		return Identifier{}, false, ErrTransitionMismatch
+	}
+	if !t.drop {
+		if id, _ := b.GetCell(t.from); id != t.before {
+			return Identifier{}, false, ErrTransitionMismatch
+		}
+		b.RemoveCell(t.from)
+	}
+
+	b.SetCell(t.to, t.after)
+	if !occupied {
+		return Identifier{}, false, nil
+	}
+	return target.Flip().Normalize(), true, nil
+}
+
+// String returns the string form of the transition, such as "P@e7>+P@e8".
+func (t Transition) String() string {
+	return string(t.AppendTo(make([]byte, 0, 2*(MaxStringLength+8)+1)))
+}
+
+// AppendTo appends the string form of the transition to dst and returns the
+// result.
+func (t Transition) AppendTo(dst []byte) []byte {
+	if !t.drop {
+		dst = t.before.AppendTo(dst)
+		dst = append(dst, cellSeparator)
+		dst = t.from.AppendTo(dst)
+		dst = append(dst, transitionSeparator)
+	}
+	dst = t.after.AppendTo(dst)
+	dst = append(dst, cellSeparator)
+	return t.to.AppendTo(dst)
+}
+
+// MarshalText returns the string form of the transition, implementing
+// encoding.TextMarshaler.
+//
+// Returns ErrInvalidTransition for the zero value.
+func (t Transition) MarshalText() ([]byte, error) {
+	if t.IsZero() {
+		return nil, ErrInvalidTransition
+	}
+	return t.AppendTo(nil), nil
+}
+
+// UnmarshalText parses the string form of a transition, implementing
+// encoding.TextUnmarshaler.
+func (t *Transition) UnmarshalText(text []byte) error {
+	parsed, err := ParseTransition(string(text))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,191 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseTransition(t *testing.T) {
+	tests := []struct {
+		input     string
+		from      string
+		to        string
+		before    string
+		after     string
+		drop      bool
+		promotion bool
+	}{
+		{"P@e2>P@e4", "e2", "e4", "P", "P", false, false},
+		{"P@e7>+P@e8", "e7", "e8", "P", "+P", false, true},
+		{"k^@e8>k^@g8", "e8", "g8", "k^", "k^", false, false},
+		{"p@e5", "", "e5", "p", "p", true, false},
+		{"+R@aa10>-R@a1", "aa10", "a1", "+R", "-R", false, true},
+	}
+
+	for _, tt := range tests {
+		tr, err := ParseTransition(tt.input)
+		if err != nil {
+			t.Errorf("ParseTransition(%q) error = %v", tt.input, err)
+			continue
+		}
+
+		from, ok := tr.From()
+		if ok == tt.drop || (ok && from != MustParseCell(tt.from)) || tr.IsDrop() != tt.drop {
+			t.Errorf("ParseTransition(%q).From() = %v, %v", tt.input, from, ok)
+		}
+		if tr.To() != MustParseCell(tt.to) {
+			t.Errorf("ParseTransition(%q).To() = %v, want %s", tt.input, tr.To(), tt.to)
+		}
+		if tr.Before() != MustParse(tt.before) || tr.After() != MustParse(tt.after) {
+			t.Errorf("ParseTransition(%q) = %v to %v, want %s to %s", tt.input, tr.Before(), tr.After(), tt.before, tt.after)
+		}
+		if tr.IsPromotion() != tt.promotion {
+			t.Errorf("ParseTransition(%q).IsPromotion() = %v, want %v", tt.input, tr.IsPromotion(), tt.promotion)
+		}
+		if got := tr.String(); got != tt.input {
+			t.Errorf("ParseTransition(%q).String() = %q", tt.input, got)
+		}
+	}
+}
+
+func TestParseTransitionErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		err    error
+	}{
+		{"", 0, ErrInvalidTransition},
+		{"Pe4", 3, ErrInvalidTransition},
+		{"P@e2>Pe4", 8, ErrInvalidTransition},
+		{"P@e2>p@e4", 5, ErrInvalidTransition},
+		{"P@e2>P@e2", 5, ErrInvalidTransition},
+		{"@e4", 0, ErrEmptyInput},
+		{"P+@e4", 1, ErrInvalidTerminalMarker},
+		{"P@e2>P@e0", 8, ErrInvalidCell},
+		{"P@E2>P@e4", 2, ErrInvalidCell},
+		{"P@e2>P@e4>P@e6", 9, ErrInvalidCell},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseTransition(tt.input)
+
+		var se *SyntaxError
+		if !errors.As(err, &se) || !errors.Is(err, tt.err) {
+			t.Errorf("ParseTransition(%q) error = %v, want %v", tt.input, err, tt.err)
+			continue
+		}
+		if se.Offset != tt.offset {
+			t.Errorf("ParseTransition(%q) offset = %d, want %d", tt.input, se.Offset, tt.offset)
+		}
+	}
+}
+
+func TestNewTransition(t *testing.T) {
+	e7, e8 := MustParseCell("e7"), MustParseCell("e8")
+
+	if got := NewTransition(e7, e8, MustParse("P"), MustParse("+P")); got != MustParseTransition("P@e7>+P@e8") {
+		t.Errorf("NewTransition() = %v, want P@e7>+P@e8", got)
+	}
+	if got := NewMove(e7, e8, MustParse("r")); got != MustParseTransition("r@e7>r@e8") {
+		t.Errorf("NewMove() = %v, want r@e7>r@e8", got)
+	}
+	if got := NewDrop(e8, MustParse("S")); got != MustParseTransition("S@e8") {
+		t.Errorf("NewDrop() = %v, want S@e8", got)
+	}
+
+	tests := []struct {
+		name string
+		fn   func()
+		want error
+	}{
+		{"zero identifier", func() { NewMove(e7, e8, Identifier{}) }, ErrInvalidIdentifier},
+		{"zero drop", func() { NewDrop(e8, Identifier{}) }, ErrInvalidIdentifier},
+		{"negative cell", func() { NewMove(Cell{File: -1}, e8, MustParse("P")) }, ErrInvalidCell},
+		{"side change", func() { NewTransition(e7, e8, MustParse("P"), MustParse("p")) }, ErrInvalidTransition},
+		{"same cell", func() { NewMove(e7, e7, MustParse("P")) }, ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s: panic = %v, want %v", tt.name, r, tt.want)
+				}
+			}()
+			tt.fn()
+		}()
+	}
+}
+
+func TestTransitionApply(t *testing.T) {
+	b, _ := MustParseFEEN("2+b1/1P2/2p1/4 / C/c").Board()
+
+	// Promotion capturing a promoted piece, which goes to hand normalized
+	captured, ok, err := MustParseTransition("P@b3>+P@c4").Apply(b)
+	if err != nil {
+		t.Fatalf("Apply(promotion) error = %v", err)
+	}
+	if want := "2+P1/4/2p1/4"; !ok || captured != MustParse("B") || placementString(b) != want {
+		t.Errorf("Apply(promotion) = %v, %v, board %s, want B, true, board %s", captured, ok, placementString(b), want)
+	}
+
+	captured, ok, err = MustParseTransition("+P@c4>+P@c2").Apply(b)
+	if err != nil || !ok || captured != MustParse("P") {
+		t.Errorf("Apply(capture) = %v, %v, %v, want P, true, nil", captured, ok, err)
+	}
+
+	// Drop on an empty square
+	captured, ok, err = MustParseTransition("S@a1").Apply(b)
+	if err != nil || ok || !captured.IsZero() {
+		t.Errorf("Apply(drop) = %v, %v, %v, want zero, false, nil", captured, ok, err)
+	}
+	if want := "4/4/2+P1/S3"; placementString(b) != want {
+		t.Errorf("board after drop = %s, want %s", placementString(b), want)
+	}
+}
+
+func TestTransitionApplyErrors(t *testing.T) {
+	tests := []struct {
+		transition Transition
+		err        error
+	}{
+		{Transition{}, ErrInvalidTransition},
+		{MustParseTransition("K@a1>K@e1"), ErrInvalidSquare},
+		{MustParseTransition("K@a1>K@a2"), ErrTransitionMismatch},
+		{MustParseTransition("+P@a2>+P@b2"), ErrTransitionMismatch},
+		{MustParseTransition("P@a2>P@b2"), ErrTransitionMismatch},
+		{MustParseTransition("S@b2"), ErrTransitionMismatch},
+	}
+
+	for _, tt := range tests {
+		b, _ := MustParseFEEN("4/4/PP2/4 / C/c").Board()
+
+		if _, _, err := tt.transition.Apply(b); err != tt.err {
+			t.Errorf("%v.Apply() error = %v, want %v", tt.transition, err, tt.err)
+		}
+		if got := placementString(b); got != "4/4/PP2/4" {
+			t.Errorf("%v.Apply() modified the board to %s", tt.transition, got)
+		}
+	}
+}
+
+func TestTransitionText(t *testing.T) {
+	if _, err := (Transition{}).MarshalText(); err != ErrInvalidTransition {
+		t.Errorf("MarshalText(zero) error = %v, want ErrInvalidTransition", err)
+	}
+
+	var tr Transition
+	if err := tr.UnmarshalText([]byte("P@e2>P@e4")); err != nil || tr != NewMove(MustParseCell("e2"), MustParseCell("e4"), MustParse("P")) {
+		t.Errorf("UnmarshalText() = %v, %v", tr, err)
+	}
+	if text, err := tr.MarshalText(); err != nil || string(text) != "P@e2>P@e4" {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+}
+
+// placementString returns the FEEN piece placement of b.
+func placementString(b *Board) string {
+	g := GameState{Placement: b.Placement(), Active: MustParseStyle("C"), Inactive: MustParseStyle("c")}
+	s := g.String()
+	return s[:len(s)-len(" / C/c")]
+}
@@ -0,0 +1,105 @@
+package pin
+
+import "strings"
+
+// xiangqiStyle is the SNN style name of the sides of a xiangqi position.
+const xiangqiStyle = "XIANGQI"
+
+// xiangqiLetter returns the letter of XiangqiProfile for the uppercase
+// piece letter c of a xiangqi FEN dialect, or 0 if c is not a piece.
+//
+// The WXF and UCCI dialects write the pieces "KABNRCP", others "KAEHRCP";
+// FEEN writes them "GAEHRCS".
+func xiangqiLetter(c byte) byte {
+	switch c {
+	case 'K', 'G':
+		return 'G'
+	case 'A':
+		return 'A'
+	case 'B', 'E':
+		return 'E'
+	case 'N', 'H':
+		return 'H'
+	case 'R':
+		return 'R'
+	case 'C':
+		return 'C'
+	case 'P', 'S':
+		return 'S'
+	default:
+		return 0
+	}
+}
+
+// FromXiangqiFEN converts a xiangqi FEN string of one of the common
+// dialects, such as the WXF and UCCI form
+//
+//	rnbakabnr/9/1c5c1/p1p1p1p1p/9/9/P1P1P1P1P/1C5C1/9/RNBAKABNR w - - 0 1
+//
+// into a GameState with the letters of XiangqiProfile, so that the example
+// above becomes
+//
+//	rheag^aehr/9/1c5c1/s1s1s1s1s/9/9/S1S1S1S1S/1C5C1/9/RHEAG^AEHR / XIANGQI/xiangqi
+//
+// Elephants may be written "B" or "E", horses "N" or "H", generals "K" or
+// "G", and soldiers "P" or "S", uppercase for red, the first side, and
+// lowercase for black. Generals are terminal pieces. The side to move is
+// "w" or "r" for red and "b" for black, red when absent; the fields after
+// it, unused in xiangqi, are ignored.
+//
+// Invalid input returns a *DecodeError locating the offending part of s,
+// wrapping one of:
+//   - ErrAbbrNotInProfile: a letter that is not a xiangqi piece
+//   - ErrInvalidCount: a count of empty squares with a leading zero
+//   - ErrInvalidPlacement: any other byte in the placement, an empty rank,
+//     ranks of different lengths, or a board other than 10 ranks of 9
+//     files
+//   - ErrInvalidTurn: a side to move other than "w", "r", or "b"
+func FromXiangqiFEN(s string) (*GameState, error) {
+	fields := strings.Split(s, " ")
+	placement := fields[0]
+
+	// Rewrite the dialect's letters in place, so offsets are kept
+	buf := []byte(placement)
+	for i, c := range buf {
+		lower := c >= 'a' && c <= 'z'
+		if !lower && (c < 'A' || c > 'Z') {
+			continue
+		}
+
+		t := xiangqiLetter(c &^ ('a' - 'A'))
+		if t == 0 {
+			return nil, segmentError(s, i, i+1, ErrAbbrNotInProfile)
+		}
+		if lower {
+			t += 'a' - 'A'
+		}
+		buf[i] = t
+	}
+
+	ranks, err := parseProfilePlacement(string(buf), XiangqiProfile, 'G')
+	if err != nil {
+		// Echo the token as written in s
+		de := err.(*DecodeError)
+		return nil, segmentError(s, int(de.Offset), int(de.Offset)+len(de.Token), de.Err)
+	}
+	if rows, cols := XiangqiProfile.BoardSize(); len(ranks) != rows || len(ranks[0]) != cols {
+		return nil, segmentError(s, 0, len(placement), ErrInvalidPlacement)
+	}
+
+	turn := First
+	if len(fields) > 1 {
+		switch fields[1] {
+		case "w", "r":
+		case "b":
+			turn = Second
+		default:
+			start := len(placement) + 1
+			return nil, segmentError(s, start, start+len(fields[1]), ErrInvalidTurn)
+		}
+	}
+
+	g := &GameState{Placement: ranks, Active: NewStyle(xiangqiStyle, turn)}
+	g.Inactive = g.Active.Flip()
+	return g, nil
+}
@@ -0,0 +1,105 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+)
+
+const xiangqiFEEN = "rheag^aehr/9/1c5c1/s1s1s1s1s/9/9/S1S1S1S1S/1C5C1/9/RHEAG^AEHR / XIANGQI/xiangqi"
+
+func TestFromXiangqiFEN(t *testing.T) {
+	tests := []string{
+		"rnbakabnr/9/1c5c1/p1p1p1p1p/9/9/P1P1P1P1P/1C5C1/9/RNBAKABNR w - - 0 1",
+		"rheakaehr/9/1c5c1/p1p1p1p1p/9/9/P1P1P1P1P/1C5C1/9/RHEAKAEHR r",
+		"rheagaehr/9/1c5c1/s1s1s1s1s/9/9/S1S1S1S1S/1C5C1/9/RHEAGAEHR",
+	}
+
+	for _, fen := range tests {
+		g, err := FromXiangqiFEN(fen)
+		if err != nil {
+			t.Errorf("FromXiangqiFEN(%q) error = %v", fen, err)
+			continue
+		}
+		if got := g.String(); got != xiangqiFEEN {
+			t.Errorf("FromXiangqiFEN(%q) = %q, want %q", fen, got, xiangqiFEEN)
+		}
+	}
+}
+
+func TestFromXiangqiFENBlackToMove(t *testing.T) {
+	g, err := FromXiangqiFEN("3k5/9/9/9/9/9/9/9/9/4K4 b - - 0 40")
+	if err != nil {
+		t.Fatalf("FromXiangqiFEN() error = %v", err)
+	}
+	if g.Turn() != Second || g.Active != MustParseStyle("xiangqi") {
+		t.Errorf("FromXiangqiFEN() active = %v, want xiangqi", g.Active)
+	}
+
+	b, err := g.Board()
+	if err != nil {
+		t.Fatalf("Board() error = %v", err)
+	}
+	if got, _ := b.GetCell(MustParseCell("e1")); got != MustParse("G^") {
+		t.Errorf("GetCell(e1) = %v, want G^", got)
+	}
+	if got, _ := b.GetCell(MustParseCell("d10")); got != MustParse("g^") {
+		t.Errorf("GetCell(d10) = %v, want g^", got)
+	}
+}
+
+func TestFromXiangqiFENErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int64
+		token  string
+		err    error
+	}{
+		{"rnbqkbnr/9/9/9/9/9/9/9/9/9", 3, "q", ErrAbbrNotInProfile},
+		{"9/9/9/9/9/9/9/9/9", 0, "9/9/9/9/9/9/9/9/9", ErrInvalidPlacement},
+		{"8/8/8/8/8/8/8/8/8/8", 0, "8/8/8/8/8/8/8/8/8/8", ErrInvalidPlacement},
+		{"9/9/9/9/9/9/9/9/9/9N", 18, "9N", ErrInvalidPlacement},
+		{"9/9/9/9/9/9/9/9/9/+P8", 18, "+P", ErrInvalidPlacement},
+		{"9/9/9/9/9/9/9/9/9/09", 18, "09", ErrInvalidCount},
+		{"9/9/9/9/9/9/9/9/9/9 x - - 0 1", 20, "x", ErrInvalidTurn},
+	}
+
+	for _, tt := range tests {
+		_, err := FromXiangqiFEN(tt.input)
+
+		var de *DecodeError
+		if !errors.As(err, &de) || !errors.Is(err, tt.err) {
+			t.Errorf("FromXiangqiFEN(%q) error = %v, want %v", tt.input, err, tt.err)
+			continue
+		}
+		if de.Offset != tt.offset || de.Token != tt.token {
+			t.Errorf("FromXiangqiFEN(%q) error at %d %q, want %d %q", tt.input, de.Offset, de.Token, tt.offset, tt.token)
+		}
+	}
+}
+
+func TestXiangqiProfile(t *testing.T) {
+	if rows, cols := XiangqiProfile.BoardSize(); rows != 10 || cols != 9 {
+		t.Errorf("BoardSize() = %d, %d, want 10, 9", rows, cols)
+	}
+	if got := XiangqiProfile.Abbrs(); got != "ACEGHRS" {
+		t.Errorf("Abbrs() = %q, want ACEGHRS", got)
+	}
+	for _, id := range []string{"S", "g^", "H"} {
+		if XiangqiProfile.IsPromotable(MustParse(id)) {
+			t.Errorf("IsPromotable(%s) = true, want false", id)
+		}
+	}
+	if got := XiangqiProfile.Glyph(MustParse("g")); got != "將" {
+		t.Errorf("Glyph(g) = %q, want 將", got)
+	}
+
+	// The starting position fits the profile
+	g := MustParseFEEN(xiangqiFEEN)
+	for _, rank := range g.Placement {
+		for _, id := range rank {
+			if !id.IsZero() && !XiangqiProfile.Allows(id) {
+				t.Errorf("XiangqiProfile does not allow %v", id)
+			}
+		}
+	}
+}
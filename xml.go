@@ -0,0 +1,36 @@
+package pin
+
+import "encoding/xml"
+
+// MarshalXMLAttr encodes the Identifier as an XML attribute holding its PIN
+// string, implementing xml.MarshalerAttr:
+//
+//	type Move struct {
+//		Piece pin.Identifier `xml:"piece,attr"` // <Move piece="+K^">
+//	}
+//
+// The zero value produces no attribute, so unset fields are omitted.
+func (id Identifier) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if id.IsZero() {
+		return xml.Attr{}, nil
+	}
+
+	text, err := id.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr parses an XML attribute holding a PIN string,
+// implementing xml.UnmarshalerAttr. Invalid values return the parsing
+// sentinels.
+func (id *Identifier) UnmarshalXMLAttr(attr xml.Attr) error {
+	parsed, err := Parse(attr.Value)
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
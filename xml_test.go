@@ -0,0 +1,75 @@
+package pin
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+var (
+	_ xml.MarshalerAttr   = Identifier{}
+	_ xml.UnmarshalerAttr = (*Identifier)(nil)
+)
+
+type xmlMove struct {
+	XMLName  xml.Name   `xml:"move"`
+	Piece    Identifier `xml:"piece,attr"`
+	Captured Identifier `xml:"captured,attr"`
+	To       string     `xml:"to,attr"`
+}
+
+func TestMarshalXMLAttr(t *testing.T) {
+	in := xmlMove{Piece: MustParse("+K^"), To: "e4"}
+
+	data, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("xml.Marshal() error = %v", err)
+	}
+	if want := `<move piece="+K^" to="e4"></move>`; string(data) != want {
+		t.Errorf("xml.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestUnmarshalXMLAttr(t *testing.T) {
+	var out xmlMove
+	if err := xml.Unmarshal([]byte(`<move piece="-p" captured="R^" to="d5"/>`), &out); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if out.Piece.String() != "-p" || out.Captured.String() != "R^" || out.To != "d5" {
+		t.Errorf("xml.Unmarshal() = %+v", out)
+	}
+}
+
+func TestXMLAttrRoundTrip(t *testing.T) {
+	for i := 0; i < identifierCount; i++ {
+		in := xmlMove{Piece: fromIndex(i)}
+
+		data, err := xml.Marshal(in)
+		if err != nil {
+			t.Fatalf("xml.Marshal(%s) error = %v", in.Piece, err)
+		}
+
+		var out xmlMove
+		if err := xml.Unmarshal(data, &out); err != nil || out.Piece != in.Piece {
+			t.Errorf("XML round trip of %s = %s, %v", in.Piece, out.Piece, err)
+		}
+	}
+}
+
+func TestUnmarshalXMLAttrErrors(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want error
+	}{
+		{`<move piece=""/>`, ErrEmptyInput},
+		{`<move piece="K+"/>`, ErrInvalidTerminalMarker},
+		{`<move piece="King"/>`, ErrInputTooLong},
+	}
+
+	for _, tt := range tests {
+		var out xmlMove
+		if err := xml.Unmarshal([]byte(tt.doc), &out); !errors.Is(err, tt.want) {
+			t.Errorf("xml.Unmarshal(%s) error = %v, want %v", tt.doc, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package pin
+
+// ZobristTable assigns a pseudo-random 64-bit key to every pair of a valid
+// identifier and a square index, for Zobrist hashing of positions.
+//
+// The hash of a position is the XOR of the keys of its pieces, so moving,
+// adding, or removing a piece updates it incrementally with one or two
+// XORs. Keys derive deterministically from the seed with the splitmix64
+// generator: a given seed yields the same table on every platform and in
+// every version of this package, so hashes may be persisted.
+//
+// A ZobristTable is immutable and safe for concurrent use.
+type ZobristTable struct {
+	squares int
+	keys    []uint64 // by square, then code
+}
+
+// NewZobristTable returns the table for a board of the given number of
+// squares, with keys derived from seed.
+//
+// Panics with ErrInvalidSquare if squares is not positive.
+func NewZobristTable(squares int, seed uint64) *ZobristTable {
+	if squares <= 0 {
+		panic(ErrInvalidSquare)
+	}
+
+	keys := make([]uint64, squares*identifierCount)
+	state := seed
+	for i := range keys {
+		keys[i] = splitmix64(&state)
+	}
+	return &ZobristTable{squares: squares, keys: keys}
+}
+
+// splitmix64 advances state and returns the next output of the splitmix64
+// generator.
+func splitmix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// Squares returns the number of squares covered by the table.
+func (z *ZobristTable) Squares() int {
+	return z.squares
+}
+
+// Key returns the key of id standing on square.
+//
+// Panics with ErrInvalidIdentifier if id is not valid, or with
+// ErrInvalidSquare if square is not in the range [0, Squares()).
+func (z *ZobristTable) Key(id Identifier, square int) uint64 {
+	if !id.isValid() {
+		panic(ErrInvalidIdentifier)
+	}
+	if square < 0 || square >= z.squares {
+		panic(ErrInvalidSquare)
+	}
+	return z.keys[square*identifierCount+id.index()]
+}
+
+// Toggle returns hash with id added to square, or removed from it if it
+// stands there: XOR is its own inverse.
+func (z *ZobristTable) Toggle(hash uint64, id Identifier, square int) uint64 {
+	return hash ^ z.Key(id, square)
+}
+
+// Move returns hash with id moved from one square to another.
+func (z *ZobristTable) Move(hash uint64, id Identifier, from, to int) uint64 {
+	return hash ^ z.Key(id, from) ^ z.Key(id, to)
+}
+
+// Replace returns hash with the piece on square changed from old to next,
+// as on a capture or a promotion.
+func (z *ZobristTable) Replace(hash uint64, square int, old, next Identifier) uint64 {
+	return hash ^ z.Key(old, square) ^ z.Key(next, square)
+}
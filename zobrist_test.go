@@ -0,0 +1,103 @@
+package pin
+
+import "testing"
+
+func TestZobristTableIsDeterministic(t *testing.T) {
+	a := NewZobristTable(64, 42)
+	b := NewZobristTable(64, 42)
+	c := NewZobristTable(64, 43)
+
+	k := MustParse("K")
+	if a.Key(k, 4) != b.Key(k, 4) {
+		t.Error("same seed gave different keys")
+	}
+	if a.Key(k, 4) == c.Key(k, 4) {
+		t.Error("different seeds gave the same key")
+	}
+}
+
+// Keys are persisted by callers; these values must never change.
+func TestZobristTableStableKeys(t *testing.T) {
+	z := NewZobristTable(81, 0)
+
+	tests := []struct {
+		pin    string
+		square int
+		want   uint64
+	}{
+		{"A", 0, 0xe220a8397b1dcdaf},
+		{"A^", 0, 0x6e789e6aa1b965f4},
+	}
+
+	for _, tt := range tests {
+		if got := z.Key(MustParse(tt.pin), tt.square); got != tt.want {
+			t.Errorf("Key(%q, %d) = %#x, want %#x", tt.pin, tt.square, got, tt.want)
+		}
+	}
+}
+
+func TestZobristTableKeysAreDistinct(t *testing.T) {
+	z := NewZobristTable(81, 1)
+
+	seen := make(map[uint64]bool)
+	for sq := 0; sq < z.Squares(); sq++ {
+		for _, id := range All() {
+			k := z.Key(id, sq)
+			if seen[k] {
+				t.Fatalf("duplicate key %#x for %v on %d", k, id, sq)
+			}
+			seen[k] = true
+		}
+	}
+}
+
+func TestZobristTableIncrementalUpdates(t *testing.T) {
+	z := NewZobristTable(64, 7)
+	k, p, q := MustParse("K"), MustParse("p"), MustParse("q")
+
+	// Position: K on 4, p on 12.
+	h := z.Toggle(z.Toggle(0, k, 4), p, 12)
+
+	// K moves from 4 to 5.
+	moved := z.Move(h, k, 4, 5)
+	if want := z.Key(k, 5) ^ z.Key(p, 12); moved != want {
+		t.Errorf("Move() = %#x, want %#x", moved, want)
+	}
+
+	// p promotes to q on 12.
+	promoted := z.Replace(moved, 12, p, q)
+	if want := z.Key(k, 5) ^ z.Key(q, 12); promoted != want {
+		t.Errorf("Replace() = %#x, want %#x", promoted, want)
+	}
+
+	// Removing every piece gives back the empty hash.
+	if got := z.Toggle(z.Toggle(promoted, k, 5), q, 12); got != 0 {
+		t.Errorf("empty position hash = %#x, want 0", got)
+	}
+}
+
+func TestZobristTablePanics(t *testing.T) {
+	z := NewZobristTable(9, 0)
+
+	tests := []struct {
+		name string
+		fn   func()
+		want error
+	}{
+		{"zero squares", func() { NewZobristTable(0, 0) }, ErrInvalidSquare},
+		{"negative square", func() { z.Key(MustParse("K"), -1) }, ErrInvalidSquare},
+		{"square out of range", func() { z.Key(MustParse("K"), 9) }, ErrInvalidSquare},
+		{"zero identifier", func() { z.Key(Identifier{}, 0) }, ErrInvalidIdentifier},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s: panic = %v, want %v", tt.name, r, tt.want)
+				}
+			}()
+			tt.fn()
+		}()
+	}
+}